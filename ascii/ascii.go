@@ -7,51 +7,145 @@ ASCII
 package ascii
 
 import (
-	"fmt"
+	"math/big"
 	"strconv"
+	"strings"
 	"unicode/utf8"
 
 	"github.com/clarketm/ncalc/decimal"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
 )
 
-// Ascii2Binary (s string) string
+// Ascii2Binary (s string) string - Ascii2BinaryE, but panics if it fails
 func Ascii2Binary(s string) string {
-	s = strconv.Itoa(ValueOf(s))
-	return decimal.Decimal2Binary(s)
+	r, err := Ascii2BinaryE(s)
+	utils.CheckError(err, utils.ASCII, utils.BINARY)
+	return r
 }
 
-// Ascii2Octal (s string) string
+// Ascii2BinaryE (s string) (string, error)
+func Ascii2BinaryE(s string) (string, error) {
+	return each(s, decimal.Decimal2BinaryE)
+}
+
+// Ascii2Octal (s string) string - Ascii2OctalE, but panics if it fails
 func Ascii2Octal(s string) string {
-	s = strconv.Itoa(ValueOf(s))
-	return decimal.Decimal2Octal(s)
+	r, err := Ascii2OctalE(s)
+	utils.CheckError(err, utils.ASCII, utils.OCTAL)
+	return r
 }
 
-// Ascii2Decimal (s string) string
+// Ascii2OctalE (s string) (string, error)
+func Ascii2OctalE(s string) (string, error) {
+	return each(s, decimal.Decimal2OctalE)
+}
+
+// Ascii2Decimal (s string) string - Ascii2DecimalE, but panics if it fails
 func Ascii2Decimal(s string) string {
-	s = strconv.Itoa(ValueOf(s))
-	return decimal.String(s)
+	r, err := Ascii2DecimalE(s)
+	utils.CheckError(err, utils.ASCII, utils.DECIMAL)
+	return r
+}
+
+// Ascii2DecimalE (s string) (string, error)
+func Ascii2DecimalE(s string) (string, error) {
+	return each(s, decimal.StringE)
 }
 
-// Ascii2Hexadecimal (s string) string
+// Ascii2Hexadecimal (s string) string - Ascii2HexadecimalE, but panics if it fails
 func Ascii2Hexadecimal(s string) string {
-	s = strconv.Itoa(ValueOf(s))
-	return decimal.Decimal2Hexadecimal(s)
+	r, err := Ascii2HexadecimalE(s)
+	utils.CheckError(err, utils.ASCII, utils.HEXADECIMAL)
+	return r
+}
+
+// Ascii2HexadecimalE (s string) (string, error)
+func Ascii2HexadecimalE(s string) (string, error) {
+	return each(s, decimal.Decimal2HexadecimalE)
 }
 
-// String (s string) string
+// String (s string) string - StringE, but panics if it fails
 func String(s string) string {
-	return fmt.Sprintf("%q", ValueOf(s))
+	r, err := StringE(s)
+	utils.CheckError(err, utils.STRING, utils.ASCII)
+	return r
+}
+
+// StringE (s string) (string, error)
+func StringE(s string) (string, error) {
+	v, err := ValuesOfE(s)
+	if err != nil {
+		return "", err
+	}
+	codes := make([]*big.Int, len(v))
+	for i, c := range v {
+		codes[i] = big.NewInt(int64(c))
+	}
+	return utils.QuoteASCII(codes)
 }
 
-// ValueOf (s string) int
+// ValueOf (s string) int - ValueOfE, but panics if it fails
 func ValueOf(s string) int {
-	// if utils.IsLiteral(s) {
-	// 	ss, _ := strconv.ParseInt(s, 0, 0)
-	// }
-	s, _ = strconv.Unquote(`"` + s + `"`)
-	r, _ := utf8.DecodeRuneInString(s)
-	return int(r)
+	v, err := ValueOfE(s)
+	utils.CheckError(err, utils.STRING, utils.ASCII)
+	return v
 }
 
-// ValueOf (s string) rune
-// TODO
+// ValueOfE (s string) (int, error) - the code of a single character
+func ValueOfE(s string) (int, error) {
+	v, err := ValuesOfE(s)
+	if err != nil {
+		return 0, err
+	}
+	if len(v) > 1 {
+		return 0, i18n.Errorf("%q is %d characters, not one", s, len(v))
+	}
+	return v[0], nil
+}
+
+// ValuesOf (s string) []int - ValuesOfE, but panics if it fails
+func ValuesOf(s string) []int {
+	v, err := ValuesOfE(s)
+	utils.CheckError(err, utils.STRING, utils.ASCII)
+	return v
+}
+
+// ValuesOfE (s string) ([]int, error) - the code of every character, after Unescape
+func ValuesOfE(s string) ([]int, error) {
+	if s == "" {
+		return nil, &strconv.NumError{Func: "ValueOf", Num: s, Err: strconv.ErrSyntax}
+	}
+
+	var v []int
+	for i, r := range []rune(Unescape(s)) {
+		if r >= utf8.RuneSelf {
+			return nil, &utils.ErrNotASCII{Pos: i + 1, Code: big.NewInt(int64(r))}
+		}
+		v = append(v, int(r))
+	}
+	return v, nil
+}
+
+// Unescape (s string) string - expands Go escapes such as \n and \x41, or returns s unchanged if it has invalid ones
+func Unescape(s string) string {
+	if u, err := strconv.Unquote(`"` + s + `"`); err == nil {
+		return u
+	}
+	return s
+}
+
+// each (s string, convert func(string) (string, error)) (string, error) - converts the code of every character, space-separated
+func each(s string, convert func(string) (string, error)) (string, error) {
+	v, err := ValuesOfE(s)
+	if err != nil {
+		return "", err
+	}
+	out := make([]string, len(v))
+	for i, c := range v {
+		if out[i], err = convert(strconv.Itoa(c)); err != nil {
+			return "", err
+		}
+	}
+	return strings.Join(out, " "), nil
+}
@@ -30,3 +30,20 @@ func Example() {
 	// 64
 	// 'd':string
 }
+
+func Example_text() {
+	// whole strings convert one character at a time
+	s := "Hello"
+	fmt.Println(ascii.Ascii2Hexadecimal(s))
+	fmt.Println(ascii.Ascii2Decimal(s))
+	fmt.Println(ascii.String(s))
+
+	_, err := ascii.Ascii2BinaryE("héllo")
+	fmt.Println(err)
+
+	// Output:
+	// 48 65 6c 6c 6f
+	// 72 101 108 108 111
+	// "Hello"
+	// non-ASCII character 'é' (U+00E9) at position 2
+}
@@ -0,0 +1,114 @@
+/*
+
+BALANCED TERNARY
+
+*/
+
+package balanced
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/clarketm/ncalc/decimal"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Base of balanced ternary, whose digits are T (-1), 0 and 1
+const Base = 3
+
+// Digits maps each digit value -1, 0, 1 (offset by one) to its character
+const Digits = "T01"
+
+// Decimal2BalancedTernary (s string) string - Decimal2BalancedTernaryE, but panics if it fails
+func Decimal2BalancedTernary(s string) string {
+	r, err := Decimal2BalancedTernaryE(s)
+	utils.CheckError(err, utils.DECIMAL, utils.BALANCED_TERNARY)
+	return r
+}
+
+// Decimal2BalancedTernaryE (s string) (string, error)
+func Decimal2BalancedTernaryE(s string) (string, error) {
+	i, err := decimal.ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return String(i), nil
+}
+
+// BalancedTernary2Decimal (s string) string - BalancedTernary2DecimalE, but panics if it fails
+func BalancedTernary2Decimal(s string) string {
+	r, err := BalancedTernary2DecimalE(s)
+	utils.CheckError(err, utils.BALANCED_TERNARY, utils.DECIMAL)
+	return r
+}
+
+// BalancedTernary2DecimalE (s string) (string, error)
+func BalancedTernary2DecimalE(s string) (string, error) {
+	i, err := ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return i.String(), nil
+}
+
+// Digit (d int) byte - the character of digit -1, 0 or 1
+func Digit(d int) byte {
+	return Digits[d+1]
+}
+
+// Divide (n *big.Int) (q *big.Int, r int, corrected bool) - one division by 3
+// with the remainder kept at -1, 0 or 1. A remainder of 2 is corrected to -1
+// (digit T) by adding 1 to the quotient.
+func Divide(n *big.Int) (*big.Int, int, bool) {
+	q, m := new(big.Int).DivMod(n, big.NewInt(Base), new(big.Int))
+	if m.Int64() == 2 {
+		return q.Add(q, big.NewInt(1)), -1, true
+	}
+	return q, int(m.Int64()), false
+}
+
+// String (i *big.Int) string - i in balanced ternary
+func String(i *big.Int) string {
+	if i.Sign() == 0 {
+		return "0"
+	}
+	var digits []byte
+	for n := new(big.Int).Set(i); n.Sign() != 0; {
+		var r int
+		n, r, _ = Divide(n)
+		digits = append(digits, Digit(r))
+	}
+	for l, r := 0, len(digits)-1; l < r; l, r = l+1, r-1 {
+		digits[l], digits[r] = digits[r], digits[l]
+	}
+	return string(digits)
+}
+
+// ValueOf (s string) *big.Int - ValueOfE, but panics if it fails
+func ValueOf(s string) *big.Int {
+	i, err := ValueOfE(s)
+	utils.CheckError(err, utils.STRING, utils.BALANCED_TERNARY)
+	return i
+}
+
+// ValueOfE (s string) (*big.Int, error) - the value of the balanced ternary digits s (T or t for -1)
+func ValueOfE(s string) (*big.Int, error) {
+	digits := strings.TrimSpace(s)
+	if digits == "" {
+		return nil, &strconv.NumError{Func: "ValueOf", Num: s, Err: strconv.ErrSyntax}
+	}
+	i := new(big.Int)
+	for pos, r := range []rune(digits) {
+		d := strings.IndexRune(Digits, r)
+		if r == 't' {
+			d = 0
+		}
+		if d < 0 {
+			return nil, &utils.ErrInvalidDigit{Pos: pos + 1, Rune: r, Base: Base}
+		}
+		i.Mul(i, big.NewInt(Base)).Add(i, big.NewInt(int64(d-1)))
+	}
+	return i, nil
+}
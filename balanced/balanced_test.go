@@ -0,0 +1,40 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package balanced_test
+
+import (
+	"fmt"
+
+	"github.com/clarketm/ncalc/balanced"
+)
+
+func Example() {
+
+	// BALANCED TERNARY
+	fmt.Println(balanced.Decimal2BalancedTernary("8"))
+	fmt.Println(balanced.Decimal2BalancedTernary("-7"))
+	fmt.Println(balanced.BalancedTernary2Decimal("10T"))
+	fmt.Println(balanced.BalancedTernary2Decimal("t1t"))
+
+	// Output:
+	// 10T
+	// T1T
+	// 8
+	// -7
+}
+
+func ExampleBalancedTernary2DecimalE() {
+
+	// BALANCED TERNARY (2 is not a digit)
+	_, err := balanced.BalancedTernary2DecimalE("102")
+	fmt.Println(err)
+
+	// Output:
+	// invalid digit '2' at position 3 for base 3
+}
@@ -0,0 +1,197 @@
+/*
+
+BCD
+
+*/
+
+package bcd
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/clarketm/ncalc/decimal"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Decimal2Bcd (s string) string - Decimal2BcdE, but panics if it fails
+func Decimal2Bcd(s string) string {
+	r, err := Decimal2BcdE(s)
+	utils.CheckError(err, utils.DECIMAL, utils.BCD)
+	return r
+}
+
+// Decimal2BcdE (s string) (string, error)
+func Decimal2BcdE(s string) (string, error) {
+	i, err := decimal.ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return EncodeE(i)
+}
+
+// Decimal2Packed (s string) string - Decimal2PackedE, but panics if it fails
+func Decimal2Packed(s string) string {
+	r, err := Decimal2PackedE(s)
+	utils.CheckError(err, utils.DECIMAL, utils.BCD_PACKED)
+	return r
+}
+
+// Decimal2PackedE (s string) (string, error)
+func Decimal2PackedE(s string) (string, error) {
+	i, err := decimal.ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return EncodePackedE(i)
+}
+
+// Bcd2Decimal (s string) string - Bcd2DecimalE, but panics if it fails
+func Bcd2Decimal(s string) string {
+	r, err := Bcd2DecimalE(s)
+	utils.CheckError(err, utils.BCD, utils.DECIMAL)
+	return r
+}
+
+// Bcd2DecimalE (s string) (string, error)
+func Bcd2DecimalE(s string) (string, error) {
+	i, err := ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return i.String(), nil
+}
+
+// Packed2Decimal (s string) string - Packed2DecimalE, but panics if it fails
+func Packed2Decimal(s string) string {
+	r, err := Packed2DecimalE(s)
+	utils.CheckError(err, utils.BCD_PACKED, utils.DECIMAL)
+	return r
+}
+
+// Packed2DecimalE (s string) (string, error)
+func Packed2DecimalE(s string) (string, error) {
+	i, err := PackedValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return i.String(), nil
+}
+
+// EncodeE (i *big.Int) (string, error) - one 4-bit group per decimal digit, e.g. 0010 1001
+func EncodeE(i *big.Int) (string, error) {
+	digits, err := digitsOf(i)
+	if err != nil {
+		return "", err
+	}
+	nibbles := make([]string, len(digits))
+	for k, d := range digits {
+		nibbles[k] = Nibble(int(d - '0'))
+	}
+	return strings.Join(nibbles, " "), nil
+}
+
+// EncodePackedE (i *big.Int) (string, error) - two digits per byte in hex, e.g. 29 47
+func EncodePackedE(i *big.Int) (string, error) {
+	digits, err := digitsOf(i)
+	if err != nil {
+		return "", err
+	}
+	if len(digits)%2 != 0 {
+		digits = "0" + digits
+	}
+	bytes := make([]string, 0, len(digits)/2)
+	for k := 0; k < len(digits); k += 2 {
+		bytes = append(bytes, digits[k:k+2])
+	}
+	return strings.Join(bytes, " "), nil
+}
+
+// ValueOf (s string) *big.Int - ValueOfE, but panics if it fails
+func ValueOf(s string) *big.Int {
+	i, err := ValueOfE(s)
+	utils.CheckError(err, utils.STRING, utils.BCD)
+	return i
+}
+
+// ValueOfE (s string) (*big.Int, error) - 4-bit groups, optionally separated by spaces or underscores
+func ValueOfE(s string) (*big.Int, error) {
+	var digits strings.Builder
+	var nibble []rune
+	start := 0
+	for k, r := range []rune(s) {
+		if r == ' ' || r == '_' {
+			continue
+		}
+		if r != '0' && r != '1' {
+			return nil, &utils.ErrInvalidDigit{Pos: k + 1, Rune: r, Base: utils.BINARY_BASE}
+		}
+		if len(nibble) == 0 {
+			start = k + 1
+		}
+		nibble = append(nibble, r)
+		if len(nibble) < 4 {
+			continue
+		}
+
+		d, _ := strconv.ParseUint(string(nibble), 2, 8)
+		if d > 9 {
+			return nil, &utils.ErrInvalidNibble{Pos: start, Nibble: string(nibble)}
+		}
+		digits.WriteByte(byte('0' + d))
+		nibble = nibble[:0]
+	}
+	if len(nibble) > 0 || digits.Len() == 0 {
+		return nil, &utils.ErrInvalidLength{Value: s, Kind: i18n.M("BCD"), Reason: i18n.M("needs a multiple of 4 bits")}
+	}
+
+	i, _ := new(big.Int).SetString(digits.String(), utils.DECIMAL_BASE)
+	return i, nil
+}
+
+// PackedValueOf (s string) *big.Int - PackedValueOfE, but panics if it fails
+func PackedValueOf(s string) *big.Int {
+	i, err := PackedValueOfE(s)
+	utils.CheckError(err, utils.STRING, utils.BCD_PACKED)
+	return i
+}
+
+// PackedValueOfE (s string) (*big.Int, error) - hex bytes whose nibbles are all decimal digits
+func PackedValueOfE(s string) (*big.Int, error) {
+	var digits strings.Builder
+	for k, r := range []rune(s) {
+		if r == ' ' || r == '_' {
+			continue
+		}
+		d, ok := utils.DigitValue(r, utils.HEXADECIMAL_BASE)
+		if !ok {
+			return nil, &utils.ErrInvalidDigit{Pos: k + 1, Rune: r, Base: utils.HEXADECIMAL_BASE}
+		}
+		if d > 9 {
+			return nil, &utils.ErrInvalidNibble{Pos: k + 1, Nibble: Nibble(d)}
+		}
+		digits.WriteRune(r)
+	}
+	if digits.Len() == 0 || digits.Len()%2 != 0 {
+		return nil, &utils.ErrInvalidLength{Value: s, Kind: i18n.M("packed BCD"), Reason: i18n.M("needs whole bytes")}
+	}
+
+	i, _ := new(big.Int).SetString(digits.String(), utils.DECIMAL_BASE)
+	return i, nil
+}
+
+// Nibble (d int) string - d as 4 bits
+func Nibble(d int) string {
+	return fmt.Sprintf("%04b", d)
+}
+
+// digitsOf (i *big.Int) (string, error)
+func digitsOf(i *big.Int) (string, error) {
+	if i.Sign() < 0 {
+		return "", &utils.ErrOverflow{Value: i.String(), Kind: i18n.M("BCD"), Min: "0"}
+	}
+	return i.String(), nil
+}
@@ -0,0 +1,41 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package bcd_test
+
+import (
+	"fmt"
+
+	"github.com/clarketm/ncalc/bcd"
+)
+
+func Example() {
+
+	// BCD
+	d := "2947"
+	fmt.Println(bcd.Decimal2Bcd(d))
+	fmt.Println(bcd.Decimal2Packed(d))
+	fmt.Println(bcd.Bcd2Decimal("0010 1001 0100 0111"))
+	fmt.Println(bcd.Packed2Decimal("29 47"))
+
+	// Output:
+	// 0010 1001 0100 0111
+	// 29 47
+	// 2947
+	// 2947
+}
+
+func ExampleBcd2DecimalE() {
+
+	// BCD (invalid nibble)
+	_, err := bcd.Bcd2DecimalE("0010 1100")
+	fmt.Println(err)
+
+	// Output:
+	// invalid BCD nibble 1100 at position 6: not a decimal digit
+}
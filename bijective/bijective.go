@@ -0,0 +1,127 @@
+/*
+
+BIJECTIVE
+
+*/
+
+package bijective
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/clarketm/ncalc/decimal"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Letters are the digits 1 to 26 of bijective base-26, as in spreadsheet
+// column names (A = 1, Z = 26, AA = 27)
+const Letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// Decimal2Bijective (s string) string - Decimal2BijectiveE, but panics if it fails
+func Decimal2Bijective(s string) string {
+	r, err := Decimal2BijectiveE(s)
+	utils.CheckError(err, utils.DECIMAL, utils.BIJECTIVE)
+	return r
+}
+
+// Decimal2BijectiveE (s string) (string, error) - s as a bijective base-26 column name
+func Decimal2BijectiveE(s string) (string, error) {
+	i, err := decimal.ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return StringE(i, len(Letters))
+}
+
+// Bijective2Decimal (s string) string - Bijective2DecimalE, but panics if it fails
+func Bijective2Decimal(s string) string {
+	r, err := Bijective2DecimalE(s)
+	utils.CheckError(err, utils.BIJECTIVE, utils.DECIMAL)
+	return r
+}
+
+// Bijective2DecimalE (s string) (string, error) - the value of a bijective base-26 column name
+func Bijective2DecimalE(s string) (string, error) {
+	i, err := ValueOfE(s, len(Letters))
+	if err != nil {
+		return "", err
+	}
+	return i.String(), nil
+}
+
+// Digit (d, k int) byte - the character of digit d (1 to k) in bijective base k:
+// a letter for base 26, otherwise the usual digit of d in base k+1
+func Digit(d, k int) byte {
+	if k == len(Letters) {
+		return Letters[d-1]
+	}
+	return big.NewInt(int64(d)).Text(k + 1)[0]
+}
+
+// digitValue (r rune, k int) (int, bool)
+func digitValue(r rune, k int) (int, bool) {
+	if k == len(Letters) {
+		d := strings.IndexRune(Letters, []rune(strings.ToUpper(string(r)))[0])
+		return d + 1, d >= 0
+	}
+	d, ok := utils.DigitValue(r, k+1)
+	return d, ok && d > 0
+}
+
+// Divide (n *big.Int, k int) (q *big.Int, r int, corrected bool) - one division
+// by k with the remainder kept from 1 to k. A remainder of 0, which has no digit,
+// is corrected to k by taking 1 from the quotient.
+func Divide(n *big.Int, k int) (*big.Int, int, bool) {
+	q, m := new(big.Int).DivMod(n, big.NewInt(int64(k)), new(big.Int))
+	if m.Sign() == 0 {
+		return q.Sub(q, big.NewInt(1)), k, true
+	}
+	return q, int(m.Int64()), false
+}
+
+// StringE (i *big.Int, k int) (string, error) - the positive integer i in bijective base k
+func StringE(i *big.Int, k int) (string, error) {
+	if k < 1 || k > utils.MAX_BASE-1 {
+		return "", i18n.Errorf("bijective base %d is not supported (1-%d)", k, utils.MAX_BASE-1)
+	}
+	if i.Sign() <= 0 {
+		return "", &utils.ErrOverflow{Value: i.String(), Kind: i18n.M("bijective numerals"), Min: "1"}
+	}
+	var digits []byte
+	for n := new(big.Int).Set(i); n.Sign() > 0; {
+		var r int
+		n, r, _ = Divide(n, k)
+		digits = append(digits, Digit(r, k))
+	}
+	for l, r := 0, len(digits)-1; l < r; l, r = l+1, r-1 {
+		digits[l], digits[r] = digits[r], digits[l]
+	}
+	return string(digits), nil
+}
+
+// ValueOf (s string, k int) *big.Int - ValueOfE, but panics if it fails
+func ValueOf(s string, k int) *big.Int {
+	i, err := ValueOfE(s, k)
+	utils.CheckError(err, utils.STRING, utils.BIJECTIVE)
+	return i
+}
+
+// ValueOfE (s string, k int) (*big.Int, error) - the value of the bijective base k digits s
+func ValueOfE(s string, k int) (*big.Int, error) {
+	digits := strings.TrimSpace(s)
+	if digits == "" {
+		return nil, &strconv.NumError{Func: "ValueOf", Num: s, Err: strconv.ErrSyntax}
+	}
+	i := new(big.Int)
+	for pos, r := range []rune(digits) {
+		d, ok := digitValue(r, k)
+		if !ok {
+			return nil, &utils.ErrInvalidDigit{Pos: pos + 1, Rune: r, Base: k}
+		}
+		i.Mul(i, big.NewInt(int64(k))).Add(i, big.NewInt(int64(d)))
+	}
+	return i, nil
+}
@@ -0,0 +1,45 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package bijective_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/clarketm/ncalc/bijective"
+)
+
+func Example() {
+
+	// BIJECTIVE
+	fmt.Println(bijective.Decimal2Bijective("26"))
+	fmt.Println(bijective.Decimal2Bijective("28"))
+	fmt.Println(bijective.Decimal2Bijective("702"))
+	fmt.Println(bijective.Bijective2Decimal("AZ"))
+	fmt.Println(bijective.Bijective2Decimal("xfd"))
+
+	// Output:
+	// Z
+	// AB
+	// ZZ
+	// 52
+	// 16384
+}
+
+func ExampleStringE() {
+
+	// BIJECTIVE (base 10: digits 1-9 and a for ten)
+	fmt.Println(bijective.StringE(big.NewInt(10), 10))
+	_, err := bijective.StringE(big.NewInt(0), 26)
+	fmt.Println(err)
+
+	// Output:
+	// a <nil>
+	// overflow: 0 is out of range for bijective numerals (at least 1)
+}
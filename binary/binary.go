@@ -8,48 +8,144 @@ package binary
 
 import (
 	"fmt"
-	"strconv"
+	"math/big"
 
 	"github.com/clarketm/ncalc/decimal"
 	"github.com/clarketm/ncalc/utils"
 )
 
-// Binary2Ascii (s string) string
+// Binary2Ascii (s string) string - Binary2AsciiE, but panics if it fails
 func Binary2Ascii(s string) string {
-	return fmt.Sprintf("%q", ValueOf(s))
+	r, err := Binary2AsciiE(s)
+	utils.CheckError(err, utils.BINARY, utils.ASCII)
+	return r
 }
 
-// Binary2Octal (s string) string
+// Binary2AsciiE (s string) (string, error)
+func Binary2AsciiE(s string) (string, error) {
+	var codes []*big.Int
+	for _, f := range utils.Fields(s) {
+		i, err := ValueOfE(f)
+		if err != nil {
+			return "", err
+		}
+		codes = append(codes, i)
+	}
+	return utils.QuoteASCII(codes)
+}
+
+// Binary2Octal (s string) string - Binary2OctalE, but panics if it fails
 func Binary2Octal(s string) string {
-	i, err := utils.Parse(s, utils.BINARY_BASE)
+	r, err := Binary2OctalE(s)
 	utils.CheckError(err, utils.BINARY, utils.OCTAL)
-	s = strconv.Itoa(int(i))
-	return decimal.Decimal2Octal(s)
+	return r
 }
 
-// Binary2Decimal (s string) string
-func Binary2Decimal(s string) string {
+// Binary2OctalE (s string) (string, error)
+func Binary2OctalE(s string) (string, error) {
+	if utils.IsFraction(s) {
+		return utils.ConvertFractionE(s, utils.BINARY_BASE, utils.OCTAL_BASE)
+	}
 	i, err := utils.Parse(s, utils.BINARY_BASE)
+	if err != nil {
+		return "", err
+	}
+	return decimal.Decimal2OctalE(i.String())
+}
+
+// Binary2Decimal (s string) string - Binary2DecimalE, but panics if it fails
+func Binary2Decimal(s string) string {
+	r, err := Binary2DecimalE(s)
 	utils.CheckError(err, utils.BINARY, utils.DECIMAL)
-	return strconv.Itoa(int(i))
+	return r
 }
 
-// Binary2Hexadecimal (s string) string
-func Binary2Hexadecimal(s string) string {
+// Binary2DecimalE (s string) (string, error)
+func Binary2DecimalE(s string) (string, error) {
+	if utils.IsFraction(s) {
+		return utils.ConvertFractionE(s, utils.BINARY_BASE, utils.DECIMAL_BASE)
+	}
 	i, err := utils.Parse(s, utils.BINARY_BASE)
+	if err != nil {
+		return "", err
+	}
+	return i.String(), nil
+}
+
+// Binary2Hexadecimal (s string) string - Binary2HexadecimalE, but panics if it fails
+func Binary2Hexadecimal(s string) string {
+	r, err := Binary2HexadecimalE(s)
 	utils.CheckError(err, utils.BINARY, utils.HEXADECIMAL)
-	s = strconv.Itoa(int(i))
-	return decimal.Decimal2Hexadecimal(s)
+	return r
+}
+
+// Binary2HexadecimalE (s string) (string, error)
+func Binary2HexadecimalE(s string) (string, error) {
+	if utils.IsFraction(s) {
+		return utils.ConvertFractionE(s, utils.BINARY_BASE, utils.HEXADECIMAL_BASE)
+	}
+	i, err := utils.Parse(s, utils.BINARY_BASE)
+	if err != nil {
+		return "", err
+	}
+	return decimal.Decimal2HexadecimalE(i.String())
 }
 
-// String (s string) string
+// String (s string) string - StringE, but panics if it fails
 func String(s string) string {
-	return fmt.Sprintf("%b", ValueOf(s))
+	r, err := StringE(s)
+	utils.CheckError(err, utils.STRING, utils.BINARY)
+	return r
 }
 
-// ValueOf (s string) int
-func ValueOf(s string) int {
-	i, err := utils.Parse(s, utils.BINARY_BASE)
+// StringE (s string) (string, error)
+func StringE(s string) (string, error) {
+	if utils.IsFraction(s) {
+		return utils.ConvertFractionE(s, utils.BINARY_BASE, utils.BINARY_BASE)
+	}
+	i, err := ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%b", i), nil
+}
+
+// ValueOf (s string) *big.Int - ValueOfE, but panics if it fails
+func ValueOf(s string) *big.Int {
+	i, err := ValueOfE(s)
 	utils.CheckError(err, utils.STRING, utils.BINARY)
-	return int(i)
+	return i
+}
+
+// ValueOfE (s string) (*big.Int, error)
+func ValueOfE(s string) (*big.Int, error) {
+	return utils.Parse(s, utils.BINARY_BASE)
+}
+
+// Bytes (s string) []byte - BytesE, but panics if it fails
+func Bytes(s string) []byte {
+	b, err := BytesE(s)
+	utils.CheckError(err, utils.BINARY, utils.STRING)
+	return b
+}
+
+// BytesE (s string) ([]byte, error) - the big-endian bytes of s, leading zero digits kept
+func BytesE(s string) ([]byte, error) {
+	return utils.BytesOf(s, utils.BINARY_BASE)
+}
+
+// Words (s string, size int, order string) string - WordsE, but panics if it fails
+func Words(s string, size int, order string) string {
+	r, err := WordsE(s, size, order)
+	utils.CheckError(err, utils.BINARY, utils.STRING)
+	return r
+}
+
+// WordsE (s string, size int, order string) (string, error) - s as words of size bytes in big- or little-endian order
+func WordsE(s string, size int, order string) (string, error) {
+	b, err := BytesE(s)
+	if err != nil {
+		return "", err
+	}
+	return utils.Words(b, size, order, utils.BINARY_BASE), nil
 }
@@ -9,9 +9,11 @@ license that can be found in the LICENSE file.
 package binary_test
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/clarketm/ncalc/binary"
+	"github.com/clarketm/ncalc/utils"
 )
 
 func Example() {
@@ -31,3 +33,42 @@ func Example() {
 	// 64
 	// 1100100:string
 }
+
+func Example_fraction() {
+
+	// BINARY FRACTION
+	b := "101.011"
+	fmt.Println(binary.Binary2Octal(b))
+	fmt.Println(binary.Binary2Decimal(b))
+	fmt.Println(binary.Binary2Hexadecimal(b))
+
+	// Output:
+	// 5.3
+	// 5.375
+	// 5.6
+}
+
+func ExampleBinary2DecimalE() {
+
+	// BINARY (invalid digit)
+	_, err := binary.Binary2DecimalE("1021")
+	fmt.Println(err)
+
+	var digit *utils.ErrInvalidDigit
+	if errors.As(err, &digit) {
+		fmt.Println(digit.Pos, string(digit.Rune), digit.Base)
+	}
+
+	// Output:
+	// invalid digit '2' at position 3 for base 2
+	// 3 2 2
+}
+
+func ExampleWordsE() {
+	fmt.Println(binary.WordsE("1111101000", 1, "little"))
+	fmt.Println(binary.WordsE("1111101000", 2, "big"))
+
+	// Output:
+	// 11101000 00000011 <nil>
+	// 0000001111101000 <nil>
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/clarketm/ncalc/bitwise"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// isBitCommand cho biết các đối số có phải lệnh `ncalc bit ...` không;
+// với -i ascii thì "bit" chỉ là văn bản cần chuyển đổi
+func isBitCommand(args []string) bool {
+	return len(args) > 1 && args[0] == "bit" && (len(inputFormat) < 1 || inputFormat[0] != utils.ASCII)
+}
+
+// runBit thực hiện một phép toán bit và in kết quả ở các định dạng đầu ra:
+//
+//	ncalc bit not X
+//	ncalc bit X and|or|xor Y
+//	ncalc bit X shl|shr|sar|rotl|rotr N
+//	ncalc bit X set|clear|toggle N
+//	ncalc bit X mask HI LO (hoặc HI:LO)
+func runBit(args []string) {
+	name, operands, err := parseBitArgs(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, loc.Error(err))
+		os.Exit(exitUsage)
+	}
+
+	values := make([]*big.Int, len(operands))
+	for i, s := range operands {
+		if values[i], err = bitOperand(s); err != nil {
+			fail(i18n.Errorf("error reading %s: %w", s, err))
+		}
+	}
+
+	result, w, err := bitwise.Eval(name, values[0], values[1:], width)
+	if err != nil {
+		fail(i18n.Errorf("error evaluating %s: %w", name, err))
+	}
+
+	printInteger(result, w)
+}
+
+// parseBitArgs tách tên phép toán và các toán hạng, giá trị đứng đầu
+func parseBitArgs(args []string) (string, []string, error) {
+	args = args[1:] // bỏ "bit"
+	if len(args) > 1 && args[0] == "not" {
+		args[0], args[1] = args[1], args[0]
+	}
+	if len(args) < 2 {
+		return "", nil, i18n.Errorf("usage: ncalc bit X OP [operands...]; OP is one of %s", strings.Join(bitwise.Names, ", "))
+	}
+
+	name := strings.ToLower(args[1])
+	operands := append([]string{args[0]}, args[2:]...)
+	if name == "mask" && len(operands) == 2 {
+		if hi, lo, ok := strings.Cut(operands[1], ":"); ok {
+			operands = []string{operands[0], hi, lo}
+		}
+	}
+
+	op, ok := bitwise.Lookup(name)
+	if !ok {
+		return "", nil, i18n.Errorf("unknown bitwise operation %s; want one of %s", args[1], strings.Join(bitwise.Names, ", "))
+	}
+	if len(operands) != op.Args+1 {
+		return "", nil, i18n.Errorf("%s takes %d operand(s) after the value, got %d", name, op.Args, len(operands)-1)
+	}
+	return name, operands, nil
+}
+
+// bitOperand đọc một toán hạng: số có tiền tố hoặc hậu tố cơ số (0xF0, 0b1010, 1Fh)
+// theo cơ số của nó, còn lại theo -i (mặc định thập phân)
+func bitOperand(s string) (*big.Int, error) {
+	base := utils.DECIMAL_BASE
+	if len(inputFormat) > 0 {
+		b, ok := utils.BaseOf(inputFormat[0])
+		if !ok {
+			return nil, i18n.Errorf("bit operands must be integers, not %s", inputFormat[0])
+		}
+		base = b
+	}
+	return utils.Parse(s, base)
+}
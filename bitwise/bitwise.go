@@ -0,0 +1,327 @@
+/*
+
+BITWISE
+
+*/
+
+package bitwise
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// maxBit is the largest shift count or bit index, which keeps a result to a megabit
+const maxBit = 1 << 20
+
+// Op is a bitwise operation. Args counts the operands after the value (a
+// shift amount, a bit index, ...) and Width reports whether the operation is
+// only defined for a fixed bit width, e.g. NOT and the rotations.
+type Op struct {
+	Name  string
+	Args  int
+	Width bool
+	Apply func(x *big.Int, args []*big.Int, width int) (*big.Int, error)
+}
+
+// Ops are the bitwise operations by name
+var Ops = map[string]Op{
+	"and":    {"and", 1, false, func(x *big.Int, a []*big.Int, w int) (*big.Int, error) { return And(x, a[0], w) }},
+	"or":     {"or", 1, false, func(x *big.Int, a []*big.Int, w int) (*big.Int, error) { return Or(x, a[0], w) }},
+	"xor":    {"xor", 1, false, func(x *big.Int, a []*big.Int, w int) (*big.Int, error) { return Xor(x, a[0], w) }},
+	"not":    {"not", 0, true, func(x *big.Int, a []*big.Int, w int) (*big.Int, error) { return Not(x, w) }},
+	"shl":    {"shl", 1, false, func(x *big.Int, a []*big.Int, w int) (*big.Int, error) { return Shl(x, a[0], w) }},
+	"shr":    {"shr", 1, false, func(x *big.Int, a []*big.Int, w int) (*big.Int, error) { return Shr(x, a[0], w) }},
+	"sar":    {"sar", 1, true, func(x *big.Int, a []*big.Int, w int) (*big.Int, error) { return Sar(x, a[0], w) }},
+	"rotl":   {"rotl", 1, true, func(x *big.Int, a []*big.Int, w int) (*big.Int, error) { return Rotl(x, a[0], w) }},
+	"rotr":   {"rotr", 1, true, func(x *big.Int, a []*big.Int, w int) (*big.Int, error) { return Rotr(x, a[0], w) }},
+	"set":    {"set", 1, false, func(x *big.Int, a []*big.Int, w int) (*big.Int, error) { return Set(x, a[0], w) }},
+	"clear":  {"clear", 1, false, func(x *big.Int, a []*big.Int, w int) (*big.Int, error) { return Clear(x, a[0], w) }},
+	"toggle": {"toggle", 1, false, func(x *big.Int, a []*big.Int, w int) (*big.Int, error) { return Toggle(x, a[0], w) }},
+	"mask":   {"mask", 2, false, func(x *big.Int, a []*big.Int, w int) (*big.Int, error) { return Mask(x, a[0], a[1], w) }},
+}
+
+// Names are the operation names in the order they are documented
+var Names = []string{"and", "or", "xor", "not", "shl", "shr", "sar", "rotl", "rotr", "set", "clear", "toggle", "mask"}
+
+// Lookup (name string) (Op, bool)
+func Lookup(name string) (Op, bool) {
+	op, ok := Ops[name]
+	return op, ok
+}
+
+// Eval (name string, x *big.Int, args []*big.Int, width int) (*big.Int, int, error) - the
+// result of the operation and the width it was computed in. A width of 0 means
+// unbounded, unless the operation needs a width or an operand is negative; the
+// smallest of 8, 16, 32 and 64 bits (or whole bytes above that) holding every
+// operand is used then.
+func Eval(name string, x *big.Int, args []*big.Int, width int) (*big.Int, int, error) {
+	op, ok := Lookup(name)
+	if !ok {
+		return nil, 0, i18n.Errorf("unknown bitwise operation %q", name)
+	}
+	if len(args) != op.Args {
+		return nil, 0, i18n.Errorf("%s takes %d operand(s) after the value, got %d", name, op.Args, len(args))
+	}
+
+	if width == 0 && (op.Width || x.Sign() < 0 || (op.Args == 1 && !shifts(name) && args[0].Sign() < 0)) {
+		operands := []*big.Int{x}
+		if !shifts(name) && name != "mask" {
+			operands = append(operands, args...)
+		}
+		width = Fit(operands...)
+	}
+	r, err := op.Apply(x, args, width)
+	return r, width, err
+}
+
+// Fit (operands ...*big.Int) int - the smallest of 8, 16, 32 and 64 bits, or
+// else a whole number of bytes, that holds every operand in two's complement
+// or as an unsigned value
+func Fit(operands ...*big.Int) int {
+	bits := 1
+	for _, i := range operands {
+		n := i.BitLen()
+		if i.Sign() < 0 {
+			// -2^(n-1) still fits in n bits
+			n = new(big.Int).Sub(new(big.Int).Neg(i), big.NewInt(1)).BitLen() + 1
+		}
+		bits = max(bits, n)
+	}
+	for _, w := range []int{8, 16, 32, 64} {
+		if bits <= w {
+			return w
+		}
+	}
+	return (bits + 7) / 8 * 8
+}
+
+// Wrap (i *big.Int, width int) (*big.Int, error) - i as a width-bit pattern:
+// a negative i in two's complement. A width of 0 leaves i unchanged.
+func Wrap(i *big.Int, width int) (*big.Int, error) {
+	if width == 0 {
+		return new(big.Int).Set(i), nil
+	}
+	min := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), uint(width-1)))
+	if i.Cmp(min) < 0 || i.Cmp(mask(width)) > 0 {
+		return nil, &utils.ErrOverflow{Value: i.String(), Width: width, Min: min.String(), Max: mask(width).String()}
+	}
+	return new(big.Int).And(i, mask(width)), nil
+}
+
+// Signed (bits *big.Int, width int) *big.Int - the width-bit pattern read in two's complement
+func Signed(bits *big.Int, width int) *big.Int {
+	if width == 0 || bits.Bit(width-1) == 0 {
+		return new(big.Int).Set(bits)
+	}
+	return new(big.Int).Sub(bits, new(big.Int).Lsh(big.NewInt(1), uint(width)))
+}
+
+// And (x, y *big.Int, width int) (*big.Int, error)
+func And(x, y *big.Int, width int) (*big.Int, error) {
+	return binary(x, y, width, (*big.Int).And)
+}
+
+// Or (x, y *big.Int, width int) (*big.Int, error)
+func Or(x, y *big.Int, width int) (*big.Int, error) {
+	return binary(x, y, width, (*big.Int).Or)
+}
+
+// Xor (x, y *big.Int, width int) (*big.Int, error)
+func Xor(x, y *big.Int, width int) (*big.Int, error) {
+	return binary(x, y, width, (*big.Int).Xor)
+}
+
+// Not (x *big.Int, width int) (*big.Int, error)
+func Not(x *big.Int, width int) (*big.Int, error) {
+	if err := needWidth("not", width); err != nil {
+		return nil, err
+	}
+	bits, err := Wrap(x, width)
+	if err != nil {
+		return nil, err
+	}
+	return bits.Xor(bits, mask(width)), nil
+}
+
+// Shl (x, n *big.Int, width int) (*big.Int, error) - x shifted left by n,
+// dropping the bits shifted out of the width
+func Shl(x, n *big.Int, width int) (*big.Int, error) {
+	bits, count, err := shift(x, n, width)
+	if err != nil {
+		return nil, err
+	}
+	bits.Lsh(bits, count)
+	if width > 0 {
+		bits.And(bits, mask(width))
+	}
+	return bits, nil
+}
+
+// Shr (x, n *big.Int, width int) (*big.Int, error) - x shifted right by n, filling with zeros
+func Shr(x, n *big.Int, width int) (*big.Int, error) {
+	bits, count, err := shift(x, n, width)
+	if err != nil {
+		return nil, err
+	}
+	return bits.Rsh(bits, count), nil
+}
+
+// Sar (x, n *big.Int, width int) (*big.Int, error) - x shifted right by n,
+// filling with copies of the sign bit
+func Sar(x, n *big.Int, width int) (*big.Int, error) {
+	if err := needWidth("sar", width); err != nil {
+		return nil, err
+	}
+	bits, count, err := shift(x, n, width)
+	if err != nil {
+		return nil, err
+	}
+	i := Signed(bits, width)
+	i.Rsh(i, count) // big.Int shifts negative values arithmetically
+	return i.And(i, mask(width)), nil
+}
+
+// Rotl (x, n *big.Int, width int) (*big.Int, error) - x rotated left by n bits
+func Rotl(x, n *big.Int, width int) (*big.Int, error) {
+	if err := needWidth("rotl", width); err != nil {
+		return nil, err
+	}
+	bits, count, err := shift(x, n, width)
+	if err != nil {
+		return nil, err
+	}
+	return rotate(bits, count%uint(width), width), nil
+}
+
+// Rotr (x, n *big.Int, width int) (*big.Int, error) - x rotated right by n bits
+func Rotr(x, n *big.Int, width int) (*big.Int, error) {
+	if err := needWidth("rotr", width); err != nil {
+		return nil, err
+	}
+	bits, count, err := shift(x, n, width)
+	if err != nil {
+		return nil, err
+	}
+	return rotate(bits, (uint(width)-count%uint(width))%uint(width), width), nil
+}
+
+// Set (x, n *big.Int, width int) (*big.Int, error) - x with bit n set to 1
+func Set(x, n *big.Int, width int) (*big.Int, error) {
+	return setBit(x, n, width, func(b uint) uint { return 1 })
+}
+
+// Clear (x, n *big.Int, width int) (*big.Int, error) - x with bit n set to 0
+func Clear(x, n *big.Int, width int) (*big.Int, error) {
+	return setBit(x, n, width, func(b uint) uint { return 0 })
+}
+
+// Toggle (x, n *big.Int, width int) (*big.Int, error) - x with bit n inverted
+func Toggle(x, n *big.Int, width int) (*big.Int, error) {
+	return setBit(x, n, width, func(b uint) uint { return b ^ 1 })
+}
+
+// Mask (x, hi, lo *big.Int, width int) (*big.Int, error) - bits hi down to lo
+// of x, inclusive, moved down to bit 0
+func Mask(x, hi, lo *big.Int, width int) (*big.Int, error) {
+	h, err := index(hi, width)
+	if err != nil {
+		return nil, err
+	}
+	l, err := index(lo, width)
+	if err != nil {
+		return nil, err
+	}
+	if l > h {
+		return nil, &utils.ErrOverflow{Value: strconv.Itoa(l), Kind: i18n.M("the low bit of mask %d:%d", h, l), Min: "0", Max: strconv.Itoa(h)}
+	}
+	bits, err := Wrap(x, width)
+	if err != nil {
+		return nil, err
+	}
+	bits.Rsh(bits, uint(l))
+	return bits.And(bits, mask(h-l+1)), nil
+}
+
+// binary (x, y *big.Int, width int, f func(z, x, y *big.Int) *big.Int) (*big.Int, error)
+func binary(x, y *big.Int, width int, f func(z, x, y *big.Int) *big.Int) (*big.Int, error) {
+	a, err := Wrap(x, width)
+	if err != nil {
+		return nil, err
+	}
+	b, err := Wrap(y, width)
+	if err != nil {
+		return nil, err
+	}
+	return f(a, a, b), nil
+}
+
+// shift (x, n *big.Int, width int) (*big.Int, uint, error) - x as a pattern and the shift count
+func shift(x, n *big.Int, width int) (*big.Int, uint, error) {
+	if n.Sign() < 0 || !n.IsInt64() || n.Int64() > maxBit {
+		return nil, 0, &utils.ErrOverflow{Value: n.String(), Kind: i18n.M("a shift count"), Min: "0", Max: strconv.Itoa(maxBit)}
+	}
+	bits, err := Wrap(x, width)
+	if err != nil {
+		return nil, 0, err
+	}
+	return bits, uint(n.Int64()), nil
+}
+
+// rotate (bits *big.Int, n uint, width int) *big.Int
+func rotate(bits *big.Int, n uint, width int) *big.Int {
+	left := new(big.Int).Lsh(bits, n)
+	right := new(big.Int).Rsh(bits, uint(width)-n)
+	return left.Or(left, right).And(left, mask(width))
+}
+
+// setBit (x, n *big.Int, width int, f func(uint) uint) (*big.Int, error)
+func setBit(x, n *big.Int, width int, f func(uint) uint) (*big.Int, error) {
+	i, err := index(n, width)
+	if err != nil {
+		return nil, err
+	}
+	bits, err := Wrap(x, width)
+	if err != nil {
+		return nil, err
+	}
+	return bits.SetBit(bits, i, f(bits.Bit(i))), nil
+}
+
+// index (n *big.Int, width int) (int, error) - n as a bit index below width
+func index(n *big.Int, width int) (int, error) {
+	top := maxBit
+	if width > 0 {
+		top = width - 1
+	}
+	if n.Sign() < 0 || !n.IsInt64() || n.Int64() > int64(top) {
+		return 0, &utils.ErrOverflow{Value: n.String(), Kind: i18n.M("a bit index"), Min: "0", Max: strconv.Itoa(top)}
+	}
+	return int(n.Int64()), nil
+}
+
+// needWidth (name string, width int) error
+func needWidth(name string, width int) error {
+	if width < 1 {
+		return i18n.Errorf("%s needs a bit width", name)
+	}
+	return nil
+}
+
+// shifts (name string) bool - the operand after the value is a count, not a value
+func shifts(name string) bool {
+	switch name {
+	case "shl", "shr", "sar", "rotl", "rotr", "set", "clear", "toggle":
+		return true
+	}
+	return false
+}
+
+// mask (width int) *big.Int
+func mask(width int) *big.Int {
+	m := new(big.Int).Lsh(big.NewInt(1), uint(width))
+	return m.Sub(m, big.NewInt(1))
+}
@@ -0,0 +1,65 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package bitwise_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/clarketm/ncalc/bitwise"
+)
+
+func Example() {
+	x := big.NewInt(0xF0)
+
+	r, _ := bitwise.And(x, big.NewInt(0b1010_1010), 0)
+	fmt.Printf("%x\n", r)
+	r, _ = bitwise.Not(x, 8)
+	fmt.Printf("%08b\n", r)
+	r, _ = bitwise.Shl(x, big.NewInt(4), 8)
+	fmt.Printf("%x\n", r)
+	r, _ = bitwise.Sar(big.NewInt(0x80), big.NewInt(3), 8)
+	fmt.Printf("%x\n", r)
+	r, _ = bitwise.Rotl(big.NewInt(0x81), big.NewInt(1), 8)
+	fmt.Printf("%x\n", r)
+	r, _ = bitwise.Toggle(x, big.NewInt(0), 0)
+	fmt.Printf("%x\n", r)
+	r, _ = bitwise.Mask(big.NewInt(0xABCD), big.NewInt(11), big.NewInt(8), 0)
+	fmt.Printf("%x\n", r)
+
+	// Output:
+	// a0
+	// 00001111
+	// 0
+	// f0
+	// 3
+	// f1
+	// b
+}
+
+func ExampleEval() {
+	// not needs a width: the smallest that holds 0x1234 is 16 bits
+	r, width, err := bitwise.Eval("not", big.NewInt(0x1234), nil, 0)
+	fmt.Printf("%x %d %v\n", r, width, err)
+
+	// negative operands are two's complement patterns
+	r, width, err = bitwise.Eval("and", big.NewInt(-1), []*big.Int{big.NewInt(0x0F)}, 8)
+	fmt.Printf("%x %d %v\n", r, width, err)
+
+	_, _, err = bitwise.Eval("and", big.NewInt(256), []*big.Int{big.NewInt(1)}, 8)
+	fmt.Println(err)
+	_, _, err = bitwise.Eval("set", big.NewInt(0), []*big.Int{big.NewInt(8)}, 8)
+	fmt.Println(err)
+
+	// Output:
+	// edcb 16 <nil>
+	// f 8 <nil>
+	// overflow: 256 does not fit in 8 bits (range -128 to 255)
+	// overflow: 8 is out of range for a bit index (range 0 to 7)
+}
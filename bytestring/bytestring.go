@@ -0,0 +1,213 @@
+/*
+
+BYTESTRING
+
+*/
+
+package bytestring
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Encoding writes byte strings as text. Encodings with Bits > 0 regroup the
+// bits into Bits-bit characters (6 for base64, 5 for base32); Bits == 0 reads
+// the bytes as one big number in base len(Alphabet), as base58 does.
+type Encoding struct {
+	Name     string
+	Title    string
+	Alphabet string
+	Bits     int
+	Pad      bool // output is padded with '=' to whole blocks
+}
+
+var (
+	Base64    = Encoding{utils.BASE64, "Base64", "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/", 6, true}
+	Base64URL = Encoding{utils.BASE64URL, "Base64URL", "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_", 6, false}
+	Base32    = Encoding{utils.BASE32, "Base32", "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567", 5, true}
+	Base58    = Encoding{utils.BASE58, "Base58", "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz", 0, false}
+)
+
+// Encodings lists every supported encoding
+var Encodings = []Encoding{Base64, Base64URL, Base32, Base58}
+
+// Padding is the character that fills the last block
+const Padding = '='
+
+// Lookup (name string) (Encoding, bool)
+func Lookup(name string) (Encoding, bool) {
+	name = strings.ToLower(name)
+	for _, e := range Encodings {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return Encoding{}, false
+}
+
+// Block () int - the number of characters in a padded block: 4 for base64, 8 for base32
+func (e Encoding) Block() int {
+	if e.Bits == 0 {
+		return 1
+	}
+	n := 1
+	for n*e.Bits%8 != 0 {
+		n++
+	}
+	return n
+}
+
+// Encode (b []byte) string
+func (e Encoding) Encode(b []byte) string {
+	if e.Bits == 0 {
+		return e.encodeNumber(b)
+	}
+
+	var out strings.Builder
+	for _, g := range Regroup(b, e.Bits) {
+		out.WriteByte(e.Alphabet[g])
+	}
+	if e.Pad {
+		for out.Len()%e.Block() != 0 {
+			out.WriteRune(Padding)
+		}
+	}
+	return out.String()
+}
+
+// Decode (s string) ([]byte, error)
+func (e Encoding) Decode(s string) ([]byte, error) {
+	digits, err := e.Digits(s)
+	if err != nil {
+		return nil, err
+	}
+	if e.Bits == 0 {
+		return e.decodeNumber(digits), nil
+	}
+
+	var b []byte
+	var acc, n int
+	for _, d := range digits {
+		acc, n = acc<<e.Bits|d, n+e.Bits
+		if n >= 8 {
+			n -= 8
+			b = append(b, byte(acc>>n))
+			acc &= 1<<n - 1
+		}
+	}
+	return b, nil
+}
+
+// Digits (s string) ([]int, error) - the value of every character of s, after
+// checking the alphabet and the padding. Spaces and line breaks are skipped.
+func (e Encoding) Digits(s string) ([]int, error) {
+	var digits []int
+	pad, padPos, last := 0, 0, 0
+	for i, r := range []rune(s) {
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			continue
+		case r == Padding && e.Bits > 0:
+			if pad == 0 {
+				padPos = i + 1
+			}
+			pad++
+			continue
+		case pad > 0:
+			return nil, &utils.ErrInvalidPadding{Pos: i + 1, Reason: i18n.M("%q after '='", r)}
+		}
+
+		d := strings.IndexRune(e.Alphabet, r)
+		if d < 0 && e == Base32 {
+			d = strings.IndexRune(e.Alphabet, r-'a'+'A')
+		}
+		if d < 0 || r > 0x7F {
+			return nil, &utils.ErrInvalidDigit{Pos: i + 1, Rune: r, Base: len(e.Alphabet)}
+		}
+		digits = append(digits, d)
+		last = i + 1
+	}
+	if len(digits) == 0 {
+		return nil, &utils.ErrInvalidDigit{Pos: 1, Rune: Padding, Base: len(e.Alphabet)}
+	}
+	if e.Bits == 0 {
+		return digits, nil
+	}
+
+	// the last character must complete at least one byte
+	if rest := len(digits) * e.Bits % 8; rest >= e.Bits {
+		return nil, &utils.ErrInvalidPadding{Pos: last, Reason: i18n.M("a %s block cannot end with %d character(s)", e.Title, len(digits)%e.Block())}
+	}
+
+	want := 0
+	if r := len(digits) % e.Block(); r != 0 {
+		want = e.Block() - r
+	}
+	switch {
+	case pad == 0 && want > 0 && e.Pad:
+		return nil, &utils.ErrInvalidPadding{Pos: last + 1, Reason: i18n.M("missing %d '=' to fill the block", want)}
+	case pad > 0 && pad != want:
+		return nil, &utils.ErrInvalidPadding{Pos: padPos, Reason: i18n.M("%d '=' where the block needs %d", pad, want)}
+	}
+	return digits, nil
+}
+
+// Regroup (b []byte, bits int) []int - the bits of b cut into bits-wide groups, the last one filled with zeros
+func Regroup(b []byte, bits int) []int {
+	var groups []int
+	var acc, n int
+	for _, c := range b {
+		acc, n = acc<<8|int(c), n+8
+		for n >= bits {
+			n -= bits
+			groups = append(groups, acc>>n)
+			acc &= 1<<n - 1
+		}
+	}
+	if n > 0 {
+		groups = append(groups, acc<<(bits-n))
+	}
+	return groups
+}
+
+// encodeNumber (b []byte) string - leading zero bytes become the first alphabet character
+func (e Encoding) encodeNumber(b []byte) string {
+	zeros := 0
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+
+	var out []byte
+	n := new(big.Int).SetBytes(b)
+	base := big.NewInt(int64(len(e.Alphabet)))
+	for mod := new(big.Int); n.Sign() > 0; {
+		n.DivMod(n, base, mod)
+		out = append(out, e.Alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		out = append(out, e.Alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// decodeNumber (digits []int) []byte
+func (e Encoding) decodeNumber(digits []int) []byte {
+	zeros := 0
+	for zeros < len(digits) && digits[zeros] == 0 {
+		zeros++
+	}
+
+	n := new(big.Int)
+	base := big.NewInt(int64(len(e.Alphabet)))
+	for _, d := range digits {
+		n.Mul(n, base).Add(n, big.NewInt(int64(d)))
+	}
+	return append(make([]byte, zeros), n.Bytes()...)
+}
@@ -0,0 +1,49 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package bytestring_test
+
+import (
+	"fmt"
+
+	"github.com/clarketm/ncalc/bytestring"
+)
+
+func Example() {
+
+	// Byte strings
+	b := []byte{0xde, 0xad, 0xbe, 0xef}
+	fmt.Println(bytestring.Base64.Encode(b))
+	fmt.Println(bytestring.Base64URL.Encode(b))
+	fmt.Println(bytestring.Base32.Encode(b))
+	fmt.Println(bytestring.Base58.Encode([]byte("hello world")))
+	fmt.Println(bytestring.Base58.Encode([]byte{0, 0, 0xff}))
+
+	// Output:
+	// 3q2+7w==
+	// 3q2-7w
+	// 32W353Y=
+	// StV1DL6CwTryKyV
+	// 115Q
+}
+
+func ExampleEncoding_Decode() {
+
+	// Base64 (padding and alphabet errors)
+	b, _ := bytestring.Base64.Decode("SGk=")
+	fmt.Printf("%s\n", b)
+	_, err := bytestring.Base64.Decode("SG=k")
+	fmt.Println(err)
+	_, err = bytestring.Base58.Decode("0OIl")
+	fmt.Println(err)
+
+	// Output:
+	// Hi
+	// invalid padding at position 4: 'k' after '='
+	// invalid digit '0' at position 1 for base 58
+}
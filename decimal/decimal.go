@@ -8,48 +8,115 @@ package decimal
 
 import (
 	"fmt"
-	"strconv"
+	"math/big"
 
 	"github.com/clarketm/ncalc/utils"
 )
 
-// Decimal2Ascii (s string) string
+// Decimal2Ascii (s string) string - Decimal2AsciiE, but panics if it fails
 func Decimal2Ascii(s string) string {
-	return fmt.Sprintf("%q", ValueOf(s))
+	r, err := Decimal2AsciiE(s)
+	utils.CheckError(err, utils.DECIMAL, utils.ASCII)
+	return r
 }
 
-// Decimal2Binary (s string) string
+// Decimal2AsciiE (s string) (string, error)
+func Decimal2AsciiE(s string) (string, error) {
+	var codes []*big.Int
+	for _, f := range utils.Fields(s) {
+		i, err := ValueOfE(f)
+		if err != nil {
+			return "", err
+		}
+		codes = append(codes, i)
+	}
+	return utils.QuoteASCII(codes)
+}
+
+// Decimal2Binary (s string) string - Decimal2BinaryE, but panics if it fails
 func Decimal2Binary(s string) string {
-	i, err := utils.Parse(s, utils.DECIMAL_BASE)
+	r, err := Decimal2BinaryE(s)
 	utils.CheckError(err, utils.STRING, utils.DECIMAL)
-	s = strconv.FormatInt(int64(i), utils.BINARY_BASE)
-	return s
+	return r
 }
 
-// Decimal2Octal (s string) string
-func Decimal2Octal(s string) string {
+// Decimal2BinaryE (s string) (string, error)
+func Decimal2BinaryE(s string) (string, error) {
+	if utils.IsFraction(s) {
+		return utils.ConvertFractionE(s, utils.DECIMAL_BASE, utils.BINARY_BASE)
+	}
 	i, err := utils.Parse(s, utils.DECIMAL_BASE)
+	if err != nil {
+		return "", err
+	}
+	return i.Text(utils.BINARY_BASE), nil
+}
+
+// Decimal2Octal (s string) string - Decimal2OctalE, but panics if it fails
+func Decimal2Octal(s string) string {
+	r, err := Decimal2OctalE(s)
 	utils.CheckError(err, utils.STRING, utils.DECIMAL)
-	s = strconv.FormatInt(int64(i), utils.OCTAL_BASE)
-	return s
+	return r
 }
 
-// Decimal2Hexadecimal (s string) string
-func Decimal2Hexadecimal(s string) string {
+// Decimal2OctalE (s string) (string, error)
+func Decimal2OctalE(s string) (string, error) {
+	if utils.IsFraction(s) {
+		return utils.ConvertFractionE(s, utils.DECIMAL_BASE, utils.OCTAL_BASE)
+	}
 	i, err := utils.Parse(s, utils.DECIMAL_BASE)
+	if err != nil {
+		return "", err
+	}
+	return i.Text(utils.OCTAL_BASE), nil
+}
+
+// Decimal2Hexadecimal (s string) string - Decimal2HexadecimalE, but panics if it fails
+func Decimal2Hexadecimal(s string) string {
+	r, err := Decimal2HexadecimalE(s)
 	utils.CheckError(err, utils.STRING, utils.DECIMAL)
-	s = strconv.FormatInt(int64(i), utils.HEXADECIMAL_BASE)
-	return s
+	return r
 }
 
-// String (s string) string
+// Decimal2HexadecimalE (s string) (string, error)
+func Decimal2HexadecimalE(s string) (string, error) {
+	if utils.IsFraction(s) {
+		return utils.ConvertFractionE(s, utils.DECIMAL_BASE, utils.HEXADECIMAL_BASE)
+	}
+	i, err := utils.Parse(s, utils.DECIMAL_BASE)
+	if err != nil {
+		return "", err
+	}
+	return i.Text(utils.HEXADECIMAL_BASE), nil
+}
+
+// String (s string) string - StringE, but panics if it fails
 func String(s string) string {
-	return fmt.Sprintf("%d", ValueOf(s))
+	r, err := StringE(s)
+	utils.CheckError(err, utils.STRING, utils.DECIMAL)
+	return r
 }
 
-// ValueOf (s string) int
-func ValueOf(s string) int {
-	i, err := utils.Parse(s, utils.DECIMAL_BASE)
+// StringE (s string) (string, error)
+func StringE(s string) (string, error) {
+	if utils.IsFraction(s) {
+		return utils.ConvertFractionE(s, utils.DECIMAL_BASE, utils.DECIMAL_BASE)
+	}
+	i, err := ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d", i), nil
+}
+
+// ValueOf (s string) *big.Int - ValueOfE, but panics if it fails
+func ValueOf(s string) *big.Int {
+	i, err := ValueOfE(s)
 	utils.CheckError(err, utils.STRING, utils.DECIMAL)
-	return int(i)
+	return i
+}
+
+// ValueOfE (s string) (*big.Int, error)
+func ValueOfE(s string) (*big.Int, error) {
+	return utils.Parse(s, utils.DECIMAL_BASE)
 }
@@ -31,3 +31,19 @@ func Example() {
 	// 64
 	// 100:string
 }
+
+func Example_fraction() {
+
+	// DECIMAL FRACTION
+	d := "0.1"
+	fmt.Println(decimal.Decimal2Binary(d))
+	fmt.Println(decimal.Decimal2Octal(d))
+	fmt.Println(decimal.Decimal2Hexadecimal(d))
+	fmt.Println(decimal.Decimal2Binary("-2.75"))
+
+	// Output:
+	// 0.0(0011)
+	// 0.0(6314)
+	// 0.1(9)
+	// -10.11
+}
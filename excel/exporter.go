@@ -1,3 +1,12 @@
+package excel
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
 // ConvertToLaTeX chuyển đổi văn bản thành định dạng LaTeX cho Excel
 func ConvertToLaTeX(text string) string {
 	// Bảo vệ từ "hexadecimal" trước khi thực hiện các thay thế
@@ -0,0 +1,207 @@
+/*
+
+EXPRESSION
+
+*/
+
+package expression
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Eval (s string, base int) *big.Int - EvalE, but panics if it fails
+func Eval(s string, base int) *big.Int {
+	i, err := EvalE(s, base)
+	utils.CheckError(err, utils.EXPRESSION, utils.DECIMAL)
+	return i
+}
+
+// EvalE (s string, base int) (*big.Int, error) - the integer value of the
+// arithmetic expression s. Operands are base literals such as 0x1F, 0b101,
+// 0o17 or 1Fh, read in their own base, or plain digits read in base. The
+// operators are + - * / % with the usual precedence, parentheses and unary
+// minus; / and % truncate toward zero, as in C and Go.
+func EvalE(s string, base int) (*big.Int, error) {
+	tokens, err := tokenize(s, base)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, end: len([]rune(s)) + 1}
+	i, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t != nil {
+		return nil, &utils.ErrInvalidExpression{Pos: t.pos, Reason: i18n.M("unexpected %q", t.text)}
+	}
+	return i, nil
+}
+
+// token is an operator, a parenthesis or a number. Pos is the 1-based position
+// of its first character.
+type token struct {
+	text  string
+	pos   int
+	value *big.Int
+}
+
+// tokenize (s string, base int) ([]token, error)
+func tokenize(s string, base int) ([]token, error) {
+	var tokens []token
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune("+-*/%()", r):
+			tokens = append(tokens, token{text: string(r), pos: i + 1})
+			i++
+		case r == '&' || r == '#' || isDigit(r):
+			// a number runs to the next operator or space, e.g. 0x1F, 1Fh, &H1F or #FF
+			j := i + 1
+			for j < len(runes) && (isDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			text := string(runes[i:j])
+			value, err := utils.Parse(text, base)
+			if err != nil {
+				var digit *utils.ErrInvalidDigit
+				if errors.As(err, &digit) {
+					return nil, &utils.ErrInvalidExpression{Pos: i + digit.Pos, Reason: i18n.M("invalid digit %q for base %d", digit.Rune, digit.Base)}
+				}
+				return nil, &utils.ErrInvalidExpression{Pos: i + 1, Reason: i18n.M("invalid number %s", text)}
+			}
+			tokens = append(tokens, token{text: text, pos: i + 1, value: value})
+			i = j
+		default:
+			return nil, &utils.ErrInvalidExpression{Pos: i + 1, Reason: i18n.M("unexpected %q", r)}
+		}
+	}
+	return tokens, nil
+}
+
+// isDigit (r rune) bool - r can appear in a number of any base up to 62
+func isDigit(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// parser evaluates the tokens by recursive descent:
+//
+//	sum     = product { ("+" | "-") product }
+//	product = unary { ("*" | "/" | "%") unary }
+//	unary   = ("-" | "+") unary | number | "(" sum ")"
+type parser struct {
+	tokens []token
+	next   int
+	end    int // the position just past the expression
+}
+
+// peek () *token - the next token, nil at the end
+func (p *parser) peek() *token {
+	if p.next < len(p.tokens) {
+		return &p.tokens[p.next]
+	}
+	return nil
+}
+
+// accept (ops string) *token - the next token if it is one of the operators ops
+func (p *parser) accept(ops string) *token {
+	t := p.peek()
+	if t == nil || t.value != nil || !strings.Contains(ops, t.text) {
+		return nil
+	}
+	p.next++
+	return t
+}
+
+// sum () (*big.Int, error)
+func (p *parser) sum() (*big.Int, error) {
+	x, err := p.product()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.accept("+-"); t != nil; t = p.accept("+-") {
+		y, err := p.product()
+		if err != nil {
+			return nil, err
+		}
+		if t.text == "+" {
+			x.Add(x, y)
+		} else {
+			x.Sub(x, y)
+		}
+	}
+	return x, nil
+}
+
+// product () (*big.Int, error)
+func (p *parser) product() (*big.Int, error) {
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.accept("*/%"); t != nil; t = p.accept("*/%") {
+		y, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case t.text == "*":
+			x.Mul(x, y)
+		case y.Sign() == 0:
+			return nil, &utils.ErrInvalidExpression{Pos: t.pos, Reason: i18n.M("division by zero")}
+		case t.text == "/":
+			x.Quo(x, y)
+		default:
+			x.Rem(x, y)
+		}
+	}
+	return x, nil
+}
+
+// unary () (*big.Int, error)
+func (p *parser) unary() (*big.Int, error) {
+	if t := p.accept("+-"); t != nil {
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		if t.text == "-" {
+			x.Neg(x)
+		}
+		return x, nil
+	}
+
+	t := p.peek()
+	switch {
+	case t == nil:
+		return nil, &utils.ErrInvalidExpression{Pos: p.end, Reason: i18n.M("unexpected end of expression")}
+	case t.value != nil:
+		p.next++
+		return new(big.Int).Set(t.value), nil
+	case t.text == "(":
+		p.next++
+		x, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		if p.accept(")") == nil {
+			pos := p.end
+			if t := p.peek(); t != nil {
+				pos = t.pos
+			}
+			return nil, &utils.ErrInvalidExpression{Pos: pos, Reason: i18n.M("missing )")}
+		}
+		return x, nil
+	default:
+		return nil, &utils.ErrInvalidExpression{Pos: t.pos, Reason: i18n.M("unexpected %q", t.text)}
+	}
+}
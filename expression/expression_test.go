@@ -0,0 +1,44 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package expression_test
+
+import (
+	"fmt"
+
+	"github.com/clarketm/ncalc/expression"
+)
+
+func Example() {
+	fmt.Println(expression.Eval("0x1F * 3 + 0b101 - 0o17", 10))
+	fmt.Println(expression.Eval("-(2 + 3) * 4", 10))
+	fmt.Println(expression.Eval("-7 / 2", 10), expression.Eval("-7 % 2", 10))
+	fmt.Println(expression.Eval("ff + 1", 16))
+	fmt.Println(expression.Eval("0FFh * 1000000000000", 10))
+
+	// Output:
+	// 83
+	// -20
+	// -3 -1
+	// 256
+	// 255000000000000
+}
+
+func ExampleEvalE() {
+	for _, s := range []string{"1 / (2 - 2)", "(1 + 2", "3 * * 4", "0x1G + 1", "2 $ 3"} {
+		_, err := expression.EvalE(s, 10)
+		fmt.Println(err)
+	}
+
+	// Output:
+	// invalid expression at position 3: division by zero
+	// invalid expression at position 7: missing )
+	// invalid expression at position 5: unexpected "*"
+	// invalid expression at position 4: invalid digit 'G' for base 16
+	// invalid expression at position 3: unexpected '$'
+}
@@ -0,0 +1,231 @@
+/*
+
+FIXED
+
+*/
+
+package fixed
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Format describes a signed Qm.n fixed-point format: m integer bits, the sign
+// bit included, and n fraction bits, stored as an (m+n)-bit two's complement
+// word. Q1.15 holds -1 to 1-2^-15 in 16 bits.
+type Format struct {
+	M int
+	N int
+}
+
+// MaxWidth is the widest supported word
+const MaxWidth = 64
+
+// Rounding records how a value was scaled, rounded and saturated into a format
+type Rounding struct {
+	Scaled    *big.Rat // the value times 2^n
+	Raw       *big.Int // the rounded integer, before saturation
+	Int       *big.Int // the stored integer, after saturation
+	Bits      *big.Int // the (m+n)-bit two's complement word
+	Saturated bool     // Raw was out of range and clamped
+}
+
+var name = regexp.MustCompile(`^q(\d+)\.(\d+)$`)
+
+// Lookup (s string) (Format, bool) - the format named qM.N, e.g. q1.15
+func Lookup(s string) (Format, bool) {
+	m := name.FindStringSubmatch(strings.ToLower(s))
+	if m == nil {
+		return Format{}, false
+	}
+	f := Format{}
+	f.M, _ = strconv.Atoi(m[1])
+	f.N, _ = strconv.Atoi(m[2])
+	if f.M < 1 || f.Width() > MaxWidth {
+		return Format{}, false
+	}
+	return f, true
+}
+
+// Decimal2Fixed (s string, f Format, opts utils.Options) string - Decimal2FixedE, but panics if it fails
+func Decimal2Fixed(s string, f Format, opts utils.Options) string {
+	r, err := Decimal2FixedE(s, f, opts)
+	utils.CheckError(err, utils.DECIMAL, f.Name())
+	return r
+}
+
+// Decimal2FixedE (s string, f Format, opts utils.Options) (string, error) - s rounded to f by opts.Rounding, and clamped if opts.Saturate
+func Decimal2FixedE(s string, f Format, opts utils.Options) (string, error) {
+	r, err := utils.ParseFraction(s, utils.DECIMAL_BASE)
+	if err != nil {
+		return "", err
+	}
+	rounding, err := f.Encode(r, opts.Rounding, opts.Saturate)
+	if err != nil {
+		return "", err
+	}
+	return f.Describe(rounding.Bits), nil
+}
+
+// Fixed2Decimal (s string, f Format, opts utils.Options) string - Fixed2DecimalE, but panics if it fails
+func Fixed2Decimal(s string, f Format, opts utils.Options) string {
+	r, err := Fixed2DecimalE(s, f, opts)
+	utils.CheckError(err, f.Name(), utils.DECIMAL)
+	return r
+}
+
+// Fixed2DecimalE (s string, f Format, opts utils.Options) (string, error) - the value of s with at most opts.Precision fractional digits
+func Fixed2DecimalE(s string, f Format, opts utils.Options) (string, error) {
+	bits, err := f.ParseBits(s)
+	if err != nil {
+		return "", err
+	}
+	return utils.FormatFraction(f.Rat(bits), utils.DECIMAL_BASE, opts.Precision), nil
+}
+
+// Name () string - e.g. q1.15
+func (f Format) Name() string {
+	return fmt.Sprintf("q%d.%d", f.M, f.N)
+}
+
+// Title () string - e.g. Q1.15
+func (f Format) Title() string {
+	return fmt.Sprintf("Q%d.%d", f.M, f.N)
+}
+
+// Width () int
+func (f Format) Width() int {
+	return f.M + f.N
+}
+
+// Scale () *big.Int - 2^n
+func (f Format) Scale() *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(f.N))
+}
+
+// Range () (min, max *big.Int) - the smallest and largest stored integer
+func (f Format) Range() (*big.Int, *big.Int) {
+	half := new(big.Int).Lsh(big.NewInt(1), uint(f.Width()-1))
+	return new(big.Int).Neg(half), half.Sub(half, big.NewInt(1))
+}
+
+// Round (x *big.Rat, mode string) *big.Int - x rounded to an integer: truncate
+// drops the fraction bits (toward minus infinity, as a shift does), nearest
+// rounds halves up and convergent rounds halves to even
+func Round(x *big.Rat, mode string) *big.Int {
+	floor := new(big.Int).Div(x.Num(), x.Denom())
+	frac := new(big.Rat).Sub(x, new(big.Rat).SetInt(floor))
+	half := big.NewRat(1, 2)
+
+	switch mode {
+	case utils.ROUND_TRUNCATE:
+		return floor
+	case utils.ROUND_CONVERGENT:
+		if c := frac.Cmp(half); c > 0 || (c == 0 && floor.Bit(0) == 1) {
+			floor.Add(floor, big.NewInt(1))
+		}
+	default:
+		if frac.Cmp(half) >= 0 {
+			floor.Add(floor, big.NewInt(1))
+		}
+	}
+	return floor
+}
+
+// Encode (r *big.Rat, mode string, saturate bool) (Rounding, error)
+func (f Format) Encode(r *big.Rat, mode string, saturate bool) (Rounding, error) {
+	rounding := Rounding{Scaled: new(big.Rat).Mul(r, new(big.Rat).SetInt(f.Scale()))}
+	rounding.Raw = Round(rounding.Scaled, mode)
+	rounding.Int = new(big.Int).Set(rounding.Raw)
+
+	min, max := f.Range()
+	if rounding.Raw.Cmp(min) < 0 || rounding.Raw.Cmp(max) > 0 {
+		if !saturate {
+			return Rounding{}, &utils.ErrOverflow{
+				Value: utils.FormatFraction(r, utils.DECIMAL_BASE, utils.DEFAULT_PRECISION),
+				Width: f.Width(),
+				Kind:  i18n.M("%s fixed point", f.Title()),
+				Min:   utils.FormatFraction(f.Rat(min), utils.DECIMAL_BASE, utils.DEFAULT_PRECISION),
+				Max:   utils.FormatFraction(f.Rat(max), utils.DECIMAL_BASE, utils.DEFAULT_PRECISION),
+			}
+		}
+		rounding.Saturated = true
+		if rounding.Raw.Sign() < 0 {
+			rounding.Int.Set(min)
+		} else {
+			rounding.Int.Set(max)
+		}
+	}
+
+	// a negative integer is stored in two's complement, i.e. plus 2^(m+n)
+	rounding.Bits = new(big.Int).Set(rounding.Int)
+	if rounding.Bits.Sign() < 0 {
+		rounding.Bits.Add(rounding.Bits, new(big.Int).Lsh(big.NewInt(1), uint(f.Width())))
+	}
+	return rounding, nil
+}
+
+// Int (bits *big.Int) *big.Int - the signed integer stored in a word
+func (f Format) Int(bits *big.Int) *big.Int {
+	i := new(big.Int).Set(bits)
+	if i.Bit(f.Width()-1) == 1 {
+		i.Sub(i, new(big.Int).Lsh(big.NewInt(1), uint(f.Width())))
+	}
+	return i
+}
+
+// Rat (bits *big.Int) *big.Rat - the value of a word, or of a stored integer
+func (f Format) Rat(bits *big.Int) *big.Rat {
+	i := bits
+	if bits.Sign() >= 0 {
+		i = f.Int(bits)
+	}
+	return new(big.Rat).SetFrac(i, f.Scale())
+}
+
+// ParseBits (s string) (*big.Int, error) - a word written in hex, or in binary
+// with a 0b prefix or at full width
+func (f Format) ParseBits(s string) (*big.Int, error) {
+	t := strings.NewReplacer(" ", "", "_", "").Replace(s)
+
+	base := utils.HEXADECIMAL_BASE
+	switch lower := strings.ToLower(t); {
+	case strings.HasPrefix(lower, "0x"):
+		t = t[2:]
+	case strings.HasPrefix(lower, "0b"):
+		t, base = t[2:], utils.BINARY_BASE
+	case len(t) == f.Width() && strings.Trim(t, "01") == "":
+		base = utils.BINARY_BASE
+	}
+
+	i, err := utils.ParseRadix(t, base)
+	if err != nil {
+		return nil, err
+	}
+	if i.Sign() < 0 || i.BitLen() > f.Width() {
+		return nil, &utils.ErrOverflow{Value: "bit pattern " + i.Text(utils.BINARY_BASE), Width: f.Width()}
+	}
+	return i, nil
+}
+
+// Describe (bits *big.Int) string - the word in hex, e.g. 0xC000
+func (f Format) Describe(bits *big.Int) string {
+	return "0x" + strings.ToUpper(f.Pad(bits, utils.HEXADECIMAL_BASE))
+}
+
+// Pad (bits *big.Int, base int) string - the word with leading zeros to its full width
+func (f Format) Pad(bits *big.Int, base int) string {
+	s := bits.Text(base)
+	max := new(big.Int).Lsh(big.NewInt(1), uint(f.Width()))
+	if digits := len(max.Sub(max, big.NewInt(1)).Text(base)); len(s) < digits {
+		s = strings.Repeat("0", digits-len(s)) + s
+	}
+	return s
+}
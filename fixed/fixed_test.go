@@ -0,0 +1,66 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package fixed_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/clarketm/ncalc/fixed"
+	"github.com/clarketm/ncalc/utils"
+)
+
+func Example() {
+
+	// FIXED
+	q, _ := fixed.Lookup("q1.15")
+	opts := utils.DefaultOptions()
+	fmt.Println(fixed.Decimal2Fixed("-0.5", q, opts))
+	fmt.Println(fixed.Decimal2Fixed("0.1", q, opts))
+	fmt.Println(fixed.Fixed2Decimal("0xC000", q, opts))
+	fmt.Println(fixed.Fixed2Decimal("0CCD", q, opts))
+	fmt.Println(fixed.Decimal2FixedE("1", q, opts))
+	opts.Saturate = true
+	fmt.Println(fixed.Decimal2FixedE("1", q, opts))
+
+	// Output:
+	// 0xC000
+	// 0x0CCD
+	// -0.5
+	// 0.100006103515625
+	//  overflow: 1 does not fit in 16-bit Q1.15 fixed point (range -1 to 0.999969482421875)
+	// 0x7FFF <nil>
+}
+
+func ExampleRound() {
+
+	// FIXED (rounding modes)
+	for _, mode := range []string{utils.ROUND_TRUNCATE, utils.ROUND_NEAREST, utils.ROUND_CONVERGENT} {
+		fmt.Println(mode, fixed.Round(big.NewRat(5, 2), mode), fixed.Round(big.NewRat(-5, 2), mode), fixed.Round(big.NewRat(7, 2), mode))
+	}
+
+	// Output:
+	// truncate 2 -3 3
+	// nearest 3 -2 4
+	// convergent 2 -2 4
+}
+
+func ExampleFormat_Encode() {
+
+	// FIXED (overflow and saturation)
+	q, _ := fixed.Lookup("q1.15")
+	_, err := q.Encode(big.NewRat(1, 1), utils.ROUND_NEAREST, false)
+	fmt.Println(err)
+	r, _ := q.Encode(big.NewRat(1, 1), utils.ROUND_NEAREST, true)
+	fmt.Println(q.Describe(r.Bits), r.Saturated)
+
+	// Output:
+	// overflow: 1 does not fit in 16-bit Q1.15 fixed point (range -1 to 0.999969482421875)
+	// 0x7FFF true
+}
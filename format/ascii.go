@@ -0,0 +1,53 @@
+package format
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/clarketm/ncalc/ascii"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Ascii is text, valued by the code of each character. Any character can be
+// read so that the Unicode formats can encode it, but only 7-bit ASCII can be
+// written out as numbers or as ascii.
+type Ascii struct{}
+
+func init() {
+	Register(Ascii{}, "a")
+}
+
+// Name () string
+func (Ascii) Name() string {
+	return utils.ASCII
+}
+
+// Title () i18n.Message
+func (Ascii) Title() i18n.Message {
+	return i18n.M("text")
+}
+
+// Parse (s string) (Value, error)
+func (Ascii) Parse(s string) (Value, error) {
+	if s == "" {
+		return Value{}, &strconv.NumError{Func: "ValueOf", Num: s, Err: strconv.ErrSyntax}
+	}
+
+	var c []*big.Int
+	for _, r := range ascii.Unescape(s) {
+		c = append(c, big.NewInt(int64(r)))
+	}
+	v := sequence(c)
+	v.Source = utils.ASCII
+	return v, nil
+}
+
+// Format (v Value, opts utils.Options) (string, error)
+func (Ascii) Format(v Value, opts utils.Options) (string, error) {
+	c, err := Codes(v)
+	if err != nil {
+		return "", err
+	}
+	return utils.QuoteASCII(c)
+}
@@ -0,0 +1,212 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/clarketm/ncalc/bcd"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/stepbystep"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// BCD is a non-negative integer in binary coded decimal: one 4-bit group per
+// digit, or two digits per byte when Packed
+type BCD struct {
+	Packed bool
+}
+
+func init() {
+	Register(BCD{}, "8421")
+	Register(BCD{Packed: true}, "packed-bcd", "bcdp")
+}
+
+// Name () string
+func (f BCD) Name() string {
+	if f.Packed {
+		return utils.BCD_PACKED
+	}
+	return utils.BCD
+}
+
+// Title () i18n.Message
+func (f BCD) Title() i18n.Message {
+	if f.Packed {
+		return i18n.M("packed BCD")
+	}
+	return i18n.M("BCD")
+}
+
+// Parse (s string) (Value, error)
+func (f BCD) Parse(s string) (Value, error) {
+	parse := bcd.ValueOfE
+	if f.Packed {
+		parse = bcd.PackedValueOfE
+	}
+	i, err := parse(s)
+	if err != nil {
+		return Value{}, err
+	}
+	return integer(i), nil
+}
+
+// Format (v Value, opts utils.Options) (string, error)
+func (f BCD) Format(v Value, opts utils.Options) (string, error) {
+	if err := checkASCII(v); err != nil {
+		return "", err
+	}
+	if v.Seq != nil {
+		return "", i18n.Errorf("%s encodes a single number, not a sequence of %d", f.Name(), len(v.Seq))
+	}
+	if v.Rat == nil || !v.Rat.IsInt() {
+		return "", i18n.Errorf("%s encodes integers, not %s", f.Name(), text(v))
+	}
+
+	if f.Packed {
+		return bcd.EncodePackedE(v.Rat.Num())
+	}
+	return bcd.EncodeE(v.Rat.Num())
+}
+
+// Steps (src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) - encoding a radix number
+// digit by digit, or decoding back to a radix
+func (f BCD) Steps(src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) {
+	from, fromRadix := src.(Radix)
+	to, toRadix := dst.(Radix)
+	switch {
+	case fromRadix && dst == f:
+		return func(s string) (*stepbystep.StepByStepResult, error) { return f.encodeSteps(s, from, opts) }, true
+	case toRadix && src == f:
+		return func(s string) (*stepbystep.StepByStepResult, error) { return f.decodeSteps(s, to, opts) }, true
+	}
+	return nil, false
+}
+
+// Question (s string, src, dst Format) i18n.Message
+func (f BCD) Question(s string, src, dst Format) i18n.Message {
+	if src == f {
+		return decodeQuestion(f, s, dst)
+	}
+	return i18n.Message{}
+}
+
+// encodeSteps (s string, src Radix, opts utils.Options) (*stepbystep.StepByStepResult, error) - every
+// decimal digit becomes a group of 4 bits
+func (f BCD) encodeSteps(s string, src Radix, opts utils.Options) (*stepbystep.StepByStepResult, error) {
+	result := &stepbystep.StepByStepResult{
+		Input:      s,
+		InputBase:  src.Name(),
+		Output:     "",
+		OutputBase: f.Name(),
+		Method:     f.Name(),
+	}
+
+	digits, err := Convert(s, src.Name(), utils.DECIMAL, opts)
+	if err == nil {
+		_, err = Convert(digits, utils.DECIMAL, f.Name(), opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Method: Replace each decimal digit by its 4-bit binary code with weights 8 4 2 1.")})
+	step := 1
+
+	// a number in another base goes through decimal first
+	if src.Base() != utils.DECIMAL_BASE {
+		result.Add(&stepbystep.Note{Line: i18n.M("Step %d: Convert %s (base %d) to decimal: %s", step, s, src.Base(), digits)})
+		step++
+	}
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Step %d: Map each digit of %s to a nibble:", step, digits)})
+	nibbles := make([]string, len(digits))
+	for i, d := range digits {
+		nibbles[i] = bcd.Nibble(int(d - '0'))
+		result.Add(&stepbystep.Equation{Terms: []stepbystep.Expr{stepbystep.Chain(stepbystep.Maps, stepbystep.Lit(string(d)), stepbystep.Lit(nibbles[i]))}})
+	}
+	step++
+
+	if f.Packed {
+		// two digits per byte, with a leading 0 nibble for an odd number of digits
+		if len(nibbles)%2 != 0 {
+			nibbles = append([]string{bcd.Nibble(0)}, nibbles...)
+			digits = "0" + digits
+			result.Add(&stepbystep.Note{Line: i18n.M("Step %d: Pad with a leading 0000 nibble to fill whole bytes.", step)})
+			step++
+		}
+		result.Add(&stepbystep.Note{Line: i18n.M("Step %d: Pack two nibbles per byte and write each byte in hex:", step)})
+		var bytes []string
+		for i := 0; i < len(nibbles); i += 2 {
+			result.Add(&stepbystep.Equation{Terms: []stepbystep.Expr{stepbystep.Lit(nibbles[i] + " " + nibbles[i+1]), stepbystep.Lit(digits[i : i+2])}})
+			bytes = append(bytes, digits[i:i+2])
+		}
+		result.Output = strings.Join(bytes, " ")
+	} else {
+		result.Output = strings.Join(nibbles, " ")
+	}
+
+	result.Add(&stepbystep.Result{Value: result.Output})
+	return result, nil
+}
+
+// decodeSteps (s string, dst Radix, opts utils.Options) (*stepbystep.StepByStepResult, error) - split
+// into groups of 4 bits, each of them one decimal digit
+func (f BCD) decodeSteps(s string, dst Radix, opts utils.Options) (*stepbystep.StepByStepResult, error) {
+	result := &stepbystep.StepByStepResult{
+		Input:      s,
+		InputBase:  f.Name(),
+		Output:     "",
+		OutputBase: dst.Name(),
+		Method:     f.Name(),
+	}
+
+	output, err := Convert(s, f.Name(), dst.Name(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Method: Split into 4-bit groups; each group from 0000 to 1001 is one decimal digit.")})
+	compact := strings.NewReplacer(" ", "", "_", "").Replace(s)
+	var nibbles []string
+	if f.Packed {
+		result.Add(&stepbystep.Note{Line: i18n.M("Step 1: Write each hex byte as two nibbles:")})
+		for i := 0; i < len(compact); i += 2 {
+			hi, lo := bcd.Nibble(int(compact[i]-'0')), bcd.Nibble(int(compact[i+1]-'0'))
+			result.Add(&stepbystep.Equation{Terms: []stepbystep.Expr{stepbystep.Chain(stepbystep.Maps, stepbystep.Lit(compact[i:i+2]), stepbystep.Lit(hi+" "+lo))}})
+			nibbles = append(nibbles, hi, lo)
+		}
+	} else {
+		for i := 0; i < len(compact); i += 4 {
+			nibbles = append(nibbles, compact[i:i+4])
+		}
+		result.Add(&stepbystep.Note{Line: i18n.M("Step 1: Split into nibbles: %s", strings.Join(nibbles, " "))})
+	}
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Step 2: Read each nibble as a decimal digit:")})
+	var digits strings.Builder
+	for _, n := range nibbles {
+		var terms []stepbystep.Expr
+		d := 0
+		for i, w := range []int{8, 4, 2, 1} {
+			b := int(n[i] - '0')
+			terms = append(terms, stepbystep.Chain(stepbystep.Times, stepbystep.Num(b), stepbystep.Num(w)))
+			d += b * w
+		}
+		result.Add(&stepbystep.Equation{Terms: []stepbystep.Expr{stepbystep.Lit(n), stepbystep.Chain(stepbystep.Plus, terms...), stepbystep.Num(d)}})
+		digits.WriteByte(byte('0' + d))
+	}
+
+	value := strings.TrimLeft(digits.String(), "0")
+	if value == "" {
+		value = "0"
+	}
+	result.Add(&stepbystep.Note{Line: i18n.M("Step 3: Join the digits: %s", value)})
+
+	// then to the target base if it is not decimal
+	if dst.Base() != utils.DECIMAL_BASE {
+		result.Add(&stepbystep.Note{Line: i18n.M("Step 4: Convert %s to base %d: %s", value, dst.Base(), output)})
+	}
+
+	result.Output = output
+	result.Add(&stepbystep.Result{Value: result.Output, Base: dst.Base()})
+	return result, nil
+}
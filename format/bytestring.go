@@ -0,0 +1,221 @@
+package format
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/clarketm/ncalc/bytestring"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/stepbystep"
+	"github.com/clarketm/ncalc/utf"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// ByteString is a byte string written in a text encoding such as base64
+type ByteString struct {
+	Encoding bytestring.Encoding
+}
+
+func init() {
+	for _, e := range bytestring.Encodings {
+		Register(ByteString{e})
+	}
+}
+
+// Name () string
+func (f ByteString) Name() string {
+	return f.Encoding.Name
+}
+
+// Title () i18n.Message
+func (f ByteString) Title() i18n.Message {
+	return i18n.M(f.Encoding.Title)
+}
+
+// Parse (s string) (Value, error)
+func (f ByteString) Parse(s string) (Value, error) {
+	b, err := f.Encoding.Decode(s)
+	if err != nil {
+		return Value{}, err
+	}
+
+	// the bytes also read as one big-endian number
+	i := new(big.Int).SetBytes(b)
+	v := integer(i)
+	v.Bits, v.Width, v.Source, v.Bytes = i, 8*len(b), f.Name(), b
+	return v, nil
+}
+
+// Format (v Value, opts utils.Options) (string, error)
+func (f ByteString) Format(v Value, opts utils.Options) (string, error) {
+	b, err := Bytes(v)
+	if err != nil {
+		return "", err
+	}
+	return f.Encoding.Encode(b), nil
+}
+
+// Steps (src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) - writing a number, text or
+// UTF bytes as a byte string, or reading one back
+func (f ByteString) Steps(src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) {
+	if src == f {
+		switch dst.(type) {
+		case Radix, Ascii, Unicode, UTF:
+			return func(s string) (*stepbystep.StepByStepResult, error) {
+				return decodeByteStringSteps(s, f.Encoding, dst, opts)
+			}, true
+		}
+	}
+	if dst == f {
+		switch src.(type) {
+		case Radix, Ascii, Unicode, UTF:
+			return func(s string) (*stepbystep.StepByStepResult, error) { return encodeByteStringSteps(s, src, f.Encoding) }, true
+		}
+	}
+	return nil, false
+}
+
+// Question (s string, src, dst Format) i18n.Message
+func (ByteString) Question(s string, src, dst Format) i18n.Message {
+	return i18n.Message{}
+}
+
+// encodeByteStringSteps (s string, src Format, dst bytestring.Encoding) (*stepbystep.StepByStepResult, error) - write
+// a byte string in base64, base32 (regrouping the bits) or base58 (by repeated division)
+func encodeByteStringSteps(s string, src Format, dst bytestring.Encoding) (*stepbystep.StepByStepResult, error) {
+	result := &stepbystep.StepByStepResult{
+		Input:      s,
+		InputBase:  src.Name(),
+		Output:     "",
+		OutputBase: dst.Name,
+		Method:     dst.Name,
+	}
+
+	v, err := src.Parse(s)
+	var b []byte
+	if err == nil {
+		b, err = Bytes(v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Output = dst.Encode(b)
+
+	if dst.Bits == 0 {
+		result.Add(&stepbystep.Note{Line: i18n.M("Method: Read the bytes as one big-endian number, write it in base %d by repeated division, and add a '%c' for each leading zero byte.",
+			len(dst.Alphabet), dst.Alphabet[0])})
+		result.Add(&stepbystep.Note{Line: i18n.M("Step 1: Bytes: %s", utf.FormatBytes(b))})
+		n := new(big.Int).SetBytes(b)
+		result.Add(&stepbystep.Note{Line: i18n.M("Step 2: As a number: %s", n)})
+		result.Add(&stepbystep.Note{Line: i18n.M("Step 3: Divide by %d repeatedly:", len(dst.Alphabet))})
+		base := big.NewInt(int64(len(dst.Alphabet)))
+		for q, r := new(big.Int).Set(n), new(big.Int); q.Sign() > 0; {
+			prev := new(big.Int).Set(q)
+			q.DivMod(q, base, r)
+			result.Add(&stepbystep.Equation{
+				Terms:   []stepbystep.Expr{stepbystep.Chain(stepbystep.Divide, stepbystep.Num(prev), stepbystep.Num(len(dst.Alphabet))), stepbystep.Num(q)},
+				Comment: i18n.M("remainder %s -> %c", r, dst.Alphabet[r.Int64()]),
+			})
+		}
+		result.Add(&stepbystep.Note{Line: i18n.M("Step 4: Read the characters from bottom to top and add the leading '%c's: %s", dst.Alphabet[0], result.Output)})
+		result.Add(&stepbystep.Result{Value: result.Output})
+		return result, nil
+	}
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Method: Write the bytes in binary, cut the bits into %d-bit groups, and look each group up in the %s alphabet.", dst.Bits, dst.Title)})
+	result.Add(&stepbystep.Note{Line: i18n.M("Step 1: Bytes: %s", utf.FormatBytes(b))})
+	result.Add(&stepbystep.Note{Line: i18n.M("Step 2: In binary: %s", binaryBytes(b))})
+
+	groups := bytestring.Regroup(b, dst.Bits)
+	bits := make([]string, len(groups))
+	for i, g := range groups {
+		bits[i] = fmt.Sprintf("%0*b", dst.Bits, g)
+	}
+	var fill i18n.Message
+	if len(b)*8%dst.Bits != 0 {
+		fill = i18n.M(" (the last group is filled with 0s)")
+	}
+	result.Add(&stepbystep.Note{Line: i18n.M("Step 3: Regroup into %d-bit groups%s: %s", dst.Bits, fill, strings.Join(bits, " "))})
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Step 4: Map each group to a character:")})
+	for i, g := range groups {
+		result.Add(&stepbystep.Equation{Terms: []stepbystep.Expr{
+			stepbystep.Lit(bits[i]),
+			stepbystep.Chain(stepbystep.Maps, stepbystep.Num(g), stepbystep.Lit(dst.Alphabet[g:g+1])),
+		}})
+	}
+	if strings.ContainsRune(result.Output, bytestring.Padding) {
+		result.Add(&stepbystep.Note{Line: i18n.M("Step 5: Pad with '%c' to a multiple of %d characters: %s", bytestring.Padding, dst.Block(), result.Output)})
+	}
+
+	result.Add(&stepbystep.Result{Value: result.Output})
+	return result, nil
+}
+
+// decodeByteStringSteps (s string, src bytestring.Encoding, dst Format, opts utils.Options) (*stepbystep.StepByStepResult, error) - read
+// a byte string back from base64, base32 or base58
+func decodeByteStringSteps(s string, src bytestring.Encoding, dst Format, opts utils.Options) (*stepbystep.StepByStepResult, error) {
+	result := &stepbystep.StepByStepResult{
+		Input:      s,
+		InputBase:  src.Name,
+		Output:     "",
+		OutputBase: dst.Name(),
+		Method:     src.Name,
+	}
+
+	output, err := Convert(s, src.Name, dst.Name(), opts)
+	if err != nil {
+		return nil, err
+	}
+	digits, _ := src.Digits(s)
+	b, _ := src.Decode(s)
+	chars := []rune(strings.TrimRight(strings.Join(strings.Fields(s), ""), string(bytestring.Padding)))
+
+	if src.Bits == 0 {
+		result.Add(&stepbystep.Note{Line: i18n.M("Method: Read the characters as a base %d number, write it as big-endian bytes, and add a zero byte for each leading '%c'.",
+			len(src.Alphabet), src.Alphabet[0])})
+		result.Add(&stepbystep.Note{Line: i18n.M("Step 1: Multiply by the base and add each digit:")})
+		n := new(big.Int)
+		base := big.NewInt(int64(len(src.Alphabet)))
+		for i, d := range digits {
+			prev := new(big.Int).Set(n)
+			n.Mul(n, base).Add(n, big.NewInt(int64(d)))
+			result.Add(&stepbystep.Equation{
+				Terms: []stepbystep.Expr{
+					stepbystep.Chain(stepbystep.Plus, stepbystep.Chain(stepbystep.Times, stepbystep.Num(prev), stepbystep.Num(len(src.Alphabet))), stepbystep.Num(d)),
+					stepbystep.Num(n),
+				},
+				Comment: i18n.M(fmt.Sprintf("'%c'", chars[i])),
+			})
+		}
+		result.Add(&stepbystep.Note{Line: i18n.M("Step 2: Bytes: %s", utf.FormatBytes(b))})
+	} else {
+		result.Add(&stepbystep.Note{Line: i18n.M("Method: Look up the %d-bit value of each character, join the bits, and cut them into bytes, dropping the fill bits at the end.", src.Bits)})
+		result.Add(&stepbystep.Note{Line: i18n.M("Step 1: Map each character to its value:")})
+		var bits strings.Builder
+		for i, d := range digits {
+			result.Add(&stepbystep.Equation{Terms: []stepbystep.Expr{stepbystep.Lit(string(chars[i])), stepbystep.Num(d), stepbystep.Lit(fmt.Sprintf("%0*b", src.Bits, d))}})
+			fmt.Fprintf(&bits, "%0*b", src.Bits, d)
+		}
+		fill := bits.Len() % 8
+		result.Add(&stepbystep.Note{Line: i18n.M("Step 2: Cut into bytes: %s", binaryBytes(b))})
+		if fill > 0 {
+			result.Add(&stepbystep.Detail{Line: i18n.M("The last %d bit(s) %s only fill the group and are dropped", fill, bits.String()[bits.Len()-fill:])})
+		}
+		result.Add(&stepbystep.Note{Line: i18n.M("Step 3: Bytes: %s", utf.FormatBytes(b))})
+	}
+
+	result.Output = output
+	result.Add(&stepbystep.Result{Value: result.Output})
+	return result, nil
+}
+
+// binaryBytes (b []byte) string - each byte as 8 bits, separated by spaces
+func binaryBytes(b []byte) string {
+	bits := make([]string, len(b))
+	for i, c := range b {
+		bits[i] = fmt.Sprintf("%08b", c)
+	}
+	return strings.Join(bits, " ")
+}
@@ -0,0 +1,94 @@
+package format
+
+import (
+	"math/big"
+
+	"github.com/clarketm/ncalc/fixed"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/stepbystep"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Fixed is a word in a signed Qm.n fixed-point format, e.g. q1.15
+type Fixed struct {
+	Q fixed.Format
+}
+
+// Name () string
+func (f Fixed) Name() string {
+	return f.Q.Name()
+}
+
+// Title () i18n.Message
+func (f Fixed) Title() i18n.Message {
+	return i18n.M("%s fixed point", f.Q.Title())
+}
+
+// Parse (s string) (Value, error)
+func (f Fixed) Parse(s string) (Value, error) {
+	return parseSeq(s, func(s string) (Value, error) {
+		bits, err := f.Q.ParseBits(s)
+		if err != nil {
+			return Value{}, err
+		}
+		return Value{
+			Rat:    f.Q.Rat(bits),
+			Bits:   bits,
+			Width:  f.Q.Width(),
+			Source: f.Name(),
+		}, nil
+	})
+}
+
+// Format (v Value, opts utils.Options) (string, error)
+func (f Fixed) Format(v Value, opts utils.Options) (string, error) {
+	if err := checkASCII(v); err != nil {
+		return "", err
+	}
+	if v.Seq != nil {
+		return formatSeq(f, v, " ", opts)
+	}
+
+	switch {
+	case v.Bits != nil && (v.Source == "" || v.Bytes != nil):
+		// an integer in another base, or a byte string, is read as the raw word
+		if v.Bits.Sign() < 0 || v.Bits.BitLen() > f.Q.Width() {
+			return "", &utils.ErrOverflow{Value: "bit pattern " + v.Bits.Text(utils.BINARY_BASE), Width: f.Q.Width()}
+		}
+		return f.Q.Describe(v.Bits), nil
+	case v.Rat == nil:
+		return "", i18n.Errorf("%s encodes finite numbers, not %s", f.Name(), text(v))
+	}
+
+	rounding, err := f.Q.Encode(new(big.Rat).Set(v.Rat), opts.Rounding, opts.Saturate)
+	if err != nil {
+		return "", err
+	}
+	return f.Q.Describe(rounding.Bits), nil
+}
+
+// Steps (src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) - encoding a decimal number, or
+// reading a word back to a radix. A number in another base is a raw word, which has no steps
+func (f Fixed) Steps(src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) {
+	from, fromRadix := src.(Radix)
+	to, toRadix := dst.(Radix)
+	switch {
+	case fromRadix && dst.Name() == f.Name() && from.Base() == utils.DECIMAL_BASE:
+		return func(s string) (*stepbystep.StepByStepResult, error) {
+			return stepbystep.Decimal2FixedSteps(s, f.Q, opts)
+		}, true
+	case toRadix && src.Name() == f.Name():
+		return func(s string) (*stepbystep.StepByStepResult, error) {
+			return stepbystep.Fixed2RadixSteps(s, f.Q, to.Base(), opts)
+		}, true
+	}
+	return nil, false
+}
+
+// Question (s string, src, dst Format) i18n.Message
+func (f Fixed) Question(s string, src, dst Format) i18n.Message {
+	if src.Name() == f.Name() {
+		return i18n.M("Decode the %s fixed-point word \\texttt{%s} to %s.", f.Q.Title(), s, dst.Title())
+	}
+	return i18n.Message{}
+}
@@ -0,0 +1,324 @@
+/*
+
+FORMAT
+
+*/
+
+package format
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/clarketm/ncalc/fixed"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/stepbystep"
+	"github.com/clarketm/ncalc/utf"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Value is what a format parses to and formats from. Rat is the exact value
+// (nil for Inf and NaN). Bits holds the raw pattern when the input was a
+// non-decimal integer or a fixed-width encoding, and Width its size in bits:
+// the digit width of a binary or hex literal, or the width of the encoding.
+// Source names the format the value came from when output depends on it (a
+// fixed-width encoding, ascii text, UTF bytes); it is empty for a plain number.
+// Text is the preferred decimal spelling when the source has one (e.g.
+// shortest float, "-0", "NaN"). Seq holds the elements of a space-separated
+// sequence, such as the character codes of a string; Rat, Bits and Text are
+// then unset. Bytes holds the raw bytes of a byte string such as base64.
+type Value struct {
+	Rat    *big.Rat
+	Bits   *big.Int
+	Width  int
+	Source string
+	Text   string
+	Seq    []Value
+	Bytes  []byte
+}
+
+// Format converts between strings and values. Title names the format in
+// questions and answers, e.g. "hexadecimal" or "Roman numerals"
+type Format interface {
+	Name() string
+	Title() i18n.Message
+	Parse(s string) (Value, error)
+	Format(v Value, opts utils.Options) (string, error)
+}
+
+// Stepper is a format that can explain a conversion step by step. Steps
+// returns the solver for converting from src to dst, where one of them is the
+// format itself, and false if it has none. Question is the question for
+// converting s from src to dst, or an empty message for the default wording
+type Stepper interface {
+	Format
+	Steps(src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool)
+	Question(s string, src, dst Format) i18n.Message
+}
+
+var registry = map[string]Format{}
+var aliases = map[string]string{}
+
+// Register (f Format, alias ...string)
+func Register(f Format, alias ...string) {
+	registry[f.Name()] = f
+	for _, a := range alias {
+		aliases[a] = f.Name()
+	}
+}
+
+// Lookup (name string) (Format, bool)
+func Lookup(name string) (Format, bool) {
+	name = strings.ToLower(name)
+	if a, ok := aliases[name]; ok {
+		name = a
+	}
+	if f, ok := registry[name]; ok {
+		return f, true
+	}
+
+	// any signed fixed-point format, written "qM.N"
+	if q, ok := fixed.Lookup(name); ok {
+		return Fixed{q}, true
+	}
+
+	// any base from 2 to 62, written "base:N" or just "N"
+	if _, err := strconv.Atoi(name); err == nil {
+		name = fmt.Sprintf("%s:%s", utils.RADIX, name)
+	}
+	if base, ok := utils.BaseOf(name); ok {
+		return Radix(base), true
+	}
+	return nil, false
+}
+
+// Convert (s, from, to string, opts utils.Options) (string, error)
+func Convert(s, from, to string, opts utils.Options) (string, error) {
+	src, ok := Lookup(from)
+	if !ok {
+		return "", i18n.Errorf("unknown format %s", from)
+	}
+	dst, ok := Lookup(to)
+	if !ok {
+		return "", i18n.Errorf("unknown format %s", to)
+	}
+
+	v, err := src.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return dst.Format(v, opts)
+}
+
+// Steps (from, to string, opts utils.Options) (stepbystep.StepsFunc, bool) - the step-by-step solver
+// for converting from one format to another, asking the output format first and then the input format
+func Steps(from, to string, opts utils.Options) (stepbystep.StepsFunc, bool) {
+	src, ok := Lookup(from)
+	if !ok {
+		return nil, false
+	}
+	dst, ok := Lookup(to)
+	if !ok {
+		return nil, false
+	}
+
+	var stepsFunc stepbystep.StepsFunc
+	var exists bool
+	if f, ok := dst.(Stepper); ok {
+		stepsFunc, exists = f.Steps(src, dst, opts)
+	}
+	if f, ok := src.(Stepper); ok && !exists {
+		stepsFunc, exists = f.Steps(src, dst, opts)
+	}
+	if !exists {
+		return nil, false
+	}
+
+	return func(s string) (*stepbystep.StepByStepResult, error) {
+		result, err := stepsFunc(s)
+		if err != nil {
+			return nil, err
+		}
+		result.Question = question(s, src, dst)
+		// a radix output is named from its base, anything else by its format
+		if _, ok := utils.BaseOf(result.OutputBase); !ok {
+			result.OutputName = dst.Title()
+		}
+		return result, nil
+	}, true
+}
+
+// question (s string, src, dst Format) i18n.Message - the question asked by the input format, else by the output format
+func question(s string, src, dst Format) i18n.Message {
+	for _, f := range []Format{src, dst} {
+		if f, ok := f.(Stepper); ok {
+			if q := f.Question(s, src, dst); q.Msg != "" {
+				return q
+			}
+		}
+	}
+	return i18n.Message{}
+}
+
+// decodeQuestion (f Format, s string, dst Format) i18n.Message - the question for reading s in format f as dst
+func decodeQuestion(f Format, s string, dst Format) i18n.Message {
+	return i18n.M("Decode the %s value \\texttt{%s} to %s.", f.Title(), s, dst.Title())
+}
+
+// sequenceSteps (stepsFunc stepbystep.StepsFunc) stepbystep.StepsFunc - stepsFunc, solving a space-separated
+// sequence one number at a time
+func sequenceSteps(stepsFunc stepbystep.StepsFunc) stepbystep.StepsFunc {
+	return func(s string) (*stepbystep.StepByStepResult, error) {
+		if len(strings.Fields(s)) > 1 {
+			return stepbystep.SequenceSteps(s, stepsFunc)
+		}
+		return stepsFunc(s)
+	}
+}
+
+// integer (i *big.Int) Value
+func integer(i *big.Int) Value {
+	return Value{Rat: new(big.Rat).SetInt(i)}
+}
+
+// parseSeq (s string, parse func(string) (Value, error)) (Value, error)
+func parseSeq(s string, parse func(string) (Value, error)) (Value, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return parse(s)
+	}
+
+	v := Value{Seq: make([]Value, len(fields))}
+	rest := s
+	for i, f := range fields {
+		start := len(s) - len(rest) + strings.Index(rest, f)
+		rest = s[start+len(f):]
+
+		e, err := parse(f)
+		if err != nil {
+			// report the position within the whole sequence
+			var digitErr *utils.ErrInvalidDigit
+			if errors.As(err, &digitErr) {
+				digitErr.Pos += utf8.RuneCountInString(s[:start])
+			}
+			return Value{}, err
+		}
+		v.Seq[i] = e
+	}
+	return v, nil
+}
+
+// formatSeq (f Format, v Value, sep string, opts utils.Options) (string, error)
+func formatSeq(f Format, v Value, sep string, opts utils.Options) (string, error) {
+	out := make([]string, len(v.Seq))
+	for i, e := range v.Seq {
+		var err error
+		if out[i], err = f.Format(e, opts); err != nil {
+			return "", err
+		}
+	}
+	return strings.Join(out, sep), nil
+}
+
+// text (v Value) string - v spelled in decimal to the default precision, for error messages
+func text(v Value) string {
+	if v.Text != "" || v.Rat == nil {
+		return v.Text
+	}
+	return utils.FormatFraction(v.Rat, utils.DECIMAL_BASE, utils.DEFAULT_PRECISION)
+}
+
+// Codes (v Value) ([]*big.Int, error) - the integer of v, of each element of a sequence, or of each byte of a byte string
+func Codes(v Value) ([]*big.Int, error) {
+	if v.Seq == nil && v.Bytes != nil {
+		c := make([]*big.Int, len(v.Bytes))
+		for i, b := range v.Bytes {
+			c[i] = big.NewInt(int64(b))
+		}
+		return c, nil
+	}
+
+	elems := v.Seq
+	if elems == nil {
+		elems = []Value{v}
+	}
+
+	c := make([]*big.Int, len(elems))
+	for i, e := range elems {
+		if e.Rat == nil || !e.Rat.IsInt() {
+			return nil, &utils.ErrInvalidCode{Pos: i + 1, Value: text(e)}
+		}
+		c[i] = e.Rat.Num()
+	}
+	return c, nil
+}
+
+// sequence (c []*big.Int) Value - a single integer, or a sequence of them
+func sequence(c []*big.Int) Value {
+	if len(c) == 1 {
+		return integer(c[0])
+	}
+	v := Value{Seq: make([]Value, len(c))}
+	for i, e := range c {
+		v.Seq[i] = integer(e)
+	}
+	return v
+}
+
+// checkASCII (v Value) error - text read by the ascii format must be 7-bit to be written as numbers
+func checkASCII(v Value) error {
+	if v.Source != utils.ASCII {
+		return nil
+	}
+	c, err := Codes(v)
+	if err != nil {
+		return err
+	}
+	_, err = utils.QuoteASCII(c)
+	return err
+}
+
+// Bytes (v Value) ([]byte, error) - v as a byte string. Text is encoded as
+// UTF-8, a sequence gives one byte per element, and a binary or hex literal
+// keeps its leading zero bytes.
+func Bytes(v Value) ([]byte, error) {
+	if v.Bytes != nil {
+		return v.Bytes, nil
+	}
+	if e, ok := utf.Lookup(v.Source); ok || v.Source == utils.ASCII {
+		if !ok {
+			e = utf.UTF8
+		}
+		c, err := Codes(v)
+		if err != nil {
+			return nil, err
+		}
+		return e.Encode(c)
+	}
+
+	if v.Seq != nil {
+		c, err := Codes(v)
+		if err != nil {
+			return nil, err
+		}
+		b := make([]byte, len(c))
+		for i, e := range c {
+			if e.Sign() < 0 || e.BitLen() > 8 {
+				return nil, &utils.ErrOverflow{Value: e.String(), Width: 8, Kind: i18n.M("byte"), Min: "0", Max: "255"}
+			}
+			b[i] = byte(e.Int64())
+		}
+		return b, nil
+	}
+
+	if v.Rat == nil || !v.Rat.IsInt() || v.Rat.Sign() < 0 {
+		return nil, i18n.Errorf("%s is not a byte string", text(v))
+	}
+	n := v.Rat.Num()
+	size := (max(v.Width, n.BitLen(), 1) + 7) / 8
+	return n.FillBytes(make([]byte, size)), nil
+}
@@ -0,0 +1,71 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package format_test
+
+import (
+	"fmt"
+
+	"github.com/clarketm/ncalc/format"
+	"github.com/clarketm/ncalc/utils"
+)
+
+func Example() {
+
+	// FORMAT
+	opts := utils.DefaultOptions()
+	for _, to := range []string{"ascii", "b", "octal", "hex", "base:36", "ieee32"} {
+		s, _ := format.Convert("100", "decimal", to, opts)
+		fmt.Println(s)
+	}
+	fmt.Println(format.Convert("40490fdb", "float", "d", opts))
+	fmt.Println(format.Convert("1A.8", "h", "b", opts))
+	fmt.Println(format.Convert("Hi!", "ascii", "h", opts))
+	fmt.Println(format.Convert("72 105 33", "d", "ascii", opts))
+	fmt.Println(format.Convert("é", "ascii", "utf8", opts))
+	fmt.Println(format.Convert("D83D DE00", "utf16", "unicode", opts))
+	fmt.Println(format.Convert("ff", "h", "bcd", opts))
+	fmt.Println(format.Convert("1011", "gray", "d", opts))
+	fmt.Println(format.Convert("deadbeef", "h", "base64", opts))
+	fmt.Println(format.Convert("1994", "d", "roman", opts))
+	fmt.Println(format.Convert("-7", "d", "negabinary", opts))
+	fmt.Println(format.Convert("T1T", "balanced-ternary", "d", opts))
+	fmt.Println(format.Convert("AB", "bijective", "d", opts))
+	fmt.Println(format.Convert("-0.5", "d", "q1.15", opts))
+	fmt.Println(format.Convert("0xC000", "q1.15", "d", opts))
+
+	// FORMAT (precision and rounding)
+	opts.Precision, opts.Rounding = 4, utils.ROUND_TRUNCATE
+	fmt.Println(format.Convert("0.1", "d", "b", opts))
+	fmt.Println(format.Convert("0.1", "d", "q1.15", opts))
+
+	// Output:
+	// 'd'
+	// 1100100
+	// 144
+	// 64
+	// 2s
+	// sign=0 exponent=10000101 mantissa=10010000000000000000000 (0x42c80000)
+	// 3.1415927 <nil>
+	// 11010.1 <nil>
+	// 48 69 21 <nil>
+	// "Hi!" <nil>
+	// C3 A9 (U+00E9) <nil>
+	// U+1F600 ("😀") <nil>
+	// 0010 0101 0101 <nil>
+	// 13 <nil>
+	// 3q2+7w== <nil>
+	// MCMXCIV <nil>
+	// 1001 <nil>
+	// -7 <nil>
+	// 28 <nil>
+	// 0xC000 <nil>
+	// -0.5 <nil>
+	// 0.0001... <nil>
+	// 0x0CCC <nil>
+}
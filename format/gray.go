@@ -0,0 +1,175 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/clarketm/ncalc/gray"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/stepbystep"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Gray is a non-negative integer in binary-reflected Gray code
+type Gray struct{}
+
+func init() {
+	Register(Gray{}, "g")
+}
+
+// Name () string
+func (Gray) Name() string {
+	return utils.GRAY
+}
+
+// Title () i18n.Message
+func (Gray) Title() i18n.Message {
+	return i18n.M("Gray code")
+}
+
+// Parse (s string) (Value, error)
+func (Gray) Parse(s string) (Value, error) {
+	return parseSeq(s, func(s string) (Value, error) {
+		i, err := gray.ValueOfE(s)
+		if err != nil {
+			return Value{}, err
+		}
+		return integer(i), nil
+	})
+}
+
+// Format (v Value, opts utils.Options) (string, error)
+func (g Gray) Format(v Value, opts utils.Options) (string, error) {
+	if err := checkASCII(v); err != nil {
+		return "", err
+	}
+	if v.Seq != nil {
+		return formatSeq(g, v, " ", opts)
+	}
+	if v.Rat == nil || !v.Rat.IsInt() {
+		return "", i18n.Errorf("%s encodes integers, not %s", g.Name(), text(v))
+	}
+	return gray.StringE(v.Rat.Num())
+}
+
+// Steps (src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) - encoding a radix number
+// by XOR of neighbouring bits, or decoding back to a radix
+func (g Gray) Steps(src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) {
+	from, fromRadix := src.(Radix)
+	to, toRadix := dst.(Radix)
+	_, fromGray := src.(Gray)
+	_, toGray := dst.(Gray)
+	switch {
+	case fromRadix && toGray:
+		return sequenceSteps(func(s string) (*stepbystep.StepByStepResult, error) { return g.encodeSteps(s, from, opts) }), true
+	case fromGray && toRadix:
+		return sequenceSteps(func(s string) (*stepbystep.StepByStepResult, error) { return g.decodeSteps(s, to, opts) }), true
+	}
+	return nil, false
+}
+
+// Question (s string, src, dst Format) i18n.Message
+func (g Gray) Question(s string, src, dst Format) i18n.Message {
+	if _, ok := src.(Gray); ok {
+		return decodeQuestion(g, s, dst)
+	}
+	return i18n.Message{}
+}
+
+// encodeSteps (s string, src Radix, opts utils.Options) (*stepbystep.StepByStepResult, error) - every
+// Gray bit is the XOR of two neighbouring binary bits
+func (g Gray) encodeSteps(s string, src Radix, opts utils.Options) (*stepbystep.StepByStepResult, error) {
+	result := &stepbystep.StepByStepResult{
+		Input:      s,
+		InputBase:  src.Name(),
+		Output:     "",
+		OutputBase: g.Name(),
+		Method:     g.Name(),
+	}
+
+	b, err := Convert(s, src.Name(), utils.BINARY, opts)
+	if err == nil {
+		result.Output, err = Convert(s, src.Name(), g.Name(), opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Method: The first Gray bit is the first binary bit; every next Gray bit is the XOR of two neighbouring binary bits, i.e. g = b XOR (b >> 1).")})
+	step := 1
+
+	// a number in another base goes through binary first
+	if src.Base() != utils.BINARY_BASE {
+		result.Add(&stepbystep.Note{Line: i18n.M("Step %d: Convert %s (base %d) to binary: %s", step, s, src.Base(), b)})
+		step++
+	}
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Step %d: XOR neighbouring bits of %s:", step, b)})
+	result.Add(&stepbystep.Equation{Terms: []stepbystep.Expr{stepbystep.Lit("g1"), stepbystep.Lit("b1"), stepbystep.Lit(b[:1])}})
+	for i := 1; i < len(b); i++ {
+		result.Add(&stepbystep.Equation{Terms: []stepbystep.Expr{
+			stepbystep.Lit(fmt.Sprintf("g%d", i+1)),
+			stepbystep.Chain(stepbystep.Xor, stepbystep.Lit(fmt.Sprintf("b%d", i)), stepbystep.Lit(fmt.Sprintf("b%d", i+1))),
+			stepbystep.Chain(stepbystep.Xor, stepbystep.Lit(b[i-1:i]), stepbystep.Lit(b[i:i+1])),
+			stepbystep.Lit(string(xorBit(b[i-1], b[i]))),
+		}})
+	}
+	step++
+
+	// check with a right shift by one bit
+	shifted := "0" + b[:len(b)-1]
+	result.Add(&stepbystep.Note{Line: i18n.M("Step %d: Check with a shift: %s XOR %s = %s", step, b, shifted, result.Output)})
+
+	result.Add(&stepbystep.Result{Value: result.Output})
+	return result, nil
+}
+
+// decodeSteps (s string, dst Radix, opts utils.Options) (*stepbystep.StepByStepResult, error) - every
+// binary bit is the previous binary bit XOR the next Gray bit
+func (g Gray) decodeSteps(s string, dst Radix, opts utils.Options) (*stepbystep.StepByStepResult, error) {
+	result := &stepbystep.StepByStepResult{
+		Input:      s,
+		InputBase:  g.Name(),
+		Output:     "",
+		OutputBase: dst.Name(),
+		Method:     g.Name(),
+	}
+
+	output, err := Convert(s, g.Name(), dst.Name(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Method: The first binary bit is the first Gray bit; every next binary bit is the previous binary bit XOR the next Gray bit.")})
+	s = strings.TrimSpace(s)
+	b := []byte{s[0]}
+	result.Add(&stepbystep.Note{Line: i18n.M("Step 1: Undo the XOR from left to right over %s:", s)})
+	result.Add(&stepbystep.Equation{Terms: []stepbystep.Expr{stepbystep.Lit("b1"), stepbystep.Lit("g1"), stepbystep.Lit(s[:1])}})
+	for i := 1; i < len(s); i++ {
+		b = append(b, xorBit(b[i-1], s[i]))
+		result.Add(&stepbystep.Equation{Terms: []stepbystep.Expr{
+			stepbystep.Lit(fmt.Sprintf("b%d", i+1)),
+			stepbystep.Chain(stepbystep.Xor, stepbystep.Lit(fmt.Sprintf("b%d", i)), stepbystep.Lit(fmt.Sprintf("g%d", i+1))),
+			stepbystep.Chain(stepbystep.Xor, stepbystep.Lit(b[i-1:i]), stepbystep.Lit(s[i:i+1])),
+			stepbystep.Lit(b[i : i+1]),
+		}})
+	}
+	result.Add(&stepbystep.Note{Line: i18n.M("Step 2: Binary: %s", b)})
+
+	// then to the target base if it is not binary
+	if dst.Base() != utils.BINARY_BASE {
+		result.Add(&stepbystep.Note{Line: i18n.M("Step 3: Convert %s to base %d: %s", b, dst.Base(), output)})
+	}
+
+	result.Output = output
+	result.Add(&stepbystep.Result{Value: result.Output, Base: dst.Base()})
+	return result, nil
+}
+
+// xorBit (a, b byte) byte - the XOR of two bit characters '0' or '1'
+func xorBit(a, b byte) byte {
+	if a == b {
+		return '0'
+	}
+	return '1'
+}
@@ -0,0 +1,112 @@
+package format
+
+import (
+	"math/big"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/ieee754"
+	"github.com/clarketm/ncalc/stepbystep"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Float is an IEEE 754 bit pattern
+type Float struct {
+	IEEE ieee754.Format
+}
+
+func init() {
+	Register(Float{ieee754.Half}, utils.HALF)
+	Register(Float{ieee754.Single}, utils.FLOAT)
+	Register(Float{ieee754.Double}, utils.DOUBLE)
+}
+
+// Name () string
+func (f Float) Name() string {
+	return f.IEEE.Name
+}
+
+// Title () i18n.Message
+func (f Float) Title() i18n.Message {
+	return i18n.M("IEEE 754 %s", i18n.M(f.IEEE.Title))
+}
+
+// Parse (s string) (Value, error)
+func (f Float) Parse(s string) (Value, error) {
+	bits, err := f.IEEE.ParseBits(s, 0)
+	if err != nil {
+		return Value{}, err
+	}
+
+	v := Value{
+		Bits:   new(big.Int).SetUint64(bits),
+		Width:  f.IEEE.Width,
+		Source: f.Name(),
+		Text:   f.IEEE.Text(bits),
+	}
+	if c := f.IEEE.Class(bits); c != "infinity" && c != "nan" {
+		v.Rat = f.IEEE.Rat(bits)
+	}
+	return v, nil
+}
+
+// Format (v Value, opts utils.Options) (string, error)
+func (f Float) Format(v Value, opts utils.Options) (string, error) {
+	var bits uint64
+	if v.Seq != nil {
+		return "", i18n.Errorf("%s encodes a single number, not a sequence of %d", f.Name(), len(v.Seq))
+	}
+	if err := checkASCII(v); err != nil {
+		return "", err
+	}
+
+	from, isFloat := ieee754.Lookup(v.Source)
+	switch {
+	case isFloat:
+		bits = ieee754.Convert(v.Bits.Uint64(), from, f.IEEE)
+	case v.Bits != nil && (v.Source == "" || v.Bytes != nil):
+		// an integer in another base, or a byte string, is read as the raw pattern
+		if v.Bits.BitLen() > f.IEEE.Width {
+			return "", &utils.ErrOverflow{Value: "bit pattern " + v.Bits.Text(utils.BINARY_BASE), Width: f.IEEE.Width}
+		}
+		bits = v.Bits.Uint64()
+	case v.Text != "":
+		b, err := f.IEEE.Parse(v.Text)
+		if err != nil {
+			return "", err
+		}
+		bits = b
+	default:
+		bits = f.IEEE.Round(v.Rat).Bits
+	}
+	return f.IEEE.Describe(bits), nil
+}
+
+// Steps (src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) - encoding a decimal number,
+// reading a bit pattern written in another base, or decoding a bit pattern to decimal
+func (f Float) Steps(src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) {
+	from, fromRadix := src.(Radix)
+	to, toRadix := dst.(Radix)
+	switch {
+	case fromRadix && dst == f && from.Base() == utils.DECIMAL_BASE:
+		return func(s string) (*stepbystep.StepByStepResult, error) {
+			return stepbystep.Decimal2FloatSteps(s, f.IEEE, opts)
+		}, true
+	case fromRadix && dst == f:
+		return func(s string) (*stepbystep.StepByStepResult, error) {
+			return stepbystep.Float2DecimalSteps(s, from.Base(), f.IEEE)
+		}, true
+	case toRadix && src == f && to.Base() == utils.DECIMAL_BASE:
+		return func(s string) (*stepbystep.StepByStepResult, error) {
+			return stepbystep.Float2DecimalSteps(s, 0, f.IEEE)
+		}, true
+	}
+	return nil, false
+}
+
+// Question (s string, src, dst Format) i18n.Message
+func (f Float) Question(s string, src, dst Format) i18n.Message {
+	if src == f {
+		return i18n.M("Decode the IEEE 754 %s bit pattern $%s$ to %s.", i18n.M(f.IEEE.Title), s, dst.Title())
+	}
+	return i18n.Message{}
+}
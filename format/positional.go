@@ -0,0 +1,330 @@
+package format
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/clarketm/ncalc/balanced"
+	"github.com/clarketm/ncalc/bijective"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/negabinary"
+	"github.com/clarketm/ncalc/stepbystep"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Negabinary is an integer in base -2, which needs no sign
+type Negabinary struct{}
+
+// BalancedTernary is an integer in base 3 with digits T (-1), 0 and 1
+type BalancedTernary struct{}
+
+// Bijective is a positive integer in bijective base-26, i.e. a spreadsheet
+// column name such as AB
+type Bijective struct{}
+
+func init() {
+	Register(Negabinary{}, "nega", "base:-2", "-2")
+	Register(BalancedTernary{}, "bt", "balanced")
+	Register(Bijective{}, "bijective26", "column")
+}
+
+// Name () string
+func (Negabinary) Name() string {
+	return utils.NEGABINARY
+}
+
+// Title () i18n.Message
+func (Negabinary) Title() i18n.Message {
+	return i18n.M("base -2")
+}
+
+// Parse (s string) (Value, error)
+func (Negabinary) Parse(s string) (Value, error) {
+	return parseInteger(s, negabinary.ValueOfE)
+}
+
+// Format (v Value, opts utils.Options) (string, error)
+func (n Negabinary) Format(v Value, opts utils.Options) (string, error) {
+	return formatInteger(n, v, opts, func(i *big.Int) (string, error) { return negabinary.String(i), nil })
+}
+
+// Steps (src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool)
+func (n Negabinary) Steps(src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) {
+	return positionalSteps(n, src, dst, opts)
+}
+
+// Question (s string, src, dst Format) i18n.Message
+func (n Negabinary) Question(s string, src, dst Format) i18n.Message {
+	return positionalQuestion(n, s, src, dst)
+}
+
+// Name () string
+func (BalancedTernary) Name() string {
+	return utils.BALANCED_TERNARY
+}
+
+// Title () i18n.Message
+func (BalancedTernary) Title() i18n.Message {
+	return i18n.M("balanced ternary")
+}
+
+// Parse (s string) (Value, error)
+func (BalancedTernary) Parse(s string) (Value, error) {
+	return parseInteger(s, balanced.ValueOfE)
+}
+
+// Format (v Value, opts utils.Options) (string, error)
+func (b BalancedTernary) Format(v Value, opts utils.Options) (string, error) {
+	return formatInteger(b, v, opts, func(i *big.Int) (string, error) { return balanced.String(i), nil })
+}
+
+// Steps (src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool)
+func (b BalancedTernary) Steps(src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) {
+	return positionalSteps(b, src, dst, opts)
+}
+
+// Question (s string, src, dst Format) i18n.Message
+func (b BalancedTernary) Question(s string, src, dst Format) i18n.Message {
+	return positionalQuestion(b, s, src, dst)
+}
+
+// Name () string
+func (Bijective) Name() string {
+	return utils.BIJECTIVE
+}
+
+// Title () i18n.Message
+func (Bijective) Title() i18n.Message {
+	return i18n.M("bijective base 26")
+}
+
+// Parse (s string) (Value, error)
+func (Bijective) Parse(s string) (Value, error) {
+	return parseInteger(s, func(s string) (*big.Int, error) { return bijective.ValueOfE(s, len(bijective.Letters)) })
+}
+
+// Format (v Value, opts utils.Options) (string, error)
+func (b Bijective) Format(v Value, opts utils.Options) (string, error) {
+	return formatInteger(b, v, opts, func(i *big.Int) (string, error) { return bijective.StringE(i, len(bijective.Letters)) })
+}
+
+// Steps (src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool)
+func (b Bijective) Steps(src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) {
+	return positionalSteps(b, src, dst, opts)
+}
+
+// Question (s string, src, dst Format) i18n.Message
+func (b Bijective) Question(s string, src, dst Format) i18n.Message {
+	return positionalQuestion(b, s, src, dst)
+}
+
+// parseInteger (s string, valueOf func(string) (*big.Int, error)) (Value, error) - one integer or a sequence
+func parseInteger(s string, valueOf func(string) (*big.Int, error)) (Value, error) {
+	return parseSeq(s, func(s string) (Value, error) {
+		i, err := valueOf(s)
+		if err != nil {
+			return Value{}, err
+		}
+		return integer(i), nil
+	})
+}
+
+// formatInteger (f Format, v Value, opts utils.Options, encode func(*big.Int) (string, error)) (string, error)
+func formatInteger(f Format, v Value, opts utils.Options, encode func(*big.Int) (string, error)) (string, error) {
+	if err := checkASCII(v); err != nil {
+		return "", err
+	}
+	if v.Seq != nil {
+		return formatSeq(f, v, " ", opts)
+	}
+	if v.Rat == nil || !v.Rat.IsInt() {
+		return "", i18n.Errorf("%s encodes integers, not %s", f.Name(), text(v))
+	}
+	return encode(v.Rat.Num())
+}
+
+// positionalSteps (f Format, src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) - writing a radix
+// number in the positional system f by repeated division, or reading it back by its place values
+func positionalSteps(f Format, src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) {
+	from, fromRadix := src.(Radix)
+	to, toRadix := dst.(Radix)
+	switch {
+	case fromRadix && dst.Name() == f.Name():
+		return sequenceSteps(func(s string) (*stepbystep.StepByStepResult, error) {
+			return encodePositionalSteps(s, from, f.Name(), opts)
+		}), true
+	case toRadix && src.Name() == f.Name():
+		return sequenceSteps(func(s string) (*stepbystep.StepByStepResult, error) {
+			return decodePositionalSteps(s, f.Name(), to, opts)
+		}), true
+	}
+	return nil, false
+}
+
+// positionalQuestion (f Format, s string, src, dst Format) i18n.Message
+func positionalQuestion(f Format, s string, src, dst Format) i18n.Message {
+	if src.Name() == f.Name() {
+		return decodeQuestion(f, s, dst)
+	}
+	return i18n.Message{}
+}
+
+// positional describes a non-standard positional system: its base, the division that corrects the remainder, and its digits
+type positional struct {
+	base    int
+	digits  string // the allowed remainders, e.g. "0 or 1"
+	fix     string // how a remainder out of range is corrected
+	qFix    int    // what correcting the remainder added to the quotient
+	divide  func(*big.Int) (*big.Int, int, bool)
+	digit   func(int) byte
+	valueOf func(string) (*big.Int, error)
+}
+
+// positionals are the positional systems by format name
+var positionals = map[string]positional{
+	utils.NEGABINARY: {
+		base:    negabinary.Base,
+		digits:  "0 or 1",
+		fix:     "is negative: add 2 to it and 1 to the quotient",
+		qFix:    1,
+		divide:  negabinary.Divide,
+		digit:   func(r int) byte { return byte('0' + r) },
+		valueOf: negabinary.ValueOfE,
+	},
+	utils.BALANCED_TERNARY: {
+		base:    balanced.Base,
+		digits:  "-1 (T), 0 or 1",
+		fix:     "is 2: write -1 (T) instead and add 1 to the quotient",
+		qFix:    1,
+		divide:  balanced.Divide,
+		digit:   balanced.Digit,
+		valueOf: balanced.ValueOfE,
+	},
+	utils.BIJECTIVE: {
+		base:    len(bijective.Letters),
+		digits:  "1 (A) to 26 (Z)",
+		fix:     "is 0, which has no digit: write 26 (Z) instead and take 1 from the quotient",
+		qFix:    -1,
+		divide:  func(n *big.Int) (*big.Int, int, bool) { return bijective.Divide(n, len(bijective.Letters)) },
+		digit:   func(r int) byte { return bijective.Digit(r, len(bijective.Letters)) },
+		valueOf: func(s string) (*big.Int, error) { return bijective.ValueOfE(s, len(bijective.Letters)) },
+	},
+}
+
+// encodePositionalSteps (s string, src Radix, dst string, opts utils.Options) (*stepbystep.StepByStepResult, error) - write
+// a number in base -2, balanced ternary or bijective base 26 by repeated division
+func encodePositionalSteps(s string, src Radix, dst string, opts utils.Options) (*stepbystep.StepByStepResult, error) {
+	result := &stepbystep.StepByStepResult{
+		Input:      s,
+		InputBase:  src.Name(),
+		Output:     "",
+		OutputBase: dst,
+		Method:     dst,
+	}
+
+	p := positionals[dst]
+	d, err := Convert(s, src.Name(), utils.DECIMAL, opts)
+	if err == nil {
+		result.Output, err = Convert(s, src.Name(), dst, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Method: Divide by %s repeatedly and keep each remainder %s, correcting the quotient when the plain remainder is out of range. The remainders read from bottom to top are the digits.",
+		baseText(p.base), i18n.M(p.digits))})
+	step := 1
+
+	// a number in another base goes through decimal first
+	if src.Base() != utils.DECIMAL_BASE {
+		result.Add(&stepbystep.Note{Line: i18n.M("Step %d: Convert %s (base %d) to decimal: %s", step, s, src.Base(), d)})
+		step++
+	}
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Step %d: Divide by %s:", step, baseText(p.base))})
+	n, _ := new(big.Int).SetString(d, utils.DECIMAL_BASE)
+	base := big.NewInt(int64(p.base))
+	if n.Sign() == 0 {
+		result.Add(&stepbystep.Detail{Line: i18n.M("The number is 0, which is written 0")})
+	}
+	for n.Sign() != 0 {
+		q, r, corrected := p.divide(n)
+		if corrected {
+			// the plain quotient and remainder, before the correction
+			rawQ := new(big.Int).Sub(q, big.NewInt(int64(p.qFix)))
+			rawR := new(big.Int).Sub(n, new(big.Int).Mul(base, rawQ))
+			result.Add(&stepbystep.Equation{
+				Terms: []stepbystep.Expr{stepbystep.Chain(stepbystep.Divide, stepbystep.Num(n), stepbystep.Lit(baseText(p.base))), stepbystep.Num(rawQ)},
+				Comment: i18n.M("remainder %s %s, so %s = %s × %s + %s -> %c",
+					rawR, i18n.M(p.fix), n, baseText(p.base), signedText(q), baseText(r), p.digit(r)),
+			})
+		} else {
+			result.Add(&stepbystep.Equation{
+				Terms:   []stepbystep.Expr{stepbystep.Chain(stepbystep.Divide, stepbystep.Num(n), stepbystep.Lit(baseText(p.base))), stepbystep.Num(q)},
+				Comment: i18n.M("remainder %d -> %c", r, p.digit(r)),
+			})
+		}
+		n = q
+	}
+	step++
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Step %d: Read the digits from bottom to top: %s", step, result.Output)})
+	result.Add(&stepbystep.Result{Value: result.Output})
+	return result, nil
+}
+
+// decodePositionalSteps (s string, src string, dst Radix, opts utils.Options) (*stepbystep.StepByStepResult, error) - read
+// a number in base -2, balanced ternary or bijective base 26 as the sum of its place values
+func decodePositionalSteps(s string, src string, dst Radix, opts utils.Options) (*stepbystep.StepByStepResult, error) {
+	result := &stepbystep.StepByStepResult{
+		Input:      s,
+		InputBase:  src,
+		Output:     "",
+		OutputBase: dst.Name(),
+		Method:     src,
+	}
+
+	p := positionals[src]
+	output, err := Convert(s, src, dst.Name(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Method: Multiply each digit by its place value, a power of %s, and add the products.", baseText(p.base))})
+	digits := []rune(strings.TrimSpace(s))
+	terms := make([]string, len(digits))
+	products := make([]string, len(digits))
+	for i, r := range digits {
+		d, _ := p.valueOf(string(r))
+		power := len(digits) - 1 - i
+		place := new(big.Int).Exp(big.NewInt(int64(p.base)), big.NewInt(int64(power)), nil)
+		terms[i] = fmt.Sprintf("%s×%s^%d", signedText(d), baseText(p.base), power)
+		products[i] = signedText(new(big.Int).Mul(d, place))
+	}
+	value, _ := p.valueOf(string(digits))
+	result.Add(&stepbystep.Note{Line: i18n.M("Step 1: Place values: %s", strings.Join(terms, " + "))})
+	result.Add(&stepbystep.Note{Line: i18n.M("Step 2: Add the products: %s = %s", strings.Join(products, " + "), value)})
+
+	// then to the target base if it is not decimal
+	if dst.Base() != utils.DECIMAL_BASE {
+		result.Add(&stepbystep.Note{Line: i18n.M("Step 3: Convert %s to base %d: %s", value, dst.Base(), output)})
+	}
+
+	result.Output = output
+	result.Add(&stepbystep.Result{Value: result.Output, Base: dst.Base()})
+	return result, nil
+}
+
+// baseText (base int) string - the base, in parentheses if it is negative
+func baseText(base int) string {
+	return signedText(big.NewInt(int64(base)))
+}
+
+// signedText (i *big.Int) string - i, in parentheses if it is negative so it can be written in a sum
+func signedText(i *big.Int) string {
+	if i.Sign() < 0 {
+		return "(" + i.String() + ")"
+	}
+	return i.String()
+}
@@ -0,0 +1,163 @@
+package format
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/clarketm/ncalc/binary"
+	"github.com/clarketm/ncalc/decimal"
+	"github.com/clarketm/ncalc/hexadecimal"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/ieee754"
+	"github.com/clarketm/ncalc/octal"
+	"github.com/clarketm/ncalc/radix"
+	"github.com/clarketm/ncalc/signed"
+	"github.com/clarketm/ncalc/stepbystep"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Radix is a positional number in a base from 2 to 62
+type Radix int
+
+func init() {
+	Register(Radix(utils.BINARY_BASE), "b")
+	Register(Radix(utils.OCTAL_BASE), "o")
+	Register(Radix(utils.DECIMAL_BASE), "d")
+	Register(Radix(utils.HEXADECIMAL_BASE), "h", "hex")
+}
+
+// Name () string
+func (r Radix) Name() string {
+	return utils.RadixFormat(int(r))
+}
+
+// Title () i18n.Message
+func (r Radix) Title() i18n.Message {
+	return stepbystep.BaseName(int(r))
+}
+
+// Base () int
+func (r Radix) Base() int {
+	return int(r)
+}
+
+// Steps (src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) - converting between two
+// different bases, digit by digit after the point for a fraction
+func (r Radix) Steps(src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) {
+	from, fromRadix := src.(Radix)
+	to, toRadix := dst.(Radix)
+	if !fromRadix || !toRadix || from == to {
+		return nil, false
+	}
+	return sequenceSteps(func(s string) (*stepbystep.StepByStepResult, error) {
+		if utils.IsFraction(s) {
+			return stepbystep.FractionSteps(s, from.Base(), to.Base(), opts.Precision)
+		}
+		return stepbystep.RadixSteps(s, from.Base(), to.Base(), opts.Method)
+	}), true
+}
+
+// Question (s string, src, dst Format) i18n.Message
+func (Radix) Question(s string, src, dst Format) i18n.Message {
+	return i18n.Message{}
+}
+
+// Parse (s string) (Value, error)
+func (r Radix) Parse(s string) (Value, error) {
+	return parseSeq(s, r.parse)
+}
+
+// parse (s string) (Value, error) - a single number
+func (r Radix) parse(s string) (Value, error) {
+	base := int(r)
+	if utils.IsFraction(s) {
+		q, err := utils.ParseFraction(s, base)
+		if err != nil {
+			return Value{}, err
+		}
+		return Value{Rat: q}, nil
+	}
+
+	var i *big.Int
+	var err error
+	switch base {
+	case utils.BINARY_BASE:
+		i, err = binary.ValueOfE(s)
+	case utils.OCTAL_BASE:
+		i, err = octal.ValueOfE(s)
+	case utils.DECIMAL_BASE:
+		return parseDecimal(s)
+	case utils.HEXADECIMAL_BASE:
+		i, err = hexadecimal.ValueOfE(s)
+	default:
+		i, err = radix.ValueOfE(s, base)
+	}
+	if err != nil {
+		return Value{}, err
+	}
+
+	// a non-decimal integer doubles as a bit pattern
+	v := integer(i)
+	if i.Sign() >= 0 {
+		v.Bits = i
+		v.Width = literalWidth(s, base)
+	}
+	return v, nil
+}
+
+// literalWidth (s string, base int) int - the bits spelled by the digits of s when base is a power of two, so leading zeros count
+func literalWidth(s string, base int) int {
+	digits, base := utils.Digits(s, base)
+	if base&(base-1) != 0 {
+		return 0
+	}
+	return len(strings.TrimLeft(digits, "+")) * (big.NewInt(int64(base - 1)).BitLen())
+}
+
+// Format (v Value, opts utils.Options) (string, error)
+func (r Radix) Format(v Value, opts utils.Options) (string, error) {
+	base := int(r)
+	if err := checkASCII(v); err != nil {
+		return "", err
+	}
+
+	switch {
+	case v.Seq != nil:
+		return formatSeq(r, v, " ", opts)
+	case base == utils.DECIMAL_BASE && v.Text != "":
+		return v.Text, nil
+	case v.Bits != nil && v.Source != "" && base != utils.DECIMAL_BASE:
+		// a fixed-width encoding is written as its raw pattern, and in decimal as its value
+		return signed.Pad(v.Bits, v.Width, base), nil
+	case v.Rat == nil:
+		return "", i18n.Errorf("%s has no %s representation", v.Text, r.Name())
+	}
+	return utils.FormatFraction(v.Rat, base, opts.Precision), nil
+}
+
+// parseDecimal (s string) (Value, error)
+func parseDecimal(s string) (Value, error) {
+	// Inf and NaN only make sense as floating point input
+	switch name, _, _ := strings.Cut(strings.ToLower(strings.TrimLeft(s, "+-")), ":"); name {
+	case "inf", "infinity", "nan", "snan":
+		if _, err := ieee754.Double.Parse(s); err != nil {
+			return Value{}, err
+		}
+		return Value{Text: s}, nil
+	}
+
+	i, err := decimal.ValueOfE(s)
+	if err != nil {
+		// scientific notation, e.g. 6.02e23
+		if q, ok := new(big.Rat).SetString(s); ok && strings.ContainsAny(s, "eE") {
+			return Value{Rat: q}, nil
+		}
+		return Value{}, err
+	}
+
+	v := integer(i)
+	if i.Sign() == 0 && strings.HasPrefix(s, "-") {
+		v.Text = "-0"
+	}
+	return v, nil
+}
@@ -0,0 +1,192 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/roman"
+	"github.com/clarketm/ncalc/stepbystep"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Roman is an integer from 1 to 3999 written as a canonical Roman numeral
+type Roman struct{}
+
+func init() {
+	Register(Roman{}, "r")
+}
+
+// Name () string
+func (Roman) Name() string {
+	return utils.ROMAN
+}
+
+// Title () i18n.Message
+func (Roman) Title() i18n.Message {
+	return i18n.M("Roman numerals")
+}
+
+// Parse (s string) (Value, error)
+func (Roman) Parse(s string) (Value, error) {
+	return parseSeq(s, func(s string) (Value, error) {
+		i, err := roman.ValueOfE(s)
+		if err != nil {
+			return Value{}, err
+		}
+		return integer(i), nil
+	})
+}
+
+// Format (v Value, opts utils.Options) (string, error)
+func (r Roman) Format(v Value, opts utils.Options) (string, error) {
+	if err := checkASCII(v); err != nil {
+		return "", err
+	}
+	if v.Seq != nil {
+		return formatSeq(r, v, " ", opts)
+	}
+	if v.Rat == nil || !v.Rat.IsInt() {
+		return "", i18n.Errorf("%s encodes integers, not %s", r.Name(), text(v))
+	}
+	return roman.StringE(v.Rat.Num())
+}
+
+// Steps (src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) - writing a radix number
+// by taking away the largest value that fits, or reading a numeral back to a radix
+func (r Roman) Steps(src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) {
+	from, fromRadix := src.(Radix)
+	to, toRadix := dst.(Radix)
+	_, fromRoman := src.(Roman)
+	_, toRoman := dst.(Roman)
+	switch {
+	case fromRadix && toRoman:
+		return sequenceSteps(func(s string) (*stepbystep.StepByStepResult, error) { return r.encodeSteps(s, from, opts) }), true
+	case fromRoman && toRadix:
+		return sequenceSteps(func(s string) (*stepbystep.StepByStepResult, error) { return r.decodeSteps(s, to, opts) }), true
+	}
+	return nil, false
+}
+
+// Question (s string, src, dst Format) i18n.Message
+func (r Roman) Question(s string, src, dst Format) i18n.Message {
+	if _, ok := src.(Roman); ok {
+		return decodeQuestion(r, s, dst)
+	}
+	return i18n.Message{}
+}
+
+// encodeSteps (s string, src Radix, opts utils.Options) (*stepbystep.StepByStepResult, error) - repeatedly
+// take away the largest value that still fits and write its symbol
+func (r Roman) encodeSteps(s string, src Radix, opts utils.Options) (*stepbystep.StepByStepResult, error) {
+	result := &stepbystep.StepByStepResult{
+		Input:      s,
+		InputBase:  src.Name(),
+		Output:     "",
+		OutputBase: r.Name(),
+		Method:     r.Name(),
+	}
+
+	d, err := Convert(s, src.Name(), utils.DECIMAL, opts)
+	if err == nil {
+		result.Output, err = Convert(s, src.Name(), r.Name(), opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Method: Repeatedly take away the largest value in the table that still fits and write its symbol. The subtractive pairs CM (900), CD (400), XC (90), XL (40), IX (9) and IV (4) write a smaller symbol before a larger one to take it away.")})
+	step := 1
+
+	// a number in another base goes through decimal first
+	if src.Base() != utils.DECIMAL_BASE {
+		result.Add(&stepbystep.Note{Line: i18n.M("Step %d: Convert %s (base %d) to decimal: %s", step, s, src.Base(), d)})
+		step++
+	}
+
+	n, _ := strconv.Atoi(d)
+	terms := roman.Terms(n)
+	result.Add(&stepbystep.Note{Line: i18n.M("Step %d: Take away the largest value that fits:", step)})
+	for _, t := range terms {
+		e := &stepbystep.Equation{Terms: []stepbystep.Expr{
+			stepbystep.Chain(stepbystep.Minus, stepbystep.Num(n), stepbystep.Num(t.Value)),
+			stepbystep.Chain(stepbystep.Maps, stepbystep.Num(n-t.Value), stepbystep.Lit(t.Symbol)),
+		}}
+		if t.Subtractive() {
+			e.Comment = i18n.M("subtractive pair: %s", pairText(t))
+		}
+		result.Add(e)
+		n -= t.Value
+	}
+	step++
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Step %d: Join the symbols: %s = %s", step, symbolsOf(terms), result.Output)})
+	result.Add(&stepbystep.Result{Value: result.Output})
+	return result, nil
+}
+
+// decodeSteps (s string, dst Radix, opts utils.Options) (*stepbystep.StepByStepResult, error) - split off
+// the subtractive pairs and add the values of the parts
+func (r Roman) decodeSteps(s string, dst Radix, opts utils.Options) (*stepbystep.StepByStepResult, error) {
+	result := &stepbystep.StepByStepResult{
+		Input:      s,
+		InputBase:  r.Name(),
+		Output:     "",
+		OutputBase: dst.Name(),
+		Method:     r.Name(),
+	}
+
+	value, err := roman.ValueOfE(s)
+	if err != nil {
+		return nil, err
+	}
+	output, err := Convert(s, r.Name(), dst.Name(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Method: Read from left to right. A symbol followed by a larger one forms a subtractive pair and is taken away from it; every other symbol is added.")})
+	n := int(value.Int64())
+	terms := roman.Terms(n)
+	result.Add(&stepbystep.Note{Line: i18n.M("Step 1: Split %s into symbols and subtractive pairs: %s", strings.ToUpper(strings.TrimSpace(s)), symbolsOf(terms))})
+	values := make([]string, len(terms))
+	for i, t := range terms {
+		values[i] = strconv.Itoa(t.Value)
+		if t.Subtractive() {
+			result.Add(&stepbystep.Equation{Terms: []stepbystep.Expr{stepbystep.Lit(t.Symbol), pairExpr(t), stepbystep.Num(t.Value)}})
+		} else {
+			result.Add(&stepbystep.Equation{Terms: []stepbystep.Expr{stepbystep.Lit(t.Symbol), stepbystep.Lit(values[i])}})
+		}
+	}
+	result.Add(&stepbystep.Note{Line: i18n.M("Step 2: Add the values: %s = %d", strings.Join(values, " + "), n)})
+
+	// then to the target base if it is not decimal
+	if dst.Base() != utils.DECIMAL_BASE {
+		result.Add(&stepbystep.Note{Line: i18n.M("Step 3: Convert %d to base %d: %s", n, dst.Base(), output)})
+	}
+
+	result.Output = output
+	result.Add(&stepbystep.Result{Value: result.Output, Base: dst.Base()})
+	return result, nil
+}
+
+// pairText (t roman.Numeral) string - a subtractive pair written as "1000 - 100 = 900"
+func pairText(t roman.Numeral) string {
+	return fmt.Sprintf("%s = %d", pairExpr(t).Text(), t.Value)
+}
+
+// pairExpr (t roman.Numeral) stepbystep.Expr - the subtraction a subtractive pair stands for, e.g. 1000 - 100
+func pairExpr(t roman.Numeral) stepbystep.Expr {
+	small := roman.ValueOf(t.Symbol[:1]).Int64()
+	return stepbystep.Chain(stepbystep.Minus, stepbystep.Num(int64(t.Value)+small), stepbystep.Num(small))
+}
+
+// symbolsOf (terms []roman.Numeral) string - the symbols, separated by spaces
+func symbolsOf(terms []roman.Numeral) string {
+	symbols := make([]string, len(terms))
+	for i, t := range terms {
+		symbols[i] = t.Symbol
+	}
+	return strings.Join(symbols, " ")
+}
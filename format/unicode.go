@@ -0,0 +1,322 @@
+package format
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/stepbystep"
+	"github.com/clarketm/ncalc/utf"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Unicode is a sequence of code points written U+XXXX
+type Unicode struct{}
+
+// UTF is the bytes of text in a Unicode encoding form
+type UTF struct {
+	Encoding utf.Encoding
+}
+
+func init() {
+	Register(Unicode{}, "u")
+	Register(UTF{utf.UTF8}, "utf-8")
+	Register(UTF{utf.UTF16LE}, "utf-16le")
+	Register(UTF{utf.UTF16BE}, "utf16", "utf-16", "utf-16be")
+	Register(UTF{utf.UTF32}, "utf32be", "utf-32", "utf-32be")
+	Register(UTF{utf.UTF32LE}, "utf-32le")
+}
+
+// Name () string
+func (Unicode) Name() string {
+	return utils.UNICODE
+}
+
+// Title () i18n.Message
+func (Unicode) Title() i18n.Message {
+	return i18n.M("Unicode code points")
+}
+
+// Parse (s string) (Value, error)
+func (u Unicode) Parse(s string) (Value, error) {
+	// the text in parentheses is only a reading aid
+	s, _, _ = strings.Cut(s, " (")
+	v, err := parseSeq(s, func(s string) (Value, error) {
+		c, err := utf.ParseCodePoint(s)
+		if err != nil {
+			return Value{}, err
+		}
+		return integer(c), nil
+	})
+	if err != nil {
+		return Value{}, err
+	}
+
+	c, _ := Codes(v)
+	if _, err := utf.Runes(c); err != nil {
+		return Value{}, err
+	}
+	return v, nil
+}
+
+// Format (v Value, opts utils.Options) (string, error)
+func (Unicode) Format(v Value, opts utils.Options) (string, error) {
+	runes, err := runesOf(v, utf.UTF8)
+	if err != nil {
+		return "", err
+	}
+	return utf.FormatCodePoints(runes) + " (" + strconv.Quote(string(runes)) + ")", nil
+}
+
+// Name () string
+func (f UTF) Name() string {
+	return f.Encoding.Name
+}
+
+// Title () i18n.Message
+func (f UTF) Title() i18n.Message {
+	return i18n.M("%s bytes", f.Encoding.Title)
+}
+
+// Parse (s string) (Value, error)
+func (f UTF) Parse(s string) (Value, error) {
+	s, _, _ = strings.Cut(s, " (")
+	b, err := utf.ParseBytes(s)
+	if err != nil {
+		return Value{}, err
+	}
+	runes, err := f.Encoding.Decode(b)
+	if err != nil {
+		return Value{}, err
+	}
+
+	c := make([]*big.Int, len(runes))
+	for i, r := range runes {
+		c[i] = big.NewInt(int64(r))
+	}
+	v := sequence(c)
+	v.Source = f.Name()
+	return v, nil
+}
+
+// Format (v Value, opts utils.Options) (string, error)
+func (f UTF) Format(v Value, opts utils.Options) (string, error) {
+	runes, err := runesOf(v, f.Encoding)
+	if err != nil {
+		return "", err
+	}
+	return utf.FormatBytes(f.Encoding.EncodeRunes(runes)) + " (" + utf.FormatCodePoints(runes) + ")", nil
+}
+
+// Steps (src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) - encoding text, code points
+// or a number in UTF-8, or decoding UTF-8 bytes to characters
+func (f UTF) Steps(src, dst Format, opts utils.Options) (stepbystep.StepsFunc, bool) {
+	if f.Encoding != utf.UTF8 {
+		return nil, false
+	}
+	if dst == f {
+		switch src.(type) {
+		case Ascii, Unicode, Radix:
+			return func(s string) (*stepbystep.StepByStepResult, error) { return utf8EncodeSteps(s, src) }, true
+		}
+	}
+	if src == f {
+		switch dst.(type) {
+		case Ascii, Unicode:
+			return func(s string) (*stepbystep.StepByStepResult, error) { return utf8DecodeSteps(s, dst, opts) }, true
+		}
+	}
+	return nil, false
+}
+
+// Question (s string, src, dst Format) i18n.Message
+func (f UTF) Question(s string, src, dst Format) i18n.Message {
+	if src == f {
+		return i18n.M("Decode the %s bytes \\texttt{%s} to %s.", f.Encoding.Title, s, dst.Title())
+	}
+	// a radix number keeps the question of its base
+	if _, ok := src.(Radix); !ok {
+		return i18n.M("Encode \\texttt{%s} (%s) in %s.", s, src.Title(), f.Encoding.Title)
+	}
+	return i18n.Message{}
+}
+
+// runesOf (v Value, e utf.Encoding) ([]rune, error) - the characters of v; a byte string is decoded with e
+func runesOf(v Value, e utf.Encoding) ([]rune, error) {
+	if v.Bytes != nil {
+		return e.Decode(v.Bytes)
+	}
+	c, err := Codes(v)
+	if err != nil {
+		return nil, err
+	}
+	return utf.Runes(c)
+}
+
+// utf8EncodeSteps (s string, src Format) (*stepbystep.StepByStepResult, error) - encode each character in
+// UTF-8, showing the marker bits and the payload bits
+func utf8EncodeSteps(s string, src Format) (*stepbystep.StepByStepResult, error) {
+	result := &stepbystep.StepByStepResult{
+		Input:      s,
+		InputBase:  src.Name(),
+		Output:     "",
+		OutputBase: utils.UTF8,
+		Method:     utils.UTF8,
+	}
+
+	runes, err := parseRunes(s, src)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Method: Choose the number of bytes from the code point, then fill the payload bits (x) of the byte templates from left to right.")})
+	for _, l := range utf.Layouts {
+		min := rune(0)
+		if l.Bytes > 1 {
+			min = utf.Layouts[l.Bytes-2].Max + 1
+		}
+		result.Add(&stepbystep.Note{Line: i18n.M("U+%04X to U+%04X: %s (%d payload bits)",
+			min, l.Max, template(l), l.PayloadBits())})
+	}
+
+	var out []byte
+	for i, r := range runes {
+		l := utf.LayoutOf(r)
+		bits := strconv.FormatInt(int64(r), 2)
+		result.Add(&stepbystep.Note{Line: i18n.M("Step %d: %s = U+%04X = %s in binary", i+1, character(r), r, bits)})
+		result.Add(&stepbystep.Detail{Line: i18n.M("U+%04X is at most U+%04X, so it takes %d byte(s): %s", r, l.Max, l.Bytes, template(l))})
+
+		// pad the payload to the bits of the template, then split it by byte
+		padded := fmt.Sprintf("%0*s", l.PayloadBits(), bits)
+		result.Add(&stepbystep.Detail{Line: i18n.M("Pad to %d payload bits: %s", l.PayloadBits(), padded)})
+		parts := splitPayload(padded, l)
+		if l.Bytes > 1 {
+			result.Add(&stepbystep.Detail{Line: i18n.M("Split the payload: %s", strings.Join(parts, " | "))})
+		}
+
+		encoded := utf.UTF8.EncodeRune(r)
+		for j, part := range parts {
+			marker := "10"
+			if j == 0 {
+				marker = l.Lead
+			}
+			result.Add(&stepbystep.Equation{
+				Label: i18n.M("Byte %d", j+1),
+				Terms: []stepbystep.Expr{
+					stepbystep.Chain(stepbystep.Plus, stepbystep.Lit(marker), stepbystep.Lit(part)),
+					stepbystep.Lit(fmt.Sprintf("%08b", encoded[j])),
+					stepbystep.Lit(fmt.Sprintf("%02X", encoded[j])),
+				},
+			})
+		}
+		result.Add(&stepbystep.Equation{Terms: []stepbystep.Expr{stepbystep.Chain(stepbystep.Maps, stepbystep.Lit(character(r)), stepbystep.Lit(utf.FormatBytes(encoded)))}})
+		out = append(out, encoded...)
+	}
+
+	result.Output = utf.FormatBytes(out) + " (" + utf.FormatCodePoints(runes) + ")"
+	result.Add(&stepbystep.Result{Value: result.Output})
+	return result, nil
+}
+
+// utf8DecodeSteps (s string, dst Format, opts utils.Options) (*stepbystep.StepByStepResult, error) - read the
+// length from each lead byte, drop the marker bits and join the payload bits
+func utf8DecodeSteps(s string, dst Format, opts utils.Options) (*stepbystep.StepByStepResult, error) {
+	result := &stepbystep.StepByStepResult{
+		Input:      s,
+		InputBase:  utils.UTF8,
+		Output:     "",
+		OutputBase: dst.Name(),
+		Method:     utils.UTF8,
+	}
+
+	s, _, _ = strings.Cut(s, " (")
+	b, err := utf.ParseBytes(s)
+	if err == nil {
+		_, err = utf.UTF8.Decode(b)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Add(&stepbystep.Note{Line: i18n.M("Method: The high bits of the lead byte give the sequence length (0: 1 byte, 110: 2, 1110: 3, 11110: 4), every continuation byte starts with 10; join the remaining payload bits.")})
+
+	var codes []*big.Int
+	for off, step := 0, 1; off < len(b); step++ {
+		l, payload, _ := utf.Lead(b[off])
+		result.Add(&stepbystep.Note{Line: i18n.M("Step %d: Byte %02X (offset %d) = %08b", step, b[off], off, b[off])})
+		payloadBits := []string{fmt.Sprintf("%0*b", 8-len(l.Lead), payload)}
+		result.Add(&stepbystep.Detail{Line: i18n.M("Lead bits %s: a %d-byte sequence, payload %s", l.Lead, l.Bytes, payloadBits[0])})
+
+		r := payload
+		for i := 1; i < l.Bytes; i++ {
+			c := b[off+i]
+			payloadBits = append(payloadBits, fmt.Sprintf("%06b", c&0x3F))
+			result.Add(&stepbystep.Detail{Line: i18n.M("Byte %02X (offset %d) = %08b: continuation bits 10, payload %06b", c, off+i, c, c&0x3F)})
+			r = r<<6 | rune(c&0x3F)
+		}
+		result.Add(&stepbystep.Equation{
+			Label:   i18n.M("Payload"),
+			Terms:   []stepbystep.Expr{stepbystep.Lit(strings.Join(payloadBits, " ")), stepbystep.Lit(strconv.FormatInt(int64(r), 2)), stepbystep.Lit(fmt.Sprintf("U+%04X", r))},
+			Comment: i18n.M(character(r)),
+		})
+
+		codes = append(codes, big.NewInt(int64(r)))
+		off += l.Bytes
+	}
+
+	output, err := Convert(strings.Join(codesText(codes), " "), utils.UNICODE, dst.Name(), opts)
+	if err != nil {
+		return nil, err
+	}
+	result.Output = output
+	result.Add(&stepbystep.Result{Value: result.Output})
+	return result, nil
+}
+
+// parseRunes (s string, src Format) ([]rune, error) - the characters of s read in format src
+func parseRunes(s string, src Format) ([]rune, error) {
+	v, err := src.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	codes, err := Codes(v)
+	if err != nil {
+		return nil, err
+	}
+	return utf.Runes(codes)
+}
+
+// template (l utf.Layout) string - the bytes of a UTF-8 sequence, e.g. 110xxxxx 10xxxxxx
+func template(l utf.Layout) string {
+	bytes := []string{l.Lead + strings.Repeat("x", 8-len(l.Lead))}
+	for i := 1; i < l.Bytes; i++ {
+		bytes = append(bytes, "10xxxxxx")
+	}
+	return strings.Join(bytes, " ")
+}
+
+// splitPayload (bits string, l utf.Layout) []string - the payload bits split by the x bits of each byte
+func splitPayload(bits string, l utf.Layout) []string {
+	first := 8 - len(l.Lead)
+	parts := []string{bits[:first]}
+	for i := first; i < len(bits); i += 6 {
+		parts = append(parts, bits[i:i+6])
+	}
+	return parts
+}
+
+// character (r rune) string - r in single quotes
+func character(r rune) string {
+	return strconv.QuoteRune(r)
+}
+
+// codesText (codes []*big.Int) []string - the character codes written U+XXXX
+func codesText(codes []*big.Int) []string {
+	text := make([]string, len(codes))
+	for i, c := range codes {
+		text[i] = utils.CodePoint(c)
+	}
+	return text
+}
@@ -2,7 +2,7 @@
 """
 Script để tạo ngẫu nhiên các câu hỏi chuyển đổi cơ số.
 Sử dụng: python generate_input.py [số lượng câu hỏi] [kiểu đầu vào] [kiểu đầu ra] [tên file đầu ra]
-Các kiểu hợp lệ: binary, decimal, octal, hexadecimal, all
+Các kiểu hợp lệ: binary, decimal, octal, hexadecimal, bcd, bcd-packed, roman, all
 Ví dụ: python generate_input.py 10 hexadecimal binary output.txt
 Mặc định: 10 câu hỏi, kiểu ngẫu nhiên, file output là input_v1.txt
 """
@@ -36,9 +36,32 @@ def generate_hexadecimal_number(min_len=2, max_len=3):
     hexadecimal = random.choice(hex_digits) + ''.join(random.choice('0123456789ABCDEF') for _ in range(length-1))
     return hexadecimal
 
+def generate_bcd_number(packed=False, min_val=1, max_val=9999):
+    """Tạo ngẫu nhiên một số BCD, các nhóm nối bằng '_' vì file đầu vào tách cột bằng dấu cách"""
+    digits = str(random.randint(min_val, max_val))
+    if packed:
+        if len(digits) % 2:
+            digits = '0' + digits
+        return '_'.join(digits[i:i+2] for i in range(0, len(digits), 2))
+    return '_'.join(format(int(d), '04b') for d in digits)
+
+def generate_roman_number(min_val=1, max_val=3999):
+    """Tạo ngẫu nhiên một số La Mã ở dạng chuẩn, từ min_val đến max_val"""
+    value = random.randint(min_val, max_val)
+    numerals = [(1000, 'M'), (900, 'CM'), (500, 'D'), (400, 'CD'), (100, 'C'), (90, 'XC'),
+                (50, 'L'), (40, 'XL'), (10, 'X'), (9, 'IX'), (5, 'V'), (4, 'IV'), (1, 'I')]
+    roman = ''
+    for n, symbol in numerals:
+        while value >= n:
+            roman += symbol
+            value -= n
+    return roman
+
 def generate_conversion_problem(input_base=None, output_base=None):
     """Tạo một bài toán chuyển đổi với cơ số đầu vào và đầu ra được chỉ định"""
-    valid_bases = ['binary', 'decimal', 'octal', 'hexadecimal']
+    valid_bases = ['binary', 'decimal', 'octal', 'hexadecimal', 'bcd', 'bcd-packed', 'roman']
+    # BCD và số La Mã chỉ chuyển đổi qua lại với các cơ số, không chuyển đổi với nhau
+    bcd_bases = ['bcd', 'bcd-packed', 'roman']
     
     # Nếu không chỉ định cơ số đầu vào, chọn ngẫu nhiên
     if input_base is None or input_base == 'all':
@@ -51,6 +74,8 @@ def generate_conversion_problem(input_base=None, output_base=None):
     if output_base is None:
         output_bases = valid_bases.copy()
         output_bases.remove(input_base)  # Loại bỏ cơ số đầu vào ra khỏi danh sách đầu ra
+        if input_base in bcd_bases:
+            output_bases = [b for b in output_bases if b not in bcd_bases]
         # Có thể chọn "all" với xác suất 20%
         if random.random() < 0.2:
             output_base = 'all'
@@ -58,8 +83,7 @@ def generate_conversion_problem(input_base=None, output_base=None):
             output_base = random.choice(output_bases)
     elif output_base not in valid_bases and output_base != 'all':
         print(f"Lỗi: Cơ số đầu ra '{output_base}' không hợp lệ. Sử dụng giá trị mặc định.")
-        output_bases = valid_bases.copy()
-        output_bases.remove(input_base)
+        output_bases = [b for b in valid_bases if b != input_base and not (input_base in bcd_bases and b in bcd_bases)]
         output_base = random.choice(output_bases)
     
     # Tạo số ngẫu nhiên dựa vào cơ số đầu vào
@@ -69,6 +93,8 @@ def generate_conversion_problem(input_base=None, output_base=None):
         number = generate_decimal_number()
     elif input_base == 'octal':
         number = generate_octal_number()
+    elif input_base in bcd_bases:
+        number = generate_roman_number() if input_base == 'roman' else generate_bcd_number(packed=input_base == 'bcd-packed')
     else:  # hexadecimal
         number = generate_hexadecimal_number()
     
@@ -149,7 +175,7 @@ def main():
     if len(sys.argv) == 1:
         print("\nHướng dẫn sử dụng:")
         print("python generate_input.py [số lượng câu hỏi] [kiểu đầu vào] [kiểu đầu ra] [tên file đầu ra]")
-        print("Các kiểu hợp lệ: binary, decimal, octal, hexadecimal, all")
+        print("Các kiểu hợp lệ: binary, decimal, octal, hexadecimal, bcd, bcd-packed, roman, all")
         print("Ví dụ: python generate_input.py 10 hexadecimal binary output.txt")
 
 if __name__ == "__main__":
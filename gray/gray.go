@@ -0,0 +1,121 @@
+/*
+
+GRAY
+
+*/
+
+package gray
+
+import (
+	"math/big"
+
+	"github.com/clarketm/ncalc/binary"
+	"github.com/clarketm/ncalc/decimal"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Binary2Gray (s string) string - Binary2GrayE, but panics if it fails
+func Binary2Gray(s string) string {
+	r, err := Binary2GrayE(s)
+	utils.CheckError(err, utils.BINARY, utils.GRAY)
+	return r
+}
+
+// Binary2GrayE (s string) (string, error)
+func Binary2GrayE(s string) (string, error) {
+	i, err := binary.ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return StringE(i)
+}
+
+// Decimal2Gray (s string) string - Decimal2GrayE, but panics if it fails
+func Decimal2Gray(s string) string {
+	r, err := Decimal2GrayE(s)
+	utils.CheckError(err, utils.DECIMAL, utils.GRAY)
+	return r
+}
+
+// Decimal2GrayE (s string) (string, error)
+func Decimal2GrayE(s string) (string, error) {
+	i, err := decimal.ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return StringE(i)
+}
+
+// Gray2Binary (s string) string - Gray2BinaryE, but panics if it fails
+func Gray2Binary(s string) string {
+	r, err := Gray2BinaryE(s)
+	utils.CheckError(err, utils.GRAY, utils.BINARY)
+	return r
+}
+
+// Gray2BinaryE (s string) (string, error)
+func Gray2BinaryE(s string) (string, error) {
+	i, err := ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return i.Text(utils.BINARY_BASE), nil
+}
+
+// Gray2Decimal (s string) string - Gray2DecimalE, but panics if it fails
+func Gray2Decimal(s string) string {
+	r, err := Gray2DecimalE(s)
+	utils.CheckError(err, utils.GRAY, utils.DECIMAL)
+	return r
+}
+
+// Gray2DecimalE (s string) (string, error)
+func Gray2DecimalE(s string) (string, error) {
+	i, err := ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return i.String(), nil
+}
+
+// Encode (i *big.Int) *big.Int - i XOR (i >> 1)
+func Encode(i *big.Int) *big.Int {
+	return new(big.Int).Xor(i, new(big.Int).Rsh(i, 1))
+}
+
+// Decode (g *big.Int) *big.Int - XOR of g shifted right by every amount
+func Decode(g *big.Int) *big.Int {
+	i := new(big.Int).Set(g)
+	for shift := new(big.Int).Rsh(g, 1); shift.Sign() > 0; shift.Rsh(shift, 1) {
+		i.Xor(i, shift)
+	}
+	return i
+}
+
+// StringE (i *big.Int) (string, error) - the Gray code of i in binary
+func StringE(i *big.Int) (string, error) {
+	if i.Sign() < 0 {
+		return "", &utils.ErrOverflow{Value: i.String(), Kind: i18n.M("Gray code"), Min: "0"}
+	}
+	return Encode(i).Text(utils.BINARY_BASE), nil
+}
+
+// ValueOf (s string) *big.Int - ValueOfE, but panics if it fails
+func ValueOf(s string) *big.Int {
+	i, err := ValueOfE(s)
+	utils.CheckError(err, utils.STRING, utils.GRAY)
+	return i
+}
+
+// ValueOfE (s string) (*big.Int, error) - the integer whose Gray code is the bit string s
+func ValueOfE(s string) (*big.Int, error) {
+	g, err := utils.ParseRadix(s, utils.BINARY_BASE)
+	if err != nil {
+		return nil, err
+	}
+	if g.Sign() < 0 {
+		return nil, &utils.ErrInvalidDigit{Pos: 1, Rune: '-', Base: utils.BINARY_BASE}
+	}
+	return Decode(g), nil
+}
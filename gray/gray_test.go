@@ -0,0 +1,30 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package gray_test
+
+import (
+	"fmt"
+
+	"github.com/clarketm/ncalc/gray"
+)
+
+func Example() {
+
+	// GRAY
+	fmt.Println(gray.Decimal2Gray("13"))
+	fmt.Println(gray.Binary2Gray("1101"))
+	fmt.Println(gray.Gray2Decimal("1011"))
+	fmt.Println(gray.Gray2Binary("1011"))
+
+	// Output:
+	// 1011
+	// 1011
+	// 13
+	// 1101
+}
@@ -8,48 +8,144 @@ package hexadecimal
 
 import (
 	"fmt"
-	"strconv"
+	"math/big"
 
 	"github.com/clarketm/ncalc/decimal"
 	"github.com/clarketm/ncalc/utils"
 )
 
-// Hexadecimal2Ascii (s string) string
+// Hexadecimal2Ascii (s string) string - Hexadecimal2AsciiE, but panics if it fails
 func Hexadecimal2Ascii(s string) string {
-	return fmt.Sprintf("%q", ValueOf(s))
+	r, err := Hexadecimal2AsciiE(s)
+	utils.CheckError(err, utils.HEXADECIMAL, utils.ASCII)
+	return r
 }
 
-// Hexadecimal2Binary (s string) string
+// Hexadecimal2AsciiE (s string) (string, error)
+func Hexadecimal2AsciiE(s string) (string, error) {
+	var codes []*big.Int
+	for _, f := range utils.Fields(s) {
+		i, err := ValueOfE(f)
+		if err != nil {
+			return "", err
+		}
+		codes = append(codes, i)
+	}
+	return utils.QuoteASCII(codes)
+}
+
+// Hexadecimal2Binary (s string) string - Hexadecimal2BinaryE, but panics if it fails
 func Hexadecimal2Binary(s string) string {
-	i, err := utils.Parse(s, utils.HEXADECIMAL_BASE)
+	r, err := Hexadecimal2BinaryE(s)
 	utils.CheckError(err, utils.HEXADECIMAL, utils.BINARY)
-	s = strconv.Itoa(int(i))
-	return decimal.Decimal2Binary(s)
+	return r
 }
 
-// Hexadecimal2Octal (s string) string
-func Hexadecimal2Octal(s string) string {
+// Hexadecimal2BinaryE (s string) (string, error)
+func Hexadecimal2BinaryE(s string) (string, error) {
+	if utils.IsFraction(s) {
+		return utils.ConvertFractionE(s, utils.HEXADECIMAL_BASE, utils.BINARY_BASE)
+	}
 	i, err := utils.Parse(s, utils.HEXADECIMAL_BASE)
+	if err != nil {
+		return "", err
+	}
+	return decimal.Decimal2BinaryE(i.String())
+}
+
+// Hexadecimal2Octal (s string) string - Hexadecimal2OctalE, but panics if it fails
+func Hexadecimal2Octal(s string) string {
+	r, err := Hexadecimal2OctalE(s)
 	utils.CheckError(err, utils.HEXADECIMAL, utils.OCTAL)
-	s = strconv.Itoa(int(i))
-	return decimal.Decimal2Octal(s)
+	return r
 }
 
-// Hexadecimal2Decimal (s string) string
-func Hexadecimal2Decimal(s string) string {
+// Hexadecimal2OctalE (s string) (string, error)
+func Hexadecimal2OctalE(s string) (string, error) {
+	if utils.IsFraction(s) {
+		return utils.ConvertFractionE(s, utils.HEXADECIMAL_BASE, utils.OCTAL_BASE)
+	}
 	i, err := utils.Parse(s, utils.HEXADECIMAL_BASE)
+	if err != nil {
+		return "", err
+	}
+	return decimal.Decimal2OctalE(i.String())
+}
+
+// Hexadecimal2Decimal (s string) string - Hexadecimal2DecimalE, but panics if it fails
+func Hexadecimal2Decimal(s string) string {
+	r, err := Hexadecimal2DecimalE(s)
 	utils.CheckError(err, utils.HEXADECIMAL, utils.DECIMAL)
-	return strconv.Itoa(int(i))
+	return r
+}
+
+// Hexadecimal2DecimalE (s string) (string, error)
+func Hexadecimal2DecimalE(s string) (string, error) {
+	if utils.IsFraction(s) {
+		return utils.ConvertFractionE(s, utils.HEXADECIMAL_BASE, utils.DECIMAL_BASE)
+	}
+	i, err := utils.Parse(s, utils.HEXADECIMAL_BASE)
+	if err != nil {
+		return "", err
+	}
+	return i.String(), nil
 }
 
-// String (s string) string
+// String (s string) string - StringE, but panics if it fails
 func String(s string) string {
-	return fmt.Sprintf("%x", ValueOf(s))
+	r, err := StringE(s)
+	utils.CheckError(err, utils.STRING, utils.HEXADECIMAL)
+	return r
 }
 
-// ValueOf (s string) int
-func ValueOf(s string) int {
-	i, err := utils.Parse(s, utils.HEXADECIMAL_BASE)
+// StringE (s string) (string, error)
+func StringE(s string) (string, error) {
+	if utils.IsFraction(s) {
+		return utils.ConvertFractionE(s, utils.HEXADECIMAL_BASE, utils.HEXADECIMAL_BASE)
+	}
+	i, err := ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", i), nil
+}
+
+// ValueOf (s string) *big.Int - ValueOfE, but panics if it fails
+func ValueOf(s string) *big.Int {
+	i, err := ValueOfE(s)
 	utils.CheckError(err, utils.STRING, utils.HEXADECIMAL)
-	return int(i)
+	return i
+}
+
+// ValueOfE (s string) (*big.Int, error)
+func ValueOfE(s string) (*big.Int, error) {
+	return utils.Parse(s, utils.HEXADECIMAL_BASE)
+}
+
+// Bytes (s string) []byte - BytesE, but panics if it fails
+func Bytes(s string) []byte {
+	b, err := BytesE(s)
+	utils.CheckError(err, utils.HEXADECIMAL, utils.STRING)
+	return b
+}
+
+// BytesE (s string) ([]byte, error) - the big-endian bytes of s, leading zero digits kept
+func BytesE(s string) ([]byte, error) {
+	return utils.BytesOf(s, utils.HEXADECIMAL_BASE)
+}
+
+// Words (s string, size int, order string) string - WordsE, but panics if it fails
+func Words(s string, size int, order string) string {
+	r, err := WordsE(s, size, order)
+	utils.CheckError(err, utils.HEXADECIMAL, utils.STRING)
+	return r
+}
+
+// WordsE (s string, size int, order string) (string, error) - s as words of size bytes in big- or little-endian order
+func WordsE(s string, size int, order string) (string, error) {
+	b, err := BytesE(s)
+	if err != nil {
+		return "", err
+	}
+	return utils.Words(b, size, order, utils.HEXADECIMAL_BASE), nil
 }
@@ -31,3 +31,23 @@ func Example() {
 	// 100
 	// 64:string
 }
+
+func ExampleHexadecimal2Ascii() {
+	fmt.Println(hexadecimal.Hexadecimal2Ascii("48 65 6c 6c 6f"))
+
+	// Output:
+	// "Hello"
+}
+
+func ExampleWordsE() {
+	fmt.Println(hexadecimal.WordsE("12345678", 1, "little"))
+	fmt.Println(hexadecimal.WordsE("12345678", 2, "little"))
+	fmt.Println(hexadecimal.WordsE("00ff", 1, "little"))
+	fmt.Println(hexadecimal.WordsE("123", 2, "big"))
+
+	// Output:
+	// 78 56 34 12 <nil>
+	// 5678 1234 <nil>
+	// ff 00 <nil>
+	// 0123 <nil>
+}
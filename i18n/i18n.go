@@ -0,0 +1,159 @@
+/*
+
+I18N
+
+*/
+
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	EN = "en"
+	VI = "vi"
+)
+
+// Languages are the supported language codes
+var Languages = []string{EN, VI}
+
+// catalogs map an English message (a fmt format string) to its translation
+var catalogs = map[string]map[string]string{
+	VI: vi,
+}
+
+// Supported (lang string) bool
+func Supported(lang string) bool {
+	for _, l := range Languages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// Detect (locale string) string - the language of a locale such as "vi_VN.UTF-8", EN if it is not supported
+func Detect(locale string) string {
+	lang := strings.ToLower(locale)
+	if i := strings.IndexAny(lang, "_.@-"); i >= 0 {
+		lang = lang[:i]
+	}
+	if Supported(lang) {
+		return lang
+	}
+	return EN
+}
+
+// Localizer translates messages into one language. The zero value leaves them in English
+type Localizer struct {
+	lang string
+}
+
+// New (lang string) Localizer - a Localizer for lang, English if lang is not supported
+func New(lang string) Localizer {
+	if _, ok := catalogs[lang]; ok {
+		return Localizer{lang: lang}
+	}
+	return Localizer{}
+}
+
+// Lang () string - the language of l
+func (l Localizer) Lang() string {
+	if l.lang == "" {
+		return EN
+	}
+	return l.lang
+}
+
+// T (msg string, args ...interface{}) string - msg in the language of l, formatted with args like fmt.Sprintf.
+// Arguments that are messages or errors are translated too, and %w formats an error like %v
+func (l Localizer) T(msg string, args ...interface{}) string {
+	if s, ok := catalogs[l.lang][msg]; ok {
+		msg = s
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	localized := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch a := arg.(type) {
+		case Localizable:
+			localized[i] = a.Localize(l)
+		case error:
+			localized[i] = l.Error(a)
+		default:
+			localized[i] = arg
+		}
+	}
+	return fmt.Sprintf(strings.ReplaceAll(msg, "%w", "%v"), localized...)
+}
+
+// Error (err error) string - the message of err in the language of l
+func (l Localizer) Error(err error) string {
+	switch e := err.(type) {
+	case Localizable:
+		return e.Localize(l)
+	case *strconv.NumError:
+		return "strconv." + e.Func + ": parsing " + strconv.Quote(e.Num) + ": " + l.Error(e.Err)
+	}
+	return err.Error()
+}
+
+// Localizable is a value whose text depends on the language, such as a Message or an error built from one
+type Localizable interface {
+	Localize(l Localizer) string
+}
+
+// Message is a message and its arguments, translated only when it is shown. The zero value is empty
+type Message struct {
+	Msg  string
+	Args []interface{}
+}
+
+// M (msg string, args ...interface{}) Message - msg with args, to be translated when it is shown
+func M(msg string, args ...interface{}) Message {
+	return Message{Msg: msg, Args: args}
+}
+
+// Localize (l Localizer) string - m in the language of l
+func (m Message) Localize(l Localizer) string {
+	if m.Msg == "" {
+		return ""
+	}
+	return l.T(m.Msg, m.Args...)
+}
+
+// String () string - m in English
+func (m Message) String() string {
+	return m.Localize(Localizer{})
+}
+
+// Error is an error whose message is translated when it is shown
+type Error struct {
+	Message
+}
+
+// Errorf (msg string, args ...interface{}) error - an error with the message msg formatted with args; like
+// fmt.Errorf, %w wraps an error argument
+func Errorf(msg string, args ...interface{}) error {
+	return &Error{M(msg, args...)}
+}
+
+// Error () string - the message in English
+func (e *Error) Error() string {
+	return e.String()
+}
+
+// Unwrap () []error - the error arguments of the message
+func (e *Error) Unwrap() []error {
+	var errs []error
+	for _, arg := range e.Args {
+		if err, ok := arg.(error); ok {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
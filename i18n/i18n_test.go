@@ -0,0 +1,151 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package i18n_test
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/clarketm/ncalc/i18n"
+)
+
+func Example() {
+
+	// I18N
+	for _, lang := range i18n.Languages {
+		l := i18n.New(lang)
+		fmt.Println(l.T("Step %d: Divide by %s:", 1, "2"))
+		fmt.Println(l.T("%d ÷ %d = %d remainder %d", 13, 2, 6, 1))
+		fmt.Println(l.T("Base conversion"))
+	}
+
+	// Output:
+	// Step 1: Divide by 2:
+	// 13 ÷ 2 = 6 remainder 1
+	// Base conversion
+	// Bước 1: Chia cho 2:
+	// 13 ÷ 2 = 6 dư 1
+	// Chuyển đổi cơ số
+}
+
+func ExampleDetect() {
+
+	// I18N (locale)
+	for _, locale := range []string{"vi_VN.UTF-8", "en_US.UTF-8", "C", "fr_FR", ""} {
+		fmt.Println(i18n.Detect(locale))
+	}
+
+	// Output:
+	// vi
+	// en
+	// en
+	// en
+	// en
+}
+
+func ExampleErrorf() {
+
+	// I18N (errors are translated when they are shown)
+	err := i18n.Errorf("error evaluating %s: %w", "1 / 0", i18n.Errorf("division by zero"))
+	fmt.Println(err)
+	fmt.Println(i18n.New(i18n.VI).Error(err))
+
+	// Output:
+	// error evaluating 1 / 0: division by zero
+	// lỗi khi tính 1 / 0: chia cho 0
+}
+
+// TestCatalogs kiểm tra mọi thông báo viết trực tiếp trong mã nguồn (đối số đầu của T, M
+// và Errorf) đều có bản dịch trong từng catalog
+func TestCatalogs(t *testing.T) {
+	catalogs := map[string]map[string]bool{
+		i18n.VI: catalogKeys(t, "vi.go", "vi"),
+	}
+
+	fset := token.NewFileSet()
+	err := filepath.WalkDir("..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			msg, ok := message(n)
+			if !ok {
+				return true
+			}
+			for lang, keys := range catalogs {
+				if !keys[msg] {
+					t.Errorf("%s: %q has no %s translation", fset.Position(n.Pos()), msg, lang)
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// message trả về thông báo viết trực tiếp trong một lời gọi i18n.M, i18n.Errorf hoặc phương thức T
+// của một Localizer
+func message(n ast.Node) (string, bool) {
+	call, ok := n.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return "", false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+	pkg, _ := sel.X.(*ast.Ident)
+	switch {
+	case sel.Sel.Name == "T":
+	case (sel.Sel.Name == "M" || sel.Sel.Name == "Errorf") && pkg != nil && pkg.Name == "i18n":
+	default:
+		return "", false
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	msg, err := strconv.Unquote(lit.Value)
+	return msg, err == nil
+}
+
+// catalogKeys trả về các khoá của biến catalog name trong file
+func catalogKeys(t *testing.T, file, name string) map[string]bool {
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := map[string]bool{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || len(spec.Names) != 1 || spec.Names[0].Name != name {
+			return true
+		}
+		for _, elt := range spec.Values[0].(*ast.CompositeLit).Elts {
+			key, _ := strconv.Unquote(elt.(*ast.KeyValueExpr).Key.(*ast.BasicLit).Value)
+			keys[key] = true
+		}
+		return false
+	})
+	return keys
+}
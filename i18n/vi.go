@@ -0,0 +1,495 @@
+package i18n
+
+// vi is the Vietnamese catalog
+var vi = map[string]string{
+	// main
+	"error reading %s: %w":                                                           "lỗi khi đọc %s: %w",
+	"error evaluating %s: %w":                                                        "lỗi khi tính %s: %w",
+	"usage: ncalc bit X OP [operands...]; OP is one of %s":                           "cách dùng: ncalc bit X OP [toán hạng...]; OP là một trong %s",
+	"unknown bitwise operation %s; want one of %s":                                   "không có phép toán bit %s; cần một trong %s",
+	"%s takes %d operand(s) after the value, got %d":                                 "%s cần %d toán hạng sau giá trị, nhận được %d",
+	"bit operands must be integers, not %s":                                          "toán hạng của phép toán bit phải là số nguyên, không phải %s",
+	"text":                                                                           "văn bản",
+	"note: %s is ambiguous: %s; reading it as %s (choose with -i)":                   "chú ý: %s có nhiều cách đọc: %s; đang đọc là %s (chọn bằng -i)",
+	"unsupported base %s":                                                            "không hỗ trợ cơ số %s",
+	"--lang must be %s or %s":                                                        "--lang phải là %s hoặc %s",
+	"--signed needs --width":                                                         "--signed cần có --width",
+	"--precision must be greater than 0":                                             "--precision phải lớn hơn 0",
+	"--round must be %s, %s or %s":                                                   "--round phải là %s, %s hoặc %s",
+	"--prefix must be %s or %s":                                                      "--prefix phải là %s hoặc %s",
+	"--pad and --group must not be negative":                                         "--pad và --group không được âm",
+	"--method must be %s, %s, %s, %s or %s":                                          "--method phải là %s, %s, %s, %s hoặc %s",
+	"--endian must be %s, %s or %s":                                                  "--endian phải là %s, %s hoặc %s",
+	"--word must be 1, 2, 4 or 8":                                                    "--word phải là 1, 2, 4 hoặc 8",
+	"error converting %s to %s: %w":                                                  "lỗi khi chuyển đổi %s sang %s: %w",
+	"warning: %s: %s; byte order not applied":                                        "cảnh báo: %s: %s; không áp dụng thứ tự byte",
+	"a fractional value has no byte representation":                                  "giá trị có phần phân số không có biểu diễn byte",
+	"a negative value has no byte representation without --width":                    "giá trị âm không có biểu diễn byte khi không có --width",
+	"a sequence of values wider than one byte":                                       "dãy có giá trị rộng hơn một byte",
+	"warning: %d bytes is not a whole number of %d-byte words; padded to %d bytes":   "cảnh báo: %d byte không chia hết thành các word %d byte; đã thêm vào cho đủ %d byte",
+	"error converting result to %s: %w":                                              "lỗi khi chuyển kết quả sang %s: %w",
+	"%s (%d-bit signed)":                                                             "%s (có dấu %d bit)",
+	"-x needs an integer input format, not %s":                                       "-x cần định dạng đầu vào là số nguyên, không phải %s",
+	"step-by-step solutions support a single operation a + b, a - b, a * b or a / b": "giải từng bước chỉ hỗ trợ một phép toán a + b, a - b, a * b hoặc a / b",
+	"Error exporting Excel file: %v":                                                 "Lỗi khi xuất file Excel: %v",
+	"Exported the solution to Excel file: %s":                                        "Đã xuất giải pháp ra file Excel: %s",
+	"Error reading file %s: %v":                                                      "Lỗi khi đọc file %s: %v",
+	"No input data in file %s":                                                       "Không có dữ liệu đầu vào từ file %s",
+	"Conversion from %s to %s is not supported":                                      "Không hỗ trợ chuyển đổi từ %s sang %s",
+	"Exported the results to Excel file: %s":                                         "Đã xuất kết quả ra file Excel: %s",
+	"Convert %s (base %s) to %s (base %s):":                                          "Chuyển đổi %s (cơ số %s) sang %s (cơ số %s):",
+	"No results to export":                                                           "Không có kết quả nào để xuất",
+	"Conversion from %s to %s":                                                       "Chuyển đổi từ %s sang %s",
+	"No step-by-step solution for conversion from %s to %s":                          "Không có giải pháp từng bước cho chuyển đổi từ %s sang %s",
+	"No solutions to export to Excel":                                                "Không có giải pháp nào để xuất ra Excel",
+
+	// main: usage
+	"NAME:":                                 "TÊN:",
+	"ncalc – number base converter.":        "ncalc – công cụ đổi cơ số.",
+	"SYNOPSIS:":                             "CÚ PHÁP:",
+	"%v [ opts... ] [ number|text ]":        "%v [ tuỳ chọn... ] [ số|văn bản ]",
+	"%v [ opts... ] bit [ x op y | not x ]": "%v [ tuỳ chọn... ] bit [ x op y | not x ]",
+	"%v [ opts... ] -x expression":          "%v [ tuỳ chọn... ] -x biểu thức",
+	"OPTIONS:":                              "TUỲ CHỌN:",
+	"FORMATS:":                              "ĐỊNH DẠNG:",
+	"BIT OPERATIONS:":                       "PHÉP TOÁN BIT:",
+	"Version:":                              "Phiên bản:",
+	"7-bit ASCII text, one code per character": "văn bản ASCII 7 bit, mỗi ký tự một mã",
+	"base N (%d-%d)":                                                           "cơ số N (%d-%d)",
+	"IEEE 754 half|single|double precision":                                    "IEEE 754 độ chính xác nửa|đơn|kép",
+	"code points, U+XXXX":                                                      "điểm mã, U+XXXX",
+	"utf8|utf16le|utf16be|utf32|utf32le text as hex bytes":                     "văn bản utf8|utf16le|utf16be|utf32|utf32le viết bằng các byte hex",
+	"binary coded decimal: a nibble per digit | two digits per hex byte":       "số thập phân mã hoá nhị phân: mỗi chữ số một nibble | hai chữ số mỗi byte hex",
+	"binary-reflected Gray code":                                               "mã Gray phản xạ nhị phân",
+	"Roman numerals (1-3999)":                                                  "số La Mã (1-3999)",
+	"base 3 with digits T (-1), 0 and 1":                                       "cơ số 3 với các chữ số T (-1), 0 và 1",
+	"bijective base 26, spreadsheet column names":                              "cơ số 26 song ánh, tên cột bảng tính",
+	"signed fixed point, e.g. q1.15":                                           "số dấu chấm tĩnh có dấu, ví dụ q1.15",
+	"base64|base64url|base32|base58 byte strings":                              "chuỗi byte base64|base64url|base32|base58",
+	"suppress printing of output format type(s)":                               "không in tên định dạng đầu ra",
+	"show step-by-step solution":                                               "hiện lời giải từng bước",
+	"export step-by-step solution to excel file":                               "xuất lời giải từng bước ra file excel",
+	"use LaTeX formatting in excel output":                                     "dùng định dạng LaTeX trong file excel",
+	"read input from text file":                                                "đọc đầu vào từ file văn bản",
+	"fixed bit `width` for signed values (8|16|32|64) and bit operations":      "độ rộng bit cố định (`width`) cho giá trị có dấu (8|16|32|64) và phép toán bit",
+	"signed `representation`: twos|ones|signmag (default: twos)":               "cách biểu diễn số có dấu (`representation`): twos|ones|signmag (mặc định: twos)",
+	"maximum fractional `digits` before truncating":                            "số chữ số (`digits`) tối đa của phần lẻ trước khi cắt bớt",
+	"fixed-point rounding `mode`: truncate|nearest|convergent":                 "cách làm tròn dấu chấm tĩnh (`mode`): truncate|nearest|convergent",
+	"clamp out-of-range fixed-point values instead of failing":                 "kẹp giá trị dấu chấm tĩnh nằm ngoài khoảng thay vì báo lỗi",
+	"number `style`: 0x (0b, 0o, 0x) or subscript":                             "kiểu viết số (`style`): 0x (0b, 0o, 0x) hoặc subscript",
+	"pad numbers with leading zeros to at least `N` digits":                    "thêm số 0 ở đầu cho đủ ít nhất `N` chữ số",
+	"group digits by `size` from the right, e.g. 4 (nibbles) or 3 (thousands)": "nhóm các chữ số từ phải sang theo cỡ (`size`), ví dụ 4 (nibble) hoặc 3 (hàng nghìn)",
+	"digit group `separator` (default: , for --group 3, _ otherwise)":          "dấu phân cách nhóm chữ số (`separator`) (mặc định: , với --group 3, _ trong các trường hợp khác)",
+	"write letter digits in upper case":                                        "viết chữ số dạng chữ cái bằng chữ hoa",
+	"byte `order` of hexadecimal and binary output: little|big|both":           "thứ tự byte (`order`) của đầu ra thập lục phân và nhị phân: little|big|both",
+	"word `size` in bytes for --endian: 1|2|4|8 (default: 1)":                  "cỡ word (`size`) tính bằng byte cho --endian: 1|2|4|8 (mặc định: 1)",
+	"input `format`: see FORMATS.":                                             "định dạng đầu vào (`format`): xem ĐỊNH DẠNG.",
+	"output `format`: see FORMATS.":                                            "định dạng đầu ra (`format`): xem ĐỊNH DẠNG.",
+	"step-by-step `method`: grouping|decimal (power-of-two bases), horner (to decimal), borrow|complement (subtraction)": "cách giải từng bước (`method`): grouping|decimal (cơ số là lũy thừa của 2), horner (sang thập phân), borrow|complement (phép trừ)",
+	"`language` of messages and step-by-step solutions: en|vi (default: from LANG)":                                      "ngôn ngữ (`language`) của thông báo và lời giải từng bước: en|vi (mặc định: theo LANG)",
+	"evaluate an arithmetic expression of mixed-base integers":                                                           "tính biểu thức số học gồm các số nguyên ở nhiều cơ số",
+	"print version number": "in số phiên bản",
+
+	// ascii
+	"%q is %d characters, not one": "%q có %d ký tự, không phải một",
+
+	// bcd
+	"needs a multiple of 4 bits": "cần số bit là bội của 4",
+	"needs whole bytes":          "cần các byte đầy đủ",
+
+	// bijective
+	"bijective base %d is not supported (1-%d)": "không hỗ trợ hệ song ánh cơ số %d (1-%d)",
+	"bijective numerals":                        "hệ song ánh",
+
+	// bitwise
+	"unknown bitwise operation %q": "không có phép toán bit %q",
+	"the low bit of mask %d:%d":    "bit thấp của mặt nạ %d:%d",
+	"a shift count":                "số bit dịch",
+	"a bit index":                  "chỉ số bit",
+	"%s needs a bit width":         "%s cần độ rộng bit",
+
+	// bytestring
+	"%q after '='": "%q sau '='",
+	"a %s block cannot end with %d character(s)": "khối %s không thể kết thúc bằng %d ký tự",
+	"missing %d '=' to fill the block":           "thiếu %d dấu '=' để lấp đầy khối",
+	"%d '=' where the block needs %d":            "%d dấu '=' trong khi khối cần %d",
+
+	// expression
+	"unexpected %q":                "không mong đợi %q",
+	"invalid digit %q for base %d": "chữ số %q không hợp lệ ở cơ số %d",
+	"invalid number %s":            "số %s không hợp lệ",
+	"division by zero":             "chia cho 0",
+	"unexpected end of expression": "biểu thức kết thúc đột ngột",
+	"missing )":                    "thiếu )",
+
+	// fixed
+	"%s fixed point": "dấu phẩy tĩnh %s",
+
+	// format
+	"%s encodes a single number, not a sequence of %d": "%s mã hoá một số, không phải dãy %d số",
+	"%s encodes integers, not %s":                      "%s mã hoá số nguyên, không phải %s",
+	"%s encodes finite numbers, not %s":                "%s mã hoá số hữu hạn, không phải %s",
+	"unknown format %s":                                "không có định dạng %s",
+	"%s is not a byte string":                          "%s không phải là chuỗi byte",
+	"%s has no %s representation":                      "%s không có biểu diễn %s",
+	"byte":                                             "byte",
+
+	// ieee754
+	"invalid NaN payload %q: want 0 to %d bits": "payload NaN %q không hợp lệ: cần từ 0 đến %d bit",
+	"half precision":   "độ chính xác nửa",
+	"single precision": "độ chính xác đơn",
+	"double precision": "độ chính xác kép",
+
+	// radix
+	"base %d out of range [%d, %d]": "cơ số %d nằm ngoài khoảng [%d, %d]",
+
+	// roman
+	"empty":                           "rỗng",
+	"%q is not a Roman digit":         "%q không phải là chữ số La Mã",
+	"not a canonical numeral":         "không ở dạng chuẩn",
+	"not canonical, %d is written %s": "không ở dạng chuẩn, %d được viết là %s",
+
+	// signed
+	"unknown signed representation %q (want %s, %s or %s)": "không có cách biểu diễn số có dấu %q (cần %s, %s hoặc %s)",
+	"invalid width %d: signed values need at least 2 bits": "độ rộng %d không hợp lệ: số có dấu cần ít nhất 2 bit",
+	"one's complement": "bù một",
+	"sign-magnitude":   "dấu-độ lớn",
+
+	// stepbystep
+	"Method: Add the columns from the right. A column sum of %d or more writes its last base-%d digit and carries the rest to the next column.": "Phương pháp: Cộng từng cột từ phải sang trái. Tổng một cột từ %d trở lên thì viết chữ số cuối ở cơ số %d và nhớ phần còn lại sang cột tiếp theo.",
+	"Note: %s": "Chú ý: %s",
+	"Step 1: Line up the digits on the right: %s + %s":              "Bước 1: Đặt các chữ số thẳng hàng bên phải: %s + %s",
+	"Step 2: Add each column with the carry from the previous one:": "Bước 2: Cộng từng cột cùng với số nhớ từ cột trước:",
+	"Result: %s":              "Kết quả: %s",
+	"Result: %s remainder %s": "Kết quả: %s dư %s",
+	"Method: Subtract the columns from the right. When the top digit is too small, borrow 1 from the next column, which adds %d to this one.": "Phương pháp: Trừ từng cột từ phải sang trái. Khi chữ số trên quá nhỏ, mượn 1 từ cột kế tiếp, tức là cộng thêm %d vào cột này.",
+	"Step %d: %s is less than %s, so subtract the other way round and make the result negative.":                                              "Bước %d: %s nhỏ hơn %s, nên trừ theo chiều ngược lại và lấy kết quả âm.",
+	"Step %d: Line up the digits on the right: %s - %s":                                                                                       "Bước %d: Đặt các chữ số thẳng hàng bên phải: %s - %s",
+	"Step %d: Subtract each column, taking away the borrow from the previous one:":                                                            "Bước %d: Trừ từng cột, trừ đi số mượn của cột trước:",
+	"Column %d":          "Cột %d",
+	"write %s, borrow 0": "viết %s, mượn 0",
+	"borrow from the next column: write %s, borrow 1": "mượn từ cột kế tiếp: viết %s, mượn 1",
+	"Method: Add the %s of the subtrahend instead of subtracting it. A carry out of the top column is dropped and means the result is positive; no carry means the result is negative.": "Phương pháp: Cộng %s của số trừ thay vì trừ nó. Số nhớ ra khỏi cột cao nhất được bỏ đi và cho biết kết quả dương; không có số nhớ thì kết quả âm.",
+	"Step 1: Pad both numbers to %d digits: %s - %s": "Bước 1: Thêm số 0 để cả hai số có %d chữ số: %s - %s",
+	"Step 2: Find the %s of %s:":                     "Bước 2: Tìm %s của %s:",
+	"Replace each digit d with %d - d: %s":           "Thay mỗi chữ số d bằng %d - d: %s",
+	"Add 1":                                          "Cộng 1",
+	"Drop the carry out of the top digit: %s":        "Bỏ số nhớ ra khỏi hàng cao nhất: %s",
+	"Step 3: Add %s + %s:":                           "Bước 3: Cộng %s + %s:",
+	"Step 4: The sum has more than %d digits: drop the carry, the result is positive: %s":                                                                                                "Bước 4: Tổng có nhiều hơn %d chữ số: bỏ số nhớ, kết quả dương: %s",
+	"Step 4: The sum has no carry beyond %d digits: the result is negative, and its size is the %s of %s:":                                                                               "Bước 4: Tổng không có số nhớ vượt quá %d chữ số: kết quả âm, và độ lớn của nó là %s của %s:",
+	"Step 4: The subtrahend is 0, so there is no carry to drop and the sum is the result: %s":                                                                                            "Bước 4: Số trừ bằng 0, nên không có số nhớ để bỏ và tổng chính là kết quả: %s",
+	"Replace each digit d with %d - d and add 1":                                                                                                                                         "Thay mỗi chữ số d bằng %d - d rồi cộng 1",
+	"Method: Shift and add. Multiply the first number by each digit of the second, from the right, shifting each partial product one place further left; then add the partial products.": "Phương pháp: Dịch và cộng. Nhân số thứ nhất với từng chữ số của số thứ hai, từ phải sang trái, mỗi tích riêng dịch sang trái thêm một vị trí; sau đó cộng các tích riêng.",
+	"Step 1: Partial products of %s:": "Bước 1: Các tích riêng của %s:",
+	"Digit %d":                        "Chữ số %d",
+	"shifted %d place(s): %s":         "dịch %d vị trí: %s",
+	"Step 2: Add the partial products column by column:": "Bước 2: Cộng các tích riêng theo từng cột:",
+	"Method: Long division. Bring down the digits of %s one at a time; each time, the number of times %s fits is the next quotient digit and what is left carries on.": "Phương pháp: Chia dài. Hạ lần lượt từng chữ số của %s; mỗi lần, số lần %s chứa được là chữ số tiếp theo của thương và phần còn lại được giữ cho lần sau.",
+	"Step 1: Divide digit by digit from the left:":                    "Bước 1: Chia từng chữ số từ trái sang:",
+	"  Bring down %s: %s ÷ %s = %s remainder %s":                      "  Hạ %s: %s ÷ %s = %s dư %s",
+	"Bring down %s: \\(%s \\div %s = %s\\), remainder \\(%s\\)":       "Hạ %s: \\(%s \\div %s = %s\\), dư \\(%s\\)",
+	"Step 2: Read the quotient digits from the top: %s, remainder %s": "Bước 2: Đọc các chữ số của thương từ trên xuống: %s, dư %s",
+	"%s remainder %s": "%s dư %s",
+	"Check":           "Thử lại",
+	"%s is negative; step-by-step arithmetic works on non-negative numbers": "%s là số âm; giải từng bước phép toán chỉ áp dụng cho số không âm",
+	"step-by-step arithmetic supports a single operator":                    "giải từng bước phép toán chỉ hỗ trợ một phép toán",
+	"write %s, carry %d":                     "viết %s, nhớ %d",
+	"The final carry %s is written in front": "Số nhớ cuối cùng %s được viết ở đầu",
+	"two's complement":                       "bù hai",
+	"%d's complement":                        "bù %d",
+	"by borrowing":                           "bằng cách mượn",
+	"by adding the %s":                       "bằng cách cộng %s",
+	"by shift and add":                       "bằng cách dịch và cộng",
+	"by long division":                       "bằng phép chia dài",
+	"Compute $%s_{%d} %s %s_{%d}$ in %s%s.":  "Tính $%s_{%d} %s %s_{%d}$ trong hệ %s%s.",
+	"$%s_{%d}$ remainder $%s_{%d}$":          "$%s_{%d}$ dư $%s_{%d}$",
+	"Method: Replace each decimal digit by its 4-bit binary code with weights 8 4 2 1.":   "Phương pháp: Thay mỗi chữ số thập phân bằng mã nhị phân 4 bit với trọng số 8 4 2 1.",
+	"Step %d: Convert %s (base %d) to decimal: %s":                                        "Bước %d: Đổi %s (cơ số %d) sang thập phân: %s",
+	"Step %d: Map each digit of %s to a nibble:":                                          "Bước %d: Đổi mỗi chữ số của %s thành một nibble:",
+	"Step %d: Pad with a leading 0000 nibble to fill whole bytes.":                        "Bước %d: Thêm nibble 0000 ở đầu để đủ các byte.",
+	"Step %d: Pack two nibbles per byte and write each byte in hex:":                      "Bước %d: Ghép hai nibble thành một byte và viết mỗi byte ở hệ thập lục phân:",
+	"Method: Split into 4-bit groups; each group from 0000 to 1001 is one decimal digit.": "Phương pháp: Tách thành các nhóm 4 bit; mỗi nhóm từ 0000 đến 1001 là một chữ số thập phân.",
+	"Step 1: Write each hex byte as two nibbles:":                                         "Bước 1: Viết mỗi byte thập lục phân thành hai nibble:",
+	"Step 1: Split into nibbles: %s":                                                      "Bước 1: Tách thành các nibble: %s",
+	"Step 2: Read each nibble as a decimal digit:":                                        "Bước 2: Đọc mỗi nibble là một chữ số thập phân:",
+	"Step 3: Join the digits: %s":                                                         "Bước 3: Ghép các chữ số: %s",
+	"Step 4: Convert %s to base %d: %s":                                                   "Bước 4: Đổi %s sang cơ số %d: %s",
+	"Method: Read the bytes as one big-endian number, write it in base %d by repeated division, and add a '%c' for each leading zero byte.": "Phương pháp: Đọc các byte như một số big-endian, viết nó ở cơ số %d bằng phép chia liên tiếp, và thêm một '%c' cho mỗi byte 0 ở đầu.",
+	"Step 1: Bytes: %s":                "Bước 1: Các byte: %s",
+	"Step 2: As a number: %s":          "Bước 2: Dưới dạng một số: %s",
+	"Step 3: Divide by %d repeatedly:": "Bước 3: Chia liên tiếp cho %d:",
+	"remainder %s -> %c":               "dư %s -> %c",
+	"Step 4: Read the characters from bottom to top and add the leading '%c's: %s":                                   "Bước 4: Đọc các ký tự từ dưới lên và thêm các '%c' ở đầu: %s",
+	"Method: Write the bytes in binary, cut the bits into %d-bit groups, and look each group up in the %s alphabet.": "Phương pháp: Viết các byte ở hệ nhị phân, cắt các bit thành nhóm %d bit, và tra mỗi nhóm trong bảng chữ cái %s.",
+	"Step 2: In binary: %s":                                    "Bước 2: Ở hệ nhị phân: %s",
+	" (the last group is filled with 0s)":                      " (nhóm cuối được thêm các số 0 cho đủ)",
+	"Step 3: Regroup into %d-bit groups%s: %s":                 "Bước 3: Nhóm lại thành các nhóm %d bit%s: %s",
+	"Step 4: Map each group to a character:":                   "Bước 4: Đổi mỗi nhóm thành một ký tự:",
+	"Step 5: Pad with '%c' to a multiple of %d characters: %s": "Bước 5: Thêm '%c' cho đủ bội của %d ký tự: %s",
+	"Method: Read the characters as a base %d number, write it as big-endian bytes, and add a zero byte for each leading '%c'.": "Phương pháp: Đọc các ký tự như một số ở cơ số %d, viết nó thành các byte big-endian, và thêm một byte 0 cho mỗi '%c' ở đầu.",
+	"Step 1: Multiply by the base and add each digit:":                                                                          "Bước 1: Nhân với cơ số rồi cộng từng chữ số:",
+	"Step 2: Bytes: %s": "Bước 2: Các byte: %s",
+	"Method: Look up the %d-bit value of each character, join the bits, and cut them into bytes, dropping the fill bits at the end.": "Phương pháp: Tra giá trị %d bit của mỗi ký tự, ghép các bit, và cắt chúng thành các byte, bỏ các bit đệm ở cuối.",
+	"Step 1: Map each character to its value:":                  "Bước 1: Đổi mỗi ký tự thành giá trị của nó:",
+	"Step 2: Cut into bytes: %s":                                "Bước 2: Cắt thành các byte: %s",
+	"The last %d bit(s) %s only fill the group and are dropped": "%d bit cuối %s chỉ để lấp đầy nhóm nên được bỏ đi",
+	"Step 3: Bytes: %s":                                         "Bước 3: Các byte: %s",
+	"Method: %s has %d integer bit(s), the sign bit included, and %d fraction bit(s). Multiply by 2^%d to move the binary point, round to an integer, keep it within the %d-bit signed range and write it as a %d-bit two's complement word.": "Phương pháp: %s có %d bit phần nguyên, tính cả bit dấu, và %d bit phần phân số. Nhân với 2^%d để dời dấu chấm nhị phân, làm tròn thành số nguyên, giữ trong phạm vi số có dấu %d bit và viết thành một word bù hai %d bit.",
+	"Step 1: Scale by 2^%d = %s: %s × %s = %s":              "Bước 1: Nhân với 2^%d = %s: %s × %s = %s",
+	"Step 2: %s is already an integer, no rounding needed":  "Bước 2: %s đã là số nguyên, không cần làm tròn",
+	"Step 2: Round to an integer (%s): %s -> %s":            "Bước 2: Làm tròn thành số nguyên (%s): %s -> %s",
+	"Step 3: %s is outside %s to %s, so it saturates to %s": "Bước 3: %s nằm ngoài khoảng %s đến %s, nên bão hoà thành %s",
+	"Step 3: %s is within %s to %s":                         "Bước 3: %s nằm trong khoảng %s đến %s",
+	"Step 4: Negative, so add 2^%d: %s + %s = %s = %s":      "Bước 4: Số âm, nên cộng 2^%d: %s + %s = %s = %s",
+	"Step 4: In %d bits: %s = %s":                           "Bước 4: Với %d bit: %s = %s",
+	"Step 5: Group the bits by 4 for hexadecimal: %s = %s":  "Bước 5: Nhóm các bit theo 4 để viết ở hệ thập lục phân: %s = %s",
+	"Step 6: Stored value: %s / %s = %s (error %s)":         "Bước 6: Giá trị được lưu: %s / %s = %s (sai số %s)",
+	"Method: Read the word as a %d-bit two's complement integer, then divide by 2^%d to put the binary point back after %d integer bit(s).": "Phương pháp: Đọc word như một số nguyên bù hai %d bit, rồi chia cho 2^%d để đặt lại dấu chấm nhị phân sau %d bit phần nguyên.",
+	"Step 1: Word in binary: %s":                                          "Bước 1: Word ở hệ nhị phân: %s",
+	"Step 2: The sign bit is 1, so subtract 2^%d: %s - %s = %s":           "Bước 2: Bit dấu là 1, nên trừ 2^%d: %s - %s = %s",
+	"Step 2: The sign bit is 0, so the integer is %s":                     "Bước 2: Bit dấu là 0, nên số nguyên là %s",
+	"Step 3: Divide by 2^%d = %s: %s / %s = %s":                           "Bước 3: Chia cho 2^%d = %s: %s / %s = %s",
+	"Converting base-%d number %s to decimal:":                            "Chuyển số %[2]s ở cơ số %[1]d sang thập phân:",
+	"Note: The number is negative, so convert %s and add the minus sign.": "Chú ý: Số này âm, nên đổi %s rồi thêm dấu trừ.",
+	"The number is negative, so convert %s and add the minus sign.":       "Số này âm, nên đổi %s rồi thêm dấu trừ.",
+	"For %s:":                             "Với %s:",
+	"Sum: %s":                             "Tổng: %s",
+	"Step 1: Convert base %d to decimal:": "Bước 1: Đổi cơ số %d sang thập phân:",
+	"Step 2: Convert decimal to base %d:": "Bước 2: Đổi thập phân sang cơ số %d:",
+	"Method: Convert the integer part by repeated division by %d and the fractional part by repeated multiplication by %d.": "Phương pháp: Đổi phần nguyên bằng cách chia liên tiếp cho %d và phần phân số bằng cách nhân liên tiếp với %d.",
+	"Step 1: Convert the integer part %d:": "Bước 1: Đổi phần nguyên %d:",
+	"The integer part is 0.":               "Phần nguyên là 0.",
+	"Step 2: Convert the fractional part %s by repeated multiplication by %d:": "Bước 2: Đổi phần phân số %s bằng cách nhân liên tiếp với %d:",
+	"Digit %s: %s x %d = %s": "Chữ số %s: %s x %d = %s",
+	"The fractional part %s appeared before (digit %d), so the digits %s repeat forever.":                                                          "Phần phân số %s đã xuất hiện trước đó (chữ số %d), nên các chữ số %s lặp lại mãi.",
+	"Stopped after %d digits (precision limit).":                                                                                                   "Dừng sau %d chữ số (giới hạn độ chính xác).",
+	"The fractional part is 0, so the expansion terminates.":                                                                                       "Phần phân số là 0, nên phép khai triển kết thúc.",
+	"Method: The first Gray bit is the first binary bit; every next Gray bit is the XOR of two neighbouring binary bits, i.e. g = b XOR (b >> 1).": "Phương pháp: Bit Gray đầu tiên là bit nhị phân đầu tiên; mỗi bit Gray tiếp theo là XOR của hai bit nhị phân kề nhau, tức là g = b XOR (b >> 1).",
+	"Step %d: Convert %s (base %d) to binary: %s":                                                                                                  "Bước %d: Đổi %s (cơ số %d) sang nhị phân: %s",
+	"Step %d: XOR neighbouring bits of %s:":                                                                                                        "Bước %d: XOR các bit kề nhau của %s:",
+	"Step %d: Check with a shift: %s XOR %s = %s":                                                                                                  "Bước %d: Thử lại bằng phép dịch: %s XOR %s = %s",
+	"Method: The first binary bit is the first Gray bit; every next binary bit is the previous binary bit XOR the next Gray bit.":                  "Phương pháp: Bit nhị phân đầu tiên là bit Gray đầu tiên; mỗi bit nhị phân tiếp theo là bit nhị phân trước đó XOR bit Gray tiếp theo.",
+	"Step 1: Undo the XOR from left to right over %s:":                                                                                             "Bước 1: Đảo phép XOR từ trái sang phải trên %s:",
+	"Step 2: Binary: %s":                "Bước 2: Nhị phân: %s",
+	"Step 3: Convert %s to base %d: %s": "Bước 3: Đổi %s sang cơ số %d: %s",
+	"Error":                             "Lỗi",
+	"Method":                            "Phương pháp",
+	"Each %s digit stands for exactly %d bits, so group the bits in %s from the right and map each group to a digit.": "Mỗi chữ số %s ứng với đúng %d bit, nên chia các bit thành %s từ bên phải và đổi mỗi nhóm thành một chữ số.",
+	"Note": "Chú ý",
+	"Each %s digit stands for exactly %d bits, so write every digit as a %d-bit group and join the groups.":                             "Mỗi chữ số %s ứng với đúng %d bit, nên viết mỗi chữ số thành một nhóm %d bit rồi ghép các nhóm lại.",
+	"Go through binary. Write every %s digit as %d bits, then group the bits in %s from the right and map each group to a digit in %s.": "Đi qua hệ nhị phân. Viết mỗi chữ số %s thành %d bit, rồi chia các bit thành %s từ bên phải và đổi mỗi nhóm thành một chữ số trong hệ %s.",
+	"Step %d":                            "Bước %d",
+	"Write each digit of %s as %d bits:": "Viết mỗi chữ số của %s thành %d bit:",
+	"Join the groups and drop the leading zeros: %s = %s":    "Ghép các nhóm và bỏ các số 0 ở đầu: %s = %s",
+	"Pad %s with leading zeros to a multiple of %d bits: %s": "Thêm số 0 vào đầu %s cho đủ bội của %d bit: %s",
+	"%s already has a multiple of %d bits":                   "%s đã có số bit là bội của %d",
+	"Split into %d-bit groups: %s":                           "Chia thành các nhóm %d bit: %s",
+	"Map each group to a digit in %s:":                       "Đổi mỗi nhóm thành một chữ số trong hệ %s:",
+	"ones":                                                   "từng bit",
+	"twos":                                                   "nhóm 2 bit",
+	"threes":                                                 "nhóm 3 bit",
+	"fours":                                                  "nhóm 4 bit",
+	"fives":                                                  "nhóm 5 bit",
+	"groups of %d":                                           "nhóm %d bit",
+	"Horner's scheme. Start from 0 and, for each digit from the left, multiply the running value by %d and add the digit.": "Sơ đồ Horner. Bắt đầu từ 0 và, với mỗi chữ số từ trái sang, nhân giá trị hiện tại với %d rồi cộng chữ số đó.",
+	"Write %s in nested form:":        "Viết %s ở dạng lồng nhau:",
+	"Multiply and add from the left:": "Nhân rồi cộng từ trái sang:",
+	"Encode %s as IEEE 754 %s (%d bits: 1 sign, %d exponent, %d mantissa bits).":                                                       "Mã hoá %s theo IEEE 754 %s (%d bit: 1 bit dấu, %d bit mũ, %d bit phần định trị).",
+	"Step 1: Sign: %s is negative, so the sign bit is 1.":                                                                              "Bước 1: Dấu: %s là số âm, nên bit dấu là 1.",
+	"Step 1: Sign: %s is not negative, so the sign bit is 0.":                                                                          "Bước 1: Dấu: %s không âm, nên bit dấu là 0.",
+	"Step 2: %s is a special value: the exponent field is all ones (%s) and the mantissa is %s.":                                       "Bước 2: %s là giá trị đặc biệt: trường mũ toàn bit 1 (%s) và phần định trị là %s.",
+	"Step 2: Zero is stored with an all-zero exponent and mantissa.":                                                                   "Bước 2: Số 0 được lưu với trường mũ và phần định trị toàn bit 0.",
+	"Step 2: Write %s in binary: %s":                                                                                                   "Bước 2: Viết %s ở hệ nhị phân: %s",
+	"Step 3: Normalize: the exponent would be below the minimum %d, so the value is subnormal: %s x 2^%d":                              "Bước 3: Chuẩn hoá: số mũ sẽ nhỏ hơn giá trị tối thiểu %d, nên giá trị là số không chuẩn (subnormal): %s x 2^%d",
+	"Step 3: Normalize: %s x 2^%d":                                                                                                     "Bước 3: Chuẩn hoá: %s x 2^%d",
+	"Step 4: Round the mantissa to %d bits: %s, nothing is discarded so the value is exact.":                                           "Bước 4: Làm tròn phần định trị thành %d bit: %s, không bỏ đi bit nào nên giá trị là chính xác.",
+	"Step 4: Round the mantissa to %d bits: keep %s; the discarded part is less than half of the last place, so round down.":           "Bước 4: Làm tròn phần định trị thành %d bit: giữ %s; phần bỏ đi nhỏ hơn một nửa đơn vị cuối, nên làm tròn xuống.",
+	"Step 4: Round the mantissa to %d bits: keep %s; the discarded part is more than half of the last place, so round up.":             "Bước 4: Làm tròn phần định trị thành %d bit: giữ %s; phần bỏ đi lớn hơn một nửa đơn vị cuối, nên làm tròn lên.",
+	"Step 4: Round the mantissa to %d bits: keep %s; the discarded part is exactly half and the last bit is 1, so round up to even.":   "Bước 4: Làm tròn phần định trị thành %d bit: giữ %s; phần bỏ đi đúng bằng một nửa và bit cuối là 1, nên làm tròn lên số chẵn.",
+	"Step 4: Round the mantissa to %d bits: keep %s; the discarded part is exactly half and the last bit is 0, so round down to even.": "Bước 4: Làm tròn phần định trị thành %d bit: giữ %s; phần bỏ đi đúng bằng một nửa và bit cuối là 0, nên làm tròn xuống số chẵn.",
+	"The exponent exceeds the maximum %d, so the value overflows to %s.":                                                               "Số mũ vượt quá giá trị tối đa %d, nên giá trị bị tràn thành %s.",
+	"Rounding carried into the next power of two: mantissa %0*b, exponent %d.":                                                         "Làm tròn có nhớ sang luỹ thừa 2 tiếp theo: phần định trị %0*b, số mũ %d.",
+	"Mantissa after rounding: %0*b":                                                                                                    "Phần định trị sau khi làm tròn: %0*b",
+	"Step 5: Infinity stores the exponent field %s.":                                                                                   "Bước 5: Vô cực lưu trường mũ %s.",
+	"Step 5: Subnormal values store the exponent field %0*b and use 2^%d.":                                                             "Bước 5: Giá trị không chuẩn lưu trường mũ %0*b và dùng 2^%d.",
+	"Step 5: Bias the exponent: %d + %d = %d = %0*b":                                                                                   "Bước 5: Cộng độ lệch vào số mũ: %d + %d = %d = %0*b",
+	"Decode the IEEE 754 %s pattern %s (%d bits: 1 sign, %d exponent, %d mantissa bits).":                                              "Giải mã mẫu bit IEEE 754 %s %s (%d bit: 1 bit dấu, %d bit mũ, %d bit phần định trị).",
+	"Step 1: Write the %d bits: %s":                                                                                                    "Bước 1: Viết %d bit: %s",
+	"Step 2: Split the fields: sign = %d, exponent = %0*b, mantissa = %0*b":                                                            "Bước 2: Tách các trường: dấu = %d, mũ = %0*b, phần định trị = %0*b",
+	"Step 3: The sign bit is 1, so the value is negative.":                                                                             "Bước 3: Bit dấu là 1, nên giá trị âm.",
+	"Step 3: The sign bit is 0, so the value is positive.":                                                                             "Bước 3: Bit dấu là 0, nên giá trị dương.",
+	"Step 4: The exponent field is all ones and the mantissa is %s, so the value is %s.":                                               "Bước 4: Trường mũ toàn bit 1 và phần định trị là %s, nên giá trị là %s.",
+	"Step 4: The exponent and mantissa fields are all zeros, so the value is zero.":                                                    "Bước 4: Trường mũ và phần định trị toàn bit 0, nên giá trị là 0.",
+	"Step 4: The exponent field is 0, so the value is subnormal: exponent = 1 - %d = %d and there is no hidden 1.":                     "Bước 4: Trường mũ là 0, nên giá trị không chuẩn: số mũ = 1 - %d = %d và không có bit 1 ẩn.",
+	"Step 4: Exponent field %0*b = %d, remove the bias: %d - %d = %d":                                                                  "Bước 4: Trường mũ %0*b = %d, trừ độ lệch: %d - %d = %d",
+	"Step 5: Significand = %s.%0*b (binary) = %s":                                                                                      "Bước 5: Phần có nghĩa = %s.%0*b (nhị phân) = %s",
+	"Step 6: Value = %s%s x 2^%d = %s":                                                                                                 "Bước 6: Giá trị = %s%s x 2^%d = %s",
+	"Step 7: The shortest decimal that reads back to the same bits is %s.":                                                             "Bước 7: Số thập phân ngắn nhất đọc lại được đúng các bit này là %s.",
+	"Step %d: Assemble sign | exponent | mantissa: %d | %0*b | %0*b":                                                                   "Bước %d: Ghép dấu | mũ | phần định trị: %d | %0*b | %0*b",
+	"Step %d: In hexadecimal: 0x%s":                                                                                                    "Bước %d: Ở hệ thập lục phân: 0x%s",
+	"zero":                                                                                                                             "0",
+	"%0*b (non-zero)":                                                                                                                  "%0*b (khác 0)",
+	"Final Answer":                                                                                                                     "Đáp án",
+	"Range":                                                                                                                            "Phạm vi",
+	"Write":                                                                                                                            "Viết",
+	"Method: Divide by %s repeatedly and keep each remainder %s, correcting the quotient when the plain remainder is out of range. The remainders read from bottom to top are the digits.": "Phương pháp: Chia liên tiếp cho %s và giữ mỗi số dư %s, sửa thương khi số dư thông thường nằm ngoài phạm vi. Các số dư đọc từ dưới lên là các chữ số.",
+	"Step %d: Divide by %s:":                          "Bước %d: Chia cho %s:",
+	"The number is 0, which is written 0":             "Số này là 0, được viết là 0",
+	"remainder %s %s, so %s = %s × %s + %s -> %c":     "dư %s: số dư %s, nên %s = %s × %s + %s -> %c",
+	"remainder %d -> %c":                              "dư %d -> %c",
+	"Step %d: Read the digits from bottom to top: %s": "Bước %d: Đọc các chữ số từ dưới lên: %s",
+	"Method: Multiply each digit by its place value, a power of %s, and add the products.": "Phương pháp: Nhân mỗi chữ số với giá trị vị trí của nó, một luỹ thừa của %s, rồi cộng các tích.",
+	"Step 1: Place values: %s":                 "Bước 1: Giá trị các vị trí: %s",
+	"Step 2: Add the products: %s = %s":        "Bước 2: Cộng các tích: %s = %s",
+	"Converting base-%d number %s to base %d:": "Chuyển số %[2]s ở cơ số %[1]d sang cơ số %[3]d:",
+	"Divide continuously by %d, note the remainders, read the result from bottom to top.": "Chia liên tục cho %d, ghi lại các số dư, đọc kết quả từ dưới lên.",
+	"Formula: %s\\\\": "Công thức: %s\\\\",
+	"Use the formula \\(%s\\), where \\(d_i\\) is the \\(i\\)-th digit from the left.":                                                       "Dùng công thức \\(%s\\), trong đó \\(d_i\\) là chữ số thứ \\(i\\) tính từ trái.",
+	"\\text{Decimal} = d_1 \\times %d^{n-1} + d_2 \\times %d^{n-2} + \\dots + d_n \\times %d^{0}":                                            "\\text{Thập phân} = d_1 \\times %d^{n-1} + d_2 \\times %d^{n-2} + \\dots + d_n \\times %d^{0}",
+	"Use the formula \\(%s\\), where \\(d_i\\) is the digit in position \\(i\\), counting from 0 left of the point and from -1 right of it.": "Dùng công thức \\(%s\\), trong đó \\(d_i\\) là chữ số ở vị trí \\(i\\), đếm từ 0 bên trái dấu chấm và từ -1 bên phải dấu chấm.",
+	"\\text{Decimal} = d_{n-1} \\times %d^{n-1} + \\dots + d_0 \\times %d^{0} + d_{-1} \\times %d^{-1} + \\dots + d_{-m} \\times %d^{-m}":    "\\text{Thập phân} = d_{n-1} \\times %d^{n-1} + \\dots + d_0 \\times %d^{0} + d_{-1} \\times %d^{-1} + \\dots + d_{-m} \\times %d^{-m}",
+	"Sum: %d":                   "Tổng: %d",
+	"Sum: \\(%s = %d\\)":        "Tổng: \\(%s = %d\\)",
+	"%d ÷ %d = %d remainder %d": "%d ÷ %d = %d dư %d",
+	"\\(%d \\div %d = %d\\), remainder \\(%d = %s\\)": "\\(%d \\div %d = %d\\), dư \\(%d = %s\\)",
+	"\\(%d \\div %d = %d\\), remainder \\(%d\\)":      "\\(%d \\div %d = %d\\), dư \\(%d\\)",
+	"  Digit %d (%s): %s x %d + %d = %s":              "  Chữ số %d (%s): %s x %d + %d = %s",
+	"Result: \\(%s\\)":                                "Kết quả: \\(%s\\)",
+	"Result: \\(%s_{%d}\\)":                           "Kết quả: \\(%s_{%d}\\)",
+	"Result: \\(%s_{%d}\\) remainder \\(%s_{%d}\\)":   "Kết quả: \\(%s_{%d}\\) dư \\(%s_{%d}\\)",
+	"Method: Repeatedly take away the largest value in the table that still fits and write its symbol. The subtractive pairs CM (900), CD (400), XC (90), XL (40), IX (9) and IV (4) write a smaller symbol before a larger one to take it away.": "Phương pháp: Lần lượt lấy đi giá trị lớn nhất trong bảng mà vẫn còn trừ được và viết ký hiệu của nó. Các cặp trừ CM (900), CD (400), XC (90), XL (40), IX (9) và IV (4) viết ký hiệu nhỏ trước ký hiệu lớn để trừ đi.",
+	"Step %d: Take away the largest value that fits:": "Bước %d: Lấy đi giá trị lớn nhất còn trừ được:",
+	"subtractive pair: %s":                            "cặp trừ: %s",
+	"Step %d: Join the symbols: %s = %s":              "Bước %d: Ghép các ký hiệu: %s = %s",
+	"Method: Read from left to right. A symbol followed by a larger one forms a subtractive pair and is taken away from it; every other symbol is added.": "Phương pháp: Đọc từ trái sang phải. Một ký hiệu đứng trước ký hiệu lớn hơn tạo thành cặp trừ và được trừ đi; mọi ký hiệu khác được cộng vào.",
+	"Step 1: Split %s into symbols and subtractive pairs: %s":            "Bước 1: Tách %s thành các ký hiệu và cặp trừ: %s",
+	"Step 2: Add the values: %s = %d":                                    "Bước 2: Cộng các giá trị: %s = %d",
+	"Step 3: Convert %d to base %d: %s":                                  "Bước 3: Đổi %d sang cơ số %d: %s",
+	"Method: Convert each of the %d space-separated numbers separately.": "Phương pháp: Đổi riêng từng số trong %d số cách nhau bởi dấu cách.",
+	"Number %d: %s":                                      "Số %d: %s",
+	"Write %s (base %d) in binary: %s":                   "Viết %s (cơ số %d) ở hệ nhị phân: %s",
+	"Write the %d-bit pattern in base %d: %s":            "Viết mẫu %d bit ở cơ số %d: %s",
+	"Method: Encode %s in %d-bit %s.":                    "Phương pháp: Mã hoá %s theo %[3]s %[2]d bit.",
+	"Range: %d to %d":                                    "Phạm vi: %d đến %d",
+	"Step 1: Convert the magnitude |%s| = %d to binary:": "Bước 1: Đổi độ lớn |%s| = %d sang nhị phân:",
+	"Step 2: Pad to %d bits: %s":                         "Bước 2: Thêm số 0 cho đủ %d bit: %s",
+	"Step 3: The number is non-negative, so the padded pattern is the result.": "Bước 3: Số không âm, nên mẫu đã thêm số 0 là kết quả.",
+	"Step 3: Invert all bits: %s":                                             "Bước 3: Đảo tất cả các bit: %s",
+	"Step 3: Set the sign bit (bit %d) to 1: %s":                              "Bước 3: Đặt bit dấu (bit %d) thành 1: %s",
+	"Step 4: Add 1: %s + 1 = %s":                                              "Bước 4: Cộng 1: %s + 1 = %s",
+	"Method: Decode %s from %d-bit %s.":                                       "Phương pháp: Giải mã %s từ %[3]s %[2]d bit.",
+	"Step 1: Pad to %d bits: %s":                                              "Bước 1: Thêm số 0 cho đủ %d bit: %s",
+	"Step 2: The sign bit (leftmost) is 0, so the number is non-negative.":    "Bước 2: Bit dấu (bên trái nhất) là 0, nên số không âm.",
+	"Step 3: Convert the pattern to decimal:":                                 "Bước 3: Đổi mẫu bit sang thập phân:",
+	"Step 2: The sign bit (leftmost) is 1, so the number is negative.":        "Bước 2: Bit dấu (bên trái nhất) là 1, nên số âm.",
+	"Step 3: Invert all bits to get the magnitude: %s":                        "Bước 3: Đảo tất cả các bit để được độ lớn: %s",
+	"Step 3: Clear the sign bit to get the magnitude: %s":                     "Bước 3: Xoá bit dấu để được độ lớn: %s",
+	"Step 4: Add 1 to get the magnitude: %s + 1 = %s":                         "Bước 4: Cộng 1 để được độ lớn: %s + 1 = %s",
+	"Step %d: Convert the magnitude to decimal and apply the minus sign:":     "Bước %d: Đổi độ lớn sang thập phân và thêm dấu trừ:",
+	"Converting binary number %s to decimal:":                                 "Chuyển số nhị phân %s sang thập phân:",
+	"Converting octal number %s to decimal:":                                  "Chuyển số bát phân %s sang thập phân:",
+	"Converting hexadecimal number %s to decimal:":                            "Chuyển số thập lục phân %s sang thập phân:",
+	"Converting octal number %s to binary:":                                   "Chuyển số bát phân %s sang nhị phân:",
+	"Converting octal number %s to hexadecimal:":                              "Chuyển số bát phân %s sang thập lục phân:",
+	"Converting hexadecimal number %s to binary:":                             "Chuyển số thập lục phân %s sang nhị phân:",
+	"Converting hexadecimal number %s to octal:":                              "Chuyển số thập lục phân %s sang bát phân:",
+	"Convert %s to decimal:":                                                  "Đổi %s sang thập phân:",
+	"Convert decimal to %s:":                                                  "Đổi thập phân sang %s:",
+	"Base conversion":                                                         "Chuyển đổi cơ số",
+	"Input":                                                                   "Đầu vào",
+	"Solution":                                                                "Lời giải",
+	"Output":                                                                  "Đầu ra",
+	"Convert the binary number $%s_{2}$ to decimal, octal, and hexadecimal.":  "Chuyển số nhị phân $%s_{2}$ sang thập phân, bát phân và thập lục phân.",
+	"Convert the binary number $%s_{2}$ to %s.":                               "Chuyển số nhị phân $%s_{2}$ sang %s.",
+	"Convert the decimal number $%s_{10}$ to binary, octal, and hexadecimal.": "Chuyển số thập phân $%s_{10}$ sang nhị phân, bát phân và thập lục phân.",
+	"Convert the decimal number $%s_{10}$ to %s.":                             "Chuyển số thập phân $%s_{10}$ sang %s.",
+	"Convert the octal number $%s_{8}$ to binary, decimal, and hexadecimal.":  "Chuyển số bát phân $%s_{8}$ sang nhị phân, thập phân và thập lục phân.",
+	"Convert the octal number $%s_{8}$ to %s.":                                "Chuyển số bát phân $%s_{8}$ sang %s.",
+	"Convert the hexadecimal number $%s_{16}$ to binary, octal, and decimal.": "Chuyển số thập lục phân $%s_{16}$ sang nhị phân, bát phân và thập phân.",
+	"Convert the hexadecimal number $%s_{16}$ to %s.":                         "Chuyển số thập lục phân $%s_{16}$ sang %s.",
+	"Decode the IEEE 754 %s bit pattern $%s$ to %s.":                          "Giải mã mẫu bit IEEE 754 %s $%s$ sang %s.",
+	"Decode the %s fixed-point word \\texttt{%s} to %s.":                      "Giải mã word dấu phẩy tĩnh %s \\texttt{%s} sang %s.",
+	"Decode the %s value \\texttt{%s} to %s.":                                 "Giải mã giá trị %s \\texttt{%s} sang %s.",
+	"Decode the %s bytes \\texttt{%s} to %s.":                                 "Giải mã các byte %s \\texttt{%s} sang %s.",
+	"Encode \\texttt{%s} (%s) in %s.":                                         "Mã hoá \\texttt{%s} (%s) theo %s.",
+	"Convert the base-%d number $%s_{%d}$ to %s.":                             "Chuyển số $%[2]s_{%[3]d}$ ở cơ số %[1]d sang %[4]s.",
+	"Convert %s (base %s) to all other number bases.":                         "Chuyển %s (cơ số %s) sang tất cả các cơ số khác.",
+	"Convert %s (base %s) to %s.":                                             "Chuyển %s (cơ số %s) sang %s.",
+	"binary":                                                                  "nhị phân",
+	"octal":                                                                   "bát phân",
+	"decimal":                                                                 "thập phân",
+	"hexadecimal":                                                             "thập lục phân",
+	"Unicode code points":                                                     "mã Unicode",
+	"BCD":                                                                     "BCD",
+	"packed BCD":                                                              "BCD nén",
+	"Gray code":                                                               "mã Gray",
+	"Roman numerals":                                                          "số La Mã",
+	"base -2":                                                                 "cơ số -2",
+	"balanced ternary":                                                        "tam phân cân bằng",
+	"bijective base 26":                                                       "hệ song ánh cơ số 26",
+	"binary, octal, decimal, and hexadecimal":                                 "nhị phân, bát phân, thập phân và thập lục phân",
+	"base %d":      "cơ số %d",
+	"IEEE 754 %s":  "IEEE 754 %s",
+	"%s bytes":     "các byte %s",
+	"%s (base %s)": "%s (cơ số %s)",
+	"Method: Choose the number of bytes from the code point, then fill the payload bits (x) of the byte templates from left to right.": "Phương pháp: Chọn số byte theo mã Unicode, rồi điền các bit dữ liệu (x) vào mẫu byte từ trái sang phải.",
+	"U+%04X to U+%04X: %s (%d payload bits)":               "U+%04X đến U+%04X: %s (%d bit dữ liệu)",
+	"Step %d: %s = U+%04X = %s in binary":                  "Bước %d: %s = U+%04X = %s ở hệ nhị phân",
+	"U+%04X is at most U+%04X, so it takes %d byte(s): %s": "U+%04X không vượt quá U+%04X, nên cần %d byte: %s",
+	"Pad to %d payload bits: %s":                           "Thêm số 0 cho đủ %d bit dữ liệu: %s",
+	"Split the payload: %s":                                "Tách các bit dữ liệu: %s",
+	"Method: The high bits of the lead byte give the sequence length (0: 1 byte, 110: 2, 1110: 3, 11110: 4), every continuation byte starts with 10; join the remaining payload bits.": "Phương pháp: Các bit cao của byte đầu cho biết độ dài dãy (0: 1 byte, 110: 2, 1110: 3, 11110: 4), mỗi byte tiếp nối bắt đầu bằng 10; ghép các bit dữ liệu còn lại.",
+	"Step %d: Byte %02X (offset %d) = %08b":                            "Bước %d: Byte %02X (vị trí %d) = %08b",
+	"Lead bits %s: a %d-byte sequence, payload %s":                     "Bit đầu %s: dãy %d byte, dữ liệu %s",
+	"Byte %02X (offset %d) = %08b: continuation bits 10, payload %06b": "Byte %02X (vị trí %d) = %08b: bit tiếp nối 10, dữ liệu %06b",
+	"Payload": "Dữ liệu",
+	"Byte %d": "Byte %d",
+	"0 or 1":  "0 hoặc 1",
+	"is negative: add 2 to it and 1 to the quotient": "âm: cộng 2 vào số dư và 1 vào thương",
+	"-1 (T), 0 or 1": "-1 (T), 0 hoặc 1",
+	"is 2: write -1 (T) instead and add 1 to the quotient": "bằng 2: viết -1 (T) thay vào đó và cộng 1 vào thương",
+	"1 (A) to 26 (Z)": "từ 1 (A) đến 26 (Z)",
+	"is 0, which has no digit: write 26 (Z) instead and take 1 from the quotient": "bằng 0, không có chữ số nào: viết 26 (Z) thay vào đó và bớt 1 ở thương",
+
+	// utf
+	"no bytes": "không có byte nào",
+	"%d byte(s) left over, a code unit is %d bytes":                 "thừa %d byte, mỗi đơn vị mã có %d byte",
+	"0x%08X is not a Unicode character":                             "0x%08X không phải là ký tự Unicode",
+	"low surrogate 0x%04X without a high surrogate":                 "surrogate thấp 0x%04X không có surrogate cao đi trước",
+	"high surrogate 0x%04X at the end of the input":                 "surrogate cao 0x%04X ở cuối đầu vào",
+	"high surrogate 0x%04X followed by 0x%04X, not a low surrogate": "surrogate cao 0x%04X theo sau bởi 0x%04X, không phải surrogate thấp",
+	"continuation byte 0x%02X without a lead byte":                  "byte tiếp nối 0x%02X không có byte đầu",
+	"0x%02X is never valid in UTF-8":                                "0x%02X không bao giờ hợp lệ trong UTF-8",
+	"%d-byte sequence cut off after %d byte(s)":                     "dãy %d byte bị cắt sau %d byte",
+	"expected a continuation byte 10xxxxxx, found 0x%02X":           "cần một byte tiếp nối 10xxxxxx, gặp 0x%02X",
+	"overlong %d-byte encoding of U+%04X":                           "mã hoá %d byte thừa cho U+%04X",
+	"U+%04X is above U+10FFFF":                                      "U+%04X lớn hơn U+10FFFF",
+	"U+%04X is a surrogate":                                         "U+%04X là một surrogate",
+	"odd number of hex digits":                                      "số chữ số thập lục phân là số lẻ",
+
+	// utils
+	"byte order without --width":                                "thứ tự byte khi không có --width",
+	"invalid digit %q at position %d for base %d":               "chữ số %q không hợp lệ ở vị trí %d cho cơ số %d",
+	"overflow: %s does not fit in %d bits":                      "tràn số: %s không vừa %d bit",
+	"overflow: %s does not fit in %d-bit %s":                    "tràn số: %s không vừa %[3]s %[2]d bit",
+	"overflow: %s is out of range for %s":                       "tràn số: %s nằm ngoài phạm vi của %s",
+	"invalid length of %s %q: %s":                               "độ dài không hợp lệ của %s %q: %s",
+	" (at least %s)":                                            " (tối thiểu %s)",
+	" (range %s to %s)":                                         " (phạm vi %s đến %s)",
+	"non-ASCII code %s at position %d":                          "mã không phải ASCII %s ở vị trí %d",
+	"non-integer code %s at position %d":                        "mã %s ở vị trí %d không phải là số nguyên",
+	"non-ASCII character %q (%s) at position %d":                "ký tự không phải ASCII %q (%s) ở vị trí %d",
+	"invalid %s at byte offset %d: %s":                          "%s không hợp lệ ở byte %d: %s",
+	"above U+10FFFF":                                            "lớn hơn U+10FFFF",
+	"negative":                                                  "số âm",
+	"a surrogate":                                               "là một surrogate",
+	"invalid code point %s at position %d: %s":                  "mã Unicode %s không hợp lệ ở vị trí %d: %s",
+	"invalid BCD nibble %s at position %d: not a decimal digit": "nibble BCD %s không hợp lệ ở vị trí %d: không phải chữ số thập phân",
+	"invalid padding at position %d: %s":                        "phần đệm không hợp lệ ở vị trí %d: %s",
+	"invalid Roman numeral %s at position %d: %s":               "số La Mã %s không hợp lệ ở vị trí %d: %s",
+	"invalid Roman numeral: %s":                                 "số La Mã không hợp lệ: %s",
+	"invalid expression at position %d: %s":                     "biểu thức không hợp lệ ở vị trí %d: %s",
+	"invalid ascii character %d":                                "ký tự ascii %d không hợp lệ",
+	"invalid integer %q":                                        "số nguyên %q không hợp lệ",
+}
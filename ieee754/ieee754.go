@@ -0,0 +1,409 @@
+/*
+
+IEEE754
+
+*/
+
+package ieee754
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Format describes an IEEE 754 binary interchange format
+type Format struct {
+	Name     string
+	Title    string
+	Width    int
+	Exponent int // width of the biased exponent field
+	Mantissa int // width of the trailing significand field
+}
+
+var (
+	Half   = Format{utils.IEEE16, "half precision", 16, 5, 10}
+	Single = Format{utils.IEEE32, "single precision", 32, 8, 23}
+	Double = Format{utils.IEEE64, "double precision", 64, 11, 52}
+)
+
+// Rounding records how a value was normalized and rounded into a format
+type Rounding struct {
+	Bits        uint64
+	Exponent    int      // unbiased exponent; the minimum normal exponent for subnormals
+	Significand *big.Int // significand bits kept before rounding, hidden bit included
+	Remainder   *big.Rat // discarded part, in units of the last place
+	Up          bool     // the kept significand was incremented
+	Overflow    bool     // the value was too large and became infinity
+}
+
+// Lookup (name string) (Format, bool)
+func Lookup(name string) (Format, bool) {
+	switch strings.ToLower(name) {
+	case utils.IEEE16, utils.HALF:
+		return Half, true
+	case utils.IEEE32, utils.FLOAT:
+		return Single, true
+	case utils.IEEE64, utils.DOUBLE:
+		return Double, true
+	}
+	return Format{}, false
+}
+
+// Decimal2Float (s string, f Format) string - Decimal2FloatE, but panics if it fails
+func Decimal2Float(s string, f Format) string {
+	r, err := Decimal2FloatE(s, f)
+	utils.CheckError(err, utils.DECIMAL, f.Name)
+	return r
+}
+
+// Decimal2FloatE (s string, f Format) (string, error)
+func Decimal2FloatE(s string, f Format) (string, error) {
+	bits, err := f.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return f.Describe(bits), nil
+}
+
+// Float2Decimal (s string, f Format) string - Float2DecimalE, but panics if it fails
+func Float2Decimal(s string, f Format) string {
+	r, err := Float2DecimalE(s, f)
+	utils.CheckError(err, f.Name, utils.DECIMAL)
+	return r
+}
+
+// Float2DecimalE (s string, f Format) (string, error)
+func Float2DecimalE(s string, f Format) (string, error) {
+	bits, err := f.ParseBits(s, 0)
+	if err != nil {
+		return "", err
+	}
+	return f.Text(bits), nil
+}
+
+// Bits2Float (s string, base int, f Format) string - Bits2FloatE, but panics if it fails
+func Bits2Float(s string, base int, f Format) string {
+	r, err := Bits2FloatE(s, base, f)
+	utils.CheckError(err, utils.RadixFormat(base), f.Name)
+	return r
+}
+
+// Bits2FloatE (s string, base int, f Format) (string, error)
+func Bits2FloatE(s string, base int, f Format) (string, error) {
+	bits, err := f.ParseBits(s, base)
+	if err != nil {
+		return "", err
+	}
+	return f.Describe(bits), nil
+}
+
+// Float2Bits (s string, base int, f Format) string - Float2BitsE, but panics if it fails
+func Float2Bits(s string, base int, f Format) string {
+	r, err := Float2BitsE(s, base, f)
+	utils.CheckError(err, f.Name, utils.RadixFormat(base))
+	return r
+}
+
+// Float2BitsE (s string, base int, f Format) (string, error)
+func Float2BitsE(s string, base int, f Format) (string, error) {
+	bits, err := f.ParseBits(s, 0)
+	if err != nil {
+		return "", err
+	}
+	return f.Pad(bits, base), nil
+}
+
+// Float2Float (s string, from, to Format) string - Float2FloatE, but panics if it fails
+func Float2Float(s string, from, to Format) string {
+	r, err := Float2FloatE(s, from, to)
+	utils.CheckError(err, from.Name, to.Name)
+	return r
+}
+
+// Float2FloatE (s string, from, to Format) (string, error)
+func Float2FloatE(s string, from, to Format) (string, error) {
+	bits, err := from.ParseBits(s, 0)
+	if err != nil {
+		return "", err
+	}
+	return to.Describe(Convert(bits, from, to)), nil
+}
+
+// Convert (bits uint64, from, to Format) uint64
+func Convert(bits uint64, from, to Format) uint64 {
+	sign, exponent, mantissa := from.Fields(bits)
+	signBit := sign << uint(to.Width-1)
+
+	switch {
+	case exponent == from.maxExponent() && mantissa == 0:
+		return signBit | to.maxExponent()<<uint(to.Mantissa)
+	case exponent == from.maxExponent():
+		// keep the quiet bit and the leading payload bits
+		if to.Mantissa >= from.Mantissa {
+			mantissa <<= uint(to.Mantissa - from.Mantissa)
+		} else {
+			mantissa >>= uint(from.Mantissa - to.Mantissa)
+			if mantissa == 0 {
+				mantissa = 1
+			}
+		}
+		return signBit | to.maxExponent()<<uint(to.Mantissa) | mantissa
+	}
+
+	r := to.Round(from.Rat(bits))
+	return r.Bits | signBit
+}
+
+// Parse (s string) (uint64, error)
+func (f Format) Parse(s string) (uint64, error) {
+	t := strings.ToLower(strings.TrimSpace(s))
+	var sign uint64
+	if strings.HasPrefix(t, "-") {
+		sign = 1
+	}
+	signBit := sign << uint(f.Width-1)
+
+	switch name, payload, _ := strings.Cut(strings.TrimLeft(t, "+-"), ":"); name {
+	case "inf", "infinity":
+		return signBit | f.maxExponent()<<uint(f.Mantissa), nil
+	case "nan", "snan":
+		p := uint64(0)
+		if payload != "" {
+			i, err := utils.Parse(payload, utils.DECIMAL_BASE)
+			if err != nil || i.Sign() < 0 || i.BitLen() > f.Mantissa-1 {
+				return 0, i18n.Errorf("invalid NaN payload %q: want 0 to %d bits", payload, f.Mantissa-1)
+			}
+			p = i.Uint64()
+		}
+		quiet := f.quietBit()
+		if name == "snan" {
+			if p == 0 {
+				p = 1 // a zero payload would read back as infinity
+			}
+			quiet = 0
+		}
+		return signBit | f.maxExponent()<<uint(f.Mantissa) | quiet | p, nil
+	}
+
+	r, ok := new(big.Rat).SetString(t)
+	if !ok {
+		return 0, &strconv.NumError{Func: "Parse", Num: s, Err: strconv.ErrSyntax}
+	}
+	return f.Round(r).Bits | signBit, nil
+}
+
+// ParseBits (s string, base int) (uint64, error)
+func (f Format) ParseBits(s string, base int) (uint64, error) {
+	t := strings.NewReplacer(" ", "", "_", "").Replace(s)
+
+	// without an explicit base, a full-width run of 0s and 1s is binary and anything else is hexadecimal
+	switch lower := strings.ToLower(t); {
+	case strings.HasPrefix(lower, "0x") && (base == 0 || base == utils.HEXADECIMAL_BASE):
+		t, base = t[2:], utils.HEXADECIMAL_BASE
+	case strings.HasPrefix(lower, "0b") && (base == 0 || base == utils.BINARY_BASE):
+		t, base = t[2:], utils.BINARY_BASE
+	case base == 0 && len(t) == f.Width && strings.Trim(t, "01") == "":
+		base = utils.BINARY_BASE
+	case base == 0:
+		base = utils.HEXADECIMAL_BASE
+	}
+
+	i, err := utils.ParseRadix(t, base)
+	if err != nil {
+		return 0, err
+	}
+	if i.Sign() < 0 || i.BitLen() > f.Width {
+		return 0, &utils.ErrOverflow{Value: "bit pattern " + i.Text(utils.BINARY_BASE), Width: f.Width}
+	}
+	return i.Uint64(), nil
+}
+
+// Round (r *big.Rat) Rounding
+func (f Format) Round(r *big.Rat) Rounding {
+	a := new(big.Rat).Abs(r)
+	var sign uint64
+	if r.Sign() < 0 {
+		sign = 1
+	}
+	signBit := sign << uint(f.Width-1)
+
+	emin := f.MinExponent()
+	if a.Sign() == 0 {
+		return Rounding{Bits: signBit, Exponent: emin, Significand: new(big.Int), Remainder: new(big.Rat)}
+	}
+
+	// e = floor(log2 a)
+	e := a.Num().BitLen() - a.Denom().BitLen()
+	if a.Cmp(pow2(e)) < 0 {
+		e--
+	}
+	if e < emin {
+		e = emin
+	}
+
+	// scale so that the kept significand is the integer part
+	scaled := new(big.Rat).Mul(a, pow2(f.Mantissa-e))
+	q, rem := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	result := Rounding{
+		Exponent:    e,
+		Significand: new(big.Int).Set(q),
+		Remainder:   new(big.Rat).SetFrac(rem, scaled.Denom()),
+	}
+
+	// round to nearest, ties to even
+	c := new(big.Int).Lsh(rem, 1).Cmp(scaled.Denom())
+	if c > 0 || c == 0 && q.Bit(0) == 1 {
+		q.Add(q, big.NewInt(1))
+		result.Up = true
+	}
+	if q.BitLen() > f.Mantissa+1 {
+		q.Rsh(q, 1)
+		e++
+	}
+
+	exponent := uint64(0)
+	if q.BitLen() > f.Mantissa {
+		exponent = uint64(e + f.Bias())
+	}
+	if exponent >= f.maxExponent() {
+		result.Overflow = true
+		result.Bits = signBit | f.maxExponent()<<uint(f.Mantissa)
+		return result
+	}
+
+	mantissa := q.SetBit(q, f.Mantissa, 0).Uint64()
+	result.Bits = signBit | exponent<<uint(f.Mantissa) | mantissa
+	return result
+}
+
+// Rat (bits uint64) *big.Rat
+func (f Format) Rat(bits uint64) *big.Rat {
+	sign, exponent, mantissa := f.Fields(bits)
+
+	significand := new(big.Int).SetUint64(mantissa)
+	e := f.MinExponent()
+	if exponent != 0 {
+		significand.SetBit(significand, f.Mantissa, 1)
+		e = int(exponent) - f.Bias()
+	}
+
+	r := new(big.Rat).SetInt(significand)
+	r.Mul(r, pow2(e-f.Mantissa))
+	if sign == 1 {
+		r.Neg(r)
+	}
+	return r
+}
+
+// Text (bits uint64) string
+func (f Format) Text(bits uint64) string {
+	sign, _, mantissa := f.Fields(bits)
+	prefix := ""
+	if sign == 1 {
+		prefix = "-"
+	}
+
+	switch f.Class(bits) {
+	case "infinity":
+		return prefix + "Inf"
+	case "nan":
+		name, payload := "NaN", mantissa&^f.quietBit()
+		if mantissa&f.quietBit() == 0 {
+			// a signalling NaN needs a non-zero payload; 1 is what "snan" parses to
+			name = "sNaN"
+			if payload == 1 {
+				payload = 0
+			}
+		}
+		if payload != 0 {
+			return fmt.Sprintf("%s%s:%#x", prefix, name, payload)
+		}
+		return prefix + name
+	case "zero":
+		return prefix + "0"
+	}
+
+	// shortest decimal that reads back to the same bits
+	x := new(big.Float).SetPrec(uint(f.Mantissa + 1)).SetRat(f.Rat(bits))
+	return x.Text('g', -1)
+}
+
+// Describe (bits uint64) string
+func (f Format) Describe(bits uint64) string {
+	sign, exponent, mantissa := f.Fields(bits)
+	return fmt.Sprintf("sign=%d exponent=%0*b mantissa=%0*b (%s)",
+		sign, f.Exponent, exponent, f.Mantissa, mantissa, "0x"+f.Pad(bits, utils.HEXADECIMAL_BASE))
+}
+
+// Pad (bits uint64, base int) string
+func (f Format) Pad(bits uint64, base int) string {
+	s := strconv.FormatUint(bits, base)
+	max := new(big.Int).Lsh(big.NewInt(1), uint(f.Width))
+	if digits := len(max.Sub(max, big.NewInt(1)).Text(base)); len(s) < digits {
+		s = strings.Repeat("0", digits-len(s)) + s
+	}
+	return s
+}
+
+// Fields (bits uint64) (sign, exponent, mantissa uint64)
+func (f Format) Fields(bits uint64) (sign, exponent, mantissa uint64) {
+	sign = bits >> uint(f.Width-1) & 1
+	exponent = bits >> uint(f.Mantissa) & f.maxExponent()
+	mantissa = bits & (1<<uint(f.Mantissa) - 1)
+	return sign, exponent, mantissa
+}
+
+// Class (bits uint64) string
+func (f Format) Class(bits uint64) string {
+	_, exponent, mantissa := f.Fields(bits)
+	switch {
+	case exponent == f.maxExponent() && mantissa == 0:
+		return "infinity"
+	case exponent == f.maxExponent():
+		return "nan"
+	case exponent == 0 && mantissa == 0:
+		return "zero"
+	case exponent == 0:
+		return "subnormal"
+	default:
+		return "normal"
+	}
+}
+
+// Bias () int
+func (f Format) Bias() int {
+	return 1<<uint(f.Exponent-1) - 1
+}
+
+// MinExponent () int
+func (f Format) MinExponent() int {
+	return 1 - f.Bias()
+}
+
+// MaxExponent () int
+func (f Format) MaxExponent() int {
+	return f.Bias()
+}
+
+// maxExponent () uint64 - the all-ones exponent field
+func (f Format) maxExponent() uint64 {
+	return 1<<uint(f.Exponent) - 1
+}
+
+// quietBit () uint64 - the leading mantissa bit, set for quiet NaNs
+func (f Format) quietBit() uint64 {
+	return 1 << uint(f.Mantissa-1)
+}
+
+// pow2 (n int) *big.Rat
+func pow2(n int) *big.Rat {
+	if n >= 0 {
+		return new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), uint(n)))
+	}
+	return new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Lsh(big.NewInt(1), uint(-n)))
+}
@@ -0,0 +1,41 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package ieee754_test
+
+import (
+	"fmt"
+
+	"github.com/clarketm/ncalc/ieee754"
+	"github.com/clarketm/ncalc/utils"
+)
+
+func Example() {
+
+	// IEEE 754
+	fmt.Println(ieee754.Decimal2Float("3.14", ieee754.Single))
+	fmt.Println(ieee754.Decimal2Float("-0.1", ieee754.Double))
+	fmt.Println(ieee754.Decimal2Float("65520", ieee754.Half))
+	fmt.Println(ieee754.Float2Decimal("40490fdb", ieee754.Single))
+	fmt.Println(ieee754.Float2Decimal("0x0001", ieee754.Half))
+	fmt.Println(ieee754.Float2Decimal("0 11111 1000000001", ieee754.Half))
+	fmt.Println(ieee754.Float2Decimal("ff800000", ieee754.Single))
+	fmt.Println(ieee754.Float2Bits("0x3f800000", utils.BINARY_BASE, ieee754.Single))
+	fmt.Println(ieee754.Float2Float("3fb999999999999a", ieee754.Double, ieee754.Half))
+
+	// Output:
+	// sign=0 exponent=10000000 mantissa=10010001111010111000011 (0x4048f5c3)
+	// sign=1 exponent=01111111011 mantissa=1001100110011001100110011001100110011001100110011010 (0xbfb999999999999a)
+	// sign=0 exponent=11111 mantissa=0000000000 (0x7c00)
+	// 3.1415927
+	// 5.96e-08
+	// NaN:0x1
+	// -Inf
+	// 00111111100000000000000000000000
+	// sign=0 exponent=01011 mantissa=1001100110 (0x2e66)
+}
@@ -8,53 +8,147 @@ NAME:
     ncalc – number base converter.
 
 SYNOPSIS:
-    ncalc [ opts... ] [ number|character ]
+    ncalc [ opts... ] [ number|text ]
+    ncalc [ opts... ] bit [ x op y | not x ]
+    ncalc [ opts... ] -x expression
 
 OPTIONS:
     -h, --help                  print usage.
     -i, --input format          input format. see FORMATS. (default: decimal|ascii)
-    -o, --output format         output format. see FORMATS. (default: all)
+    -o, --output format         output format. see FORMATS. (default: all; skips formats that cannot show the value)
     -q, --quiet                 suppress printing of output format type(s)
     -s, --steps                 show step-by-step solution
     -e, --excel filename        export step-by-step solution to excel file
     -l, --latex                 use LaTeX formatting in excel output
     -f, --file filename         read input from text file
+    -w, --width bits            fixed bit width for signed values (8|16|32|64) and bit operations
+        --signed repr           signed representation: twos|ones|signmag (default: twos)
+    -p, --precision digits      maximum fractional digits before truncating (default: 32)
+        --round mode            fixed-point rounding: truncate|nearest|convergent (default: nearest)
+        --saturate              clamp out-of-range fixed-point values instead of failing
+        --prefix[=style]        prefix numbers: 0x (0b, 0o, 0x, the default) or subscript (ff₁₆)
+        --pad N                 pad numbers with leading zeros to at least N digits
+        --group size            group digits from the right, e.g. 4 (nibbles) or 3 (thousands)
+        --separator sep         digit group separator (default: , for --group 3, _ otherwise)
+        --upper                 write letter digits in upper case
+        --endian order          byte order of hexadecimal and binary output: little|big|both
+        --word size             word size in bytes for --endian: 1|2|4|8 (default: 1)
+        --method name           step-by-step method: grouping|decimal for power-of-two bases (default: grouping), horner for conversions to decimal (default: powers of the base), borrow|complement for subtraction (default: both)
+        --lang code             language of messages and step-by-step solutions: en|vi (default: from LANG, else en)
+    -x, --expression            evaluate an arithmetic expression of mixed-base integers: + - * / % ( )
     -v, --version               print version number.
 
 FORMATS:
-    (a)scii                     character
+    (a)scii                     7-bit ASCII text, one code per character
     (b)inary                    base 2
     (o)ctal                     base 8
     (d)ecimal                   base 10
     (h)exadecimal               base 16
+    base:N                      base N (2-62)
+    ieee16|ieee32|ieee64        IEEE 754 half|single|double precision (aliases: half|float|double)
+    (u)nicode                   code points, U+XXXX
+    utf8|utf16le|utf16be        text encoded as hex bytes
+    utf32|utf32le               text encoded as big-|little-endian UTF-32 hex bytes
+    bcd|bcd-packed              binary coded decimal: a nibble per digit | two digits per hex byte
+    (g)ray                      binary-reflected Gray code
+    (r)oman                     Roman numerals from 1 to 3999, canonical form only
+    negabinary                  base -2, no sign needed (aliases: nega|base:-2)
+    balanced-ternary            base 3 with digits T (-1), 0 and 1 (aliases: bt|balanced)
+    bijective                   bijective base 26, spreadsheet column names A-Z, AA, ... (alias: column)
+    qM.N                        signed fixed point with M integer bits (sign included) and N fraction bits, e.g. q1.15
+    base64|base64url            bytes as RFC 4648 base64 (padded) | URL-safe base64 (unpadded)
+    base32|base58               bytes as RFC 4648 base32 | Bitcoin base58
+
+BIT OPERATIONS:
+    x and|or|xor y              bitwise AND, OR, exclusive OR
+    not x                       invert every bit of x
+    x shl|shr|sar n             shift left, right filling with zeros, right copying the sign bit
+    x rotl|rotr n               rotate left, right
+    x set|clear|toggle n        set bit n to 1, to 0, invert it
+    x mask hi:lo                bits hi down to lo of x, moved down to bit 0
 
 EXAMPLES:
     ncalc "6"                               # output `decimal` number `6` in `all` formats
     ncalc "G"                               # output `ascii` character `G` in `all` formats
+    ncalc "0b1010"                          # detect a base literal: 0b1010, 0o17, 0x1F, 1Fh, 1010b, &H1F, #FF
     ncalc -i a "f"                          # output `ascii` character `f` in `all` formats
+    ncalc -i a -o h "Hello"                 # output `48 65 6c 6c 6f`, the code of each character
+    ncalc -i h -o a 48 65 6c 6c 6f          # decode a sequence of codes back to text
+    ncalc -i a -o utf8 "é"                  # output `C3 A9 (U+00E9)`, the UTF-8 bytes of `é`
+    ncalc -i utf16le -o u "3D D8 00 DE"     # decode UTF-16LE bytes to code points
+    ncalc -i a -o utf8 -s "é"               # show the UTF-8 bit packing step by step
+    ncalc -i d -o bcd "2947"                # output `0010 1001 0100 0111`
+    ncalc -i bcd-packed -o d -s "29 47"     # decode packed BCD with the per-digit nibble mapping
+    ncalc -i d -o gray "13"                 # output `1011`, the Gray code of 13
+    ncalc -i gray -o d -s "1011"            # decode Gray code with step-by-step XOR
+    ncalc -i d -o roman "1994"              # output `MCMXCIV`
+    ncalc -i roman -o d -s "MCMXCIV"        # decode a Roman numeral, splitting out the subtractive pairs
+    ncalc -i d -o nega -s -- "-7"           # output `1001`, correcting each negative remainder
+    ncalc -i d -o bt -- "-7"                # output `T1T` in balanced ternary
+    ncalc -i column -o d "AB"               # output `28`, the spreadsheet column number of AB
+    ncalc -i d -o q1.15 -- "-0.5"           # output `0xC000`, -0.5 as a Q1.15 word
+    ncalc -i q1.15 -o d "0x0CCD"            # output `0.100006103515625`, the value of a Q1.15 word
+    ncalc -i d -o q1.15 --saturate -s "1"   # saturate to `0x7FFF`, showing the 2^15 scaling
+    ncalc -o h --prefix --upper "255"       # output `0xFF`
+    ncalc -o b --pad 16 --group 4 "1000"    # output `0000_0011_1110_1000`
+    ncalc -o d --group 3 "1234567"          # output `1,234,567`
+    ncalc -o h --endian little "0x12345678" # output `78 56 34 12`, the bytes in little-endian order
+    ncalc -o h --endian both --word 2 "0x12345678" # output `1234 5678` and `5678 1234`
+    ncalc -x "0x1F * 3 + 0b101 - 0o17"      # output `83` in `all` formats
+    ncalc -x -o h "(0xFF + 1) / 2"          # output `80`
+    ncalc -x -s -i b "1011 - 110"           # subtract in binary with borrows, then with two's complement
+    ncalc -x -i h -e "sum.xlsx" -l "ff + 1" # export the column-by-column addition to Excel
+    ncalc bit 0xF0 or 0b1010                # output 0xF0 | 0b1010 = 250 in `all` formats
+    ncalc bit -w 8 -o h 0x81 rotl 1         # output `03`
+    ncalc bit -o h 0xABCD mask 11:8         # output `b`, bits 11 to 8
+    ncalc -i h -o base64 "deadbeef"         # output `3q2+7w==`, the bytes DE AD BE EF in base64
+    ncalc -i a -o base64 -s "Hi"            # show the regrouping into 6-bit groups step by step
+    ncalc -i base58 -o h "115Q"             # output `0000ff`, one zero byte per leading `1`
     ncalc -i decimal -o ascii "15"          # output `decimal` number `15` as `ascii`
     ncalc --input h --output o "ff"         # output `hexadecimal` number `ff` as `octal`
     ncalc -i d -o b -s "15"                 # convert decimal 15 to binary with steps
+    ncalc -i d -o b -s --lang vi "15"       # the same steps in Vietnamese
+    ncalc -i b -o h -s "1011010"            # group the bits in fours: 0101 1010 = 5A
+    ncalc -i o -o h -s --method decimal "755" # go through decimal instead of grouping bits
+    ncalc -i h -o d -s --method horner "2F3" # multiply and add from the left: (2 x 16 + 15) x 16 + 3
+    ncalc -i base:7 -o base:36 "1202"       # output base 7 number `1202` as base 36
+    ncalc -i d -o b "0.1"                   # output `0.0(0011)`, the repeating binary expansion of 0.1
+    ncalc -i h -o d -s "1A.8"               # convert hexadecimal 1A.8 to decimal with steps
+    ncalc -i d -o ieee32 "3.14"             # encode 3.14 as an IEEE 754 single precision float
+    ncalc -i ieee64 -o d "3fb999999999999a" # decode a double precision bit pattern
+    ncalc -w 8 -i d -o b -- "-5"            # encode `-5` as an 8-bit two's complement pattern
+    ncalc -w 8 --signed ones -i h -o d "fa" # decode `fa` as an 8-bit one's complement value
     ncalc -i d -o all -e "result.xlsx" "42" # export conversions to Excel file
     ncalc -f "input.txt" -e "result.xlsx" -l # read from text file, export to excel with LaTeX
 
+EXIT STATUS:
+    0                           success
+    1                           general error (e.g. unreadable input file)
+    2                           invalid command-line option
+    3                           invalid input (e.g. a digit outside the input base, non-ASCII text, bad UTF-8, BCD nibble above 9, bad base64 padding, non-canonical Roman numeral, malformed expression or division by zero)
+    4                           value does not fit in the requested width
+
 */
 
 package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"math/big"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 
 	flag "github.com/clarketm/pflag"
 
-	"github.com/clarketm/ncalc/ascii"
-	"github.com/clarketm/ncalc/binary"
-	"github.com/clarketm/ncalc/decimal"
-	"github.com/clarketm/ncalc/hexadecimal"
-	"github.com/clarketm/ncalc/octal"
+	"github.com/clarketm/ncalc/bitwise"
+	"github.com/clarketm/ncalc/expression"
+	"github.com/clarketm/ncalc/format"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/signed"
 	"github.com/clarketm/ncalc/stepbystep"
 	"github.com/clarketm/ncalc/utils"
 	"github.com/fatih/color"
@@ -63,6 +157,14 @@ import (
 // VERSION - current version number
 const VERSION = "v1.2.3"
 
+// Mã thoát của chương trình
+const (
+	exitError    = 1 // lỗi chung
+	exitUsage    = 2 // sai tuỳ chọn dòng lệnh
+	exitInvalid  = 3 // đầu vào không hợp lệ
+	exitOverflow = 4 // giá trị vượt quá độ rộng bit
+)
+
 type inputFlag []string
 
 func (i *inputFlag) String() string {
@@ -74,7 +176,12 @@ func (i *inputFlag) Type() string {
 }
 
 func (i *inputFlag) Set(value string) error {
-	*i = getFormat(value)
+	format, err := getFormat(value)
+	if err != nil {
+		statusCode = exitUsage
+		return errors.New(loc.Error(err)) // pflag tự in lỗi nên dịch ngay ở đây
+	}
+	*i = format
 	return nil
 }
 
@@ -88,7 +195,12 @@ func (o *outputFlag) Type() string {
 }
 
 func (o *outputFlag) Set(value string) error {
-	*o = getFormat(value)
+	format, err := getFormat(value)
+	if err != nil {
+		statusCode = exitUsage
+		return errors.New(loc.Error(err)) // pflag tự in lỗi nên dịch ngay ở đây
+	}
+	*o = format
 	return nil
 }
 
@@ -99,56 +211,25 @@ var showSteps bool
 var excelFile string
 var inputFile string
 var useLaTeX bool
+var width int
+var signedRep string
+var precision int
+var rounding string
+var saturate bool
+var presentation utils.Presentation
+var endian string
+var expressionMode bool
+var method string
+var word int
+var lang string
 
 var inputFormat inputFlag
 var outputFormat outputFlag = utils.ALL
 
 // Globals
 var statusCode int
+var loc i18n.Localizer // ngôn ngữ của thông báo và lời giải, chọn một lần trong main
 var bold = color.New(color.Bold).SprintFunc()
-var funcMap = map[string]interface{}{
-	"ascii|ascii":             ascii.String,
-	"ascii|binary":            ascii.Ascii2Binary,
-	"ascii|octal":             ascii.Ascii2Octal,
-	"ascii|decimal":           ascii.Ascii2Decimal,
-	"ascii|hexadecimal":       ascii.Ascii2Hexadecimal,
-	"binary|ascii":            binary.Binary2Ascii,
-	"binary|binary":           binary.String,
-	"binary|octal":            binary.Binary2Octal,
-	"binary|decimal":          binary.Binary2Decimal,
-	"binary|hexadecimal":      binary.Binary2Hexadecimal,
-	"octal|ascii":             octal.Octal2Ascii,
-	"octal|binary":            octal.Octal2Binary,
-	"octal|octal":             octal.String,
-	"octal|decimal":           octal.Octal2Decimal,
-	"octal|hexadecimal":       octal.Octal2Hexadecimal,
-	"decimal|ascii":           decimal.Decimal2Ascii,
-	"decimal|binary":          decimal.Decimal2Binary,
-	"decimal|octal":           decimal.Decimal2Octal,
-	"decimal|decimal":         decimal.String,
-	"decimal|hexadecimal":     decimal.Decimal2Hexadecimal,
-	"hexadecimal|ascii":       hexadecimal.Hexadecimal2Ascii,
-	"hexadecimal|binary":      hexadecimal.Hexadecimal2Binary,
-	"hexadecimal|octal":       hexadecimal.Hexadecimal2Octal,
-	"hexadecimal|decimal":     hexadecimal.Hexadecimal2Decimal,
-	"hexadecimal|hexadecimal": hexadecimal.String,
-}
-
-// Map cho các hàm giải từng bước
-var stepsFuncMap = map[string]func(string) *stepbystep.StepByStepResult{
-	"binary|decimal":          stepbystep.Binary2DecimalSteps,
-	"binary|octal":            stepbystep.Binary2OctalSteps,
-	"binary|hexadecimal":      stepbystep.Binary2HexadecimalSteps,
-	"octal|decimal":           stepbystep.Octal2DecimalSteps,
-	"octal|binary":            stepbystep.Octal2BinarySteps,
-	"octal|hexadecimal":       stepbystep.Octal2HexadecimalSteps,
-	"decimal|binary":          stepbystep.Decimal2BinarySteps,
-	"decimal|octal":           stepbystep.Decimal2OctalSteps,
-	"decimal|hexadecimal":     stepbystep.Decimal2HexadecimalSteps,
-	"hexadecimal|decimal":     stepbystep.Hexadecimal2DecimalSteps,
-	"hexadecimal|binary":      stepbystep.Hexadecimal2BinarySteps,
-	"hexadecimal|octal":       stepbystep.Hexadecimal2OctalSteps,
-}
 
 // init () - initialize command-line flags
 func init() {
@@ -167,105 +248,339 @@ func init() {
 	// -f, --file
 	flag.StringVarP(&inputFile, "file", "f", "", "read input from text file")
 
+	// -w, --width
+	flag.IntVarP(&width, "width", "w", 0, "fixed bit `width` for signed values (8|16|32|64) and bit operations")
+
+	// --signed
+	flag.StringVar(&signedRep, "signed", "", "signed `representation`: twos|ones|signmag (default: twos)")
+
+	// -p, --precision
+	flag.IntVarP(&precision, "precision", "p", utils.DEFAULT_PRECISION, "maximum fractional `digits` before truncating")
+
+	// --round
+	flag.StringVar(&rounding, "round", utils.ROUND_NEAREST, "fixed-point rounding `mode`: truncate|nearest|convergent")
+
+	// --saturate
+	flag.BoolVar(&saturate, "saturate", false, "clamp out-of-range fixed-point values instead of failing")
+
+	// --prefix
+	flag.StringVar(&presentation.Prefix, "prefix", "", "number `style`: 0x (0b, 0o, 0x) or subscript")
+	flag.Lookup("prefix").NoOptDefVal = utils.PREFIX_C
+
+	// --pad
+	flag.IntVar(&presentation.Pad, "pad", 0, "pad numbers with leading zeros to at least `N` digits")
+
+	// --group
+	flag.IntVar(&presentation.Group, "group", 0, "group digits by `size` from the right, e.g. 4 (nibbles) or 3 (thousands)")
+
+	// --separator
+	flag.StringVar(&presentation.Separator, "separator", "", "digit group `separator` (default: , for --group 3, _ otherwise)")
+
+	// --upper
+	flag.BoolVar(&presentation.Upper, "upper", false, "write letter digits in upper case")
+
+	// --endian
+	flag.StringVar(&endian, "endian", "", "byte `order` of hexadecimal and binary output: little|big|both")
+
+	// --word
+	flag.IntVar(&word, "word", 0, "word `size` in bytes for --endian: 1|2|4|8 (default: 1)")
+
 	// -i, --input
 	flag.VarP(&inputFormat, "input", "i", "input `format`: see FORMATS.")
 
 	// -o, --output
 	flag.VarP(&outputFormat, "output", "o", "output `format`: see FORMATS.")
 
+	// --method
+	flag.StringVar(&method, "method", "", "step-by-step `method`: grouping|decimal (power-of-two bases), horner (to decimal), borrow|complement (subtraction)")
+
+	// --lang
+	flag.StringVar(&lang, "lang", "", "`language` of messages and step-by-step solutions: en|vi (default: from LANG)")
+
+	// -x, --expression
+	flag.BoolVarP(&expressionMode, "expression", "x", false, "evaluate an arithmetic expression of mixed-base integers")
+
 	// -v, --version
 	flag.BoolVarP(&version, "version", "v", false, "print version number")
 
 	// Usage
 	flag.Usage = func() {
+		// -h được xử lý ngay khi đọc tuỳ chọn, nên --lang đứng trước nó chưa được áp dụng cho loc
+		l := loc
+		if i18n.Supported(strings.ToLower(lang)) {
+			l = i18n.New(strings.ToLower(lang))
+		}
+		// các dòng trợ giúp của tuỳ chọn là khoá trong bảng dịch, dịch ngay trước khi in
+		flag.VisitAll(func(f *flag.Flag) {
+			f.Usage = l.T(f.Usage)
+		})
+
 		println()
-		fmt.Printf("NAME:\n")
-		fmt.Printf("\tncalc – number base converter.\n")
+		fmt.Printf("%s\n", l.T("NAME:"))
+		fmt.Printf("\t%s\n", l.T("ncalc – number base converter."))
 		println()
-		fmt.Printf("SYNOPSIS:\n")
-		fmt.Printf("\t%v [ opts... ] [ number|character ]\n", bold("ncalc"))
+		fmt.Printf("%s\n", l.T("SYNOPSIS:"))
+		fmt.Printf("\t%s\n", l.T("%v [ opts... ] [ number|text ]", bold("ncalc")))
+		fmt.Printf("\t%s\n", l.T("%v [ opts... ] bit [ x op y | not x ]", bold("ncalc")))
+		fmt.Printf("\t%s\n", l.T("%v [ opts... ] -x expression", bold("ncalc")))
 		println()
-		fmt.Printf("OPTIONS:\n")
+		fmt.Printf("%s\n", l.T("OPTIONS:"))
 		flag.PrintDefaults()
 		println()
-		fmt.Printf("FORMATS:\n")
-		fmt.Printf("\t(a)scii      \tcharacter\n")
-		fmt.Printf("\t(b)inary     \tbase 2\n")
-		fmt.Printf("\t(o)ctal      \tbase 8\n")
-		fmt.Printf("\t(d)ecimal    \tbase 10\n")
-		fmt.Printf("\t(h)exadecimal\tbase 16\n")
+		// tên định dạng là thứ gõ sau -i và -o nên giữ nguyên, chỉ dịch phần mô tả
+		fmt.Printf("%s\n", l.T("FORMATS:"))
+		fmt.Printf("\t(a)scii      \t%s\n", l.T("7-bit ASCII text, one code per character"))
+		fmt.Printf("\t(b)inary     \t%s\n", l.T("base %d", utils.BINARY_BASE))
+		fmt.Printf("\t(o)ctal      \t%s\n", l.T("base %d", utils.OCTAL_BASE))
+		fmt.Printf("\t(d)ecimal    \t%s\n", l.T("base %d", utils.DECIMAL_BASE))
+		fmt.Printf("\t(h)exadecimal\t%s\n", l.T("base %d", utils.HEXADECIMAL_BASE))
+		fmt.Printf("\tbase:N       \t%s\n", l.T("base N (%d-%d)", utils.MIN_BASE, utils.MAX_BASE))
+		fmt.Printf("\tieee16|32|64 \t%s\n", l.T("IEEE 754 half|single|double precision"))
+		fmt.Printf("\t(u)nicode    \t%s\n", l.T("code points, U+XXXX"))
+		fmt.Printf("\tutf8 ...     \t%s\n", l.T("utf8|utf16le|utf16be|utf32|utf32le text as hex bytes"))
+		fmt.Printf("\tbcd|bcd-packed\t%s\n", l.T("binary coded decimal: a nibble per digit | two digits per hex byte"))
+		fmt.Printf("\t(g)ray       \t%s\n", l.T("binary-reflected Gray code"))
+		fmt.Printf("\t(r)oman      \t%s\n", l.T("Roman numerals (1-3999)"))
+		fmt.Printf("\tnegabinary   \t%s\n", l.T("base -2"))
+		fmt.Printf("\tbalanced-ternary\t%s\n", l.T("base 3 with digits T (-1), 0 and 1"))
+		fmt.Printf("\tbijective    \t%s\n", l.T("bijective base 26, spreadsheet column names"))
+		fmt.Printf("\tqM.N         \t%s\n", l.T("signed fixed point, e.g. q1.15"))
+		fmt.Printf("\tbase64 ...   \t%s\n", l.T("base64|base64url|base32|base58 byte strings"))
+		println()
+		fmt.Printf("%s\n", l.T("BIT OPERATIONS:"))
+		fmt.Printf("\t%v x and|or|xor y, not x, x shl|shr|sar|rotl|rotr n,\n", bold("ncalc bit"))
+		fmt.Printf("\t          x set|clear|toggle n, x mask hi:lo\n")
 		println()
 		os.Exit(statusCode)
 	}
 }
 
 func printVersion() {
-	fmt.Printf("\n%s %v\n", bold("Version:"), VERSION)
+	fmt.Printf("\n%s %v\n", bold(loc.T("Version:")), VERSION)
 	os.Exit(0)
 }
 
-func getFormat(format string) []string {
-	o := make([]string, 1)
-
-	switch format {
-	case "all":
-		return utils.ALL
-	case utils.ASCII, string(utils.ASCII[0]):
-		o = []string{utils.ASCII}
-	case utils.BINARY, string(utils.BINARY[0]):
-		o = []string{utils.BINARY}
-	case utils.OCTAL, string(utils.OCTAL[0]):
-		o = []string{utils.OCTAL}
-	case utils.DECIMAL, string(utils.DECIMAL[0]):
-		o = []string{utils.DECIMAL}
-	case utils.HEXADECIMAL, string(utils.HEXADECIMAL[0]):
-		o = []string{utils.HEXADECIMAL}
-	default:
-		fmt.Fprintln(os.Stderr, "Unkown format", format)
-		os.Exit(1)
+func getFormat(name string) ([]string, error) {
+	if name == "all" {
+		return utils.ALL, nil
 	}
-	return o
+	f, ok := format.Lookup(name)
+	if !ok {
+		return nil, i18n.Errorf("unknown format %s", name)
+	}
+	return []string{f.Name()}, nil
 }
 
 func setDefaultInputFormat(v interface{}) {
-	if utils.IsDecimal(v.(string)) {
-		inputFormat = []string{utils.DECIMAL}
-	} else {
-		inputFormat = []string{utils.ASCII}
+	fields := utils.Fields(v.(string))
+
+	// số viết kèm tiền tố hoặc hậu tố cơ số, ví dụ 0b1010, 0o17, 1Fh, &H1F, #FF
+	base := 0
+	for _, f := range fields {
+		lit, err := utils.ParseLiteral(f)
+		if err != nil {
+			// 0xZZ là số hỏng chứ không phải văn bản
+			if err != utils.ErrNotLiteral && len(fields) == 1 {
+				fail(i18n.Errorf("error reading %s: %w", f, err))
+			}
+			base = 0
+			break
+		}
+		if base != 0 && lit.Base != base {
+			base = utils.DECIMAL_BASE // các cơ số khác nhau: mỗi số được đọc theo cơ số của nó
+		} else {
+			base = lit.Base
+		}
+	}
+	if base != 0 {
+		inputFormat = []string{utils.RadixFormat(base)}
+		return
+	}
+
+	// một dãy số thập phân cách nhau bởi dấu cách cũng là đầu vào thập phân
+	for _, f := range fields {
+		if !utils.IsDecimal(f) && !utils.IsLiteral(f) {
+			inputFormat = []string{utils.ASCII}
+			return
+		}
+	}
+	inputFormat = []string{utils.DECIMAL}
+}
+
+// noteAmbiguity in ra stderr các cách đọc khác nhau của một số không ghi rõ cơ số, ví dụ 10 hoặc 0101
+func noteAmbiguity(arg string) {
+	readings := utils.Interpretations(strings.TrimSpace(arg))
+	if inputFormat[0] == utils.ASCII && len(readings) == 0 {
+		return
+	}
+
+	values := map[string]bool{}
+	var choices []string
+	if inputFormat[0] == utils.ASCII {
+		values["text"] = true
+		choices = append(choices, loc.T("text"))
+	}
+	for _, r := range readings {
+		values[r.Value().String()] = true
+		choices = append(choices, fmt.Sprintf("%s %s = %s", utils.RadixFormat(r.Base), r, r.Value()))
+	}
+	if len(values) < 2 {
+		return
+	}
+	reading := inputFormat[0]
+	if reading == utils.ASCII {
+		reading = loc.T("text")
 	}
+	fmt.Fprintln(os.Stderr, loc.T("note: %s is ambiguous: %s; reading it as %s (choose with -i)",
+		strings.TrimSpace(arg), strings.Join(choices, ", "), reading))
 }
 
 // baseNameToFormat chuyển đổi tên cơ số thành định dạng
-func baseNameToFormat(baseName string) string {
+func baseNameToFormat(baseName string) (string, error) {
 	baseName = strings.ToLower(baseName)
-	switch baseName {
-	case "2", "binary", "b":
-		return utils.BINARY
-	case "8", "octal", "o":
-		return utils.OCTAL
-	case "10", "decimal", "d":
-		return utils.DECIMAL
-	case "16", "hexadecimal", "h", "hex":
-		return utils.HEXADECIMAL
-	case "ascii", "a":
-		return utils.ASCII
-	case "all":
-		return "all"
-	default:
-		fmt.Fprintf(os.Stderr, "Không hỗ trợ cơ số %s\n", baseName)
-		os.Exit(1)
-		return ""
+	if baseName == "all" {
+		return "all", nil
+	}
+	f, ok := format.Lookup(baseName)
+	if !ok {
+		return "", i18n.Errorf("unsupported base %s", baseName)
+	}
+	return f.Name(), nil
+}
+
+// convert chuyển đổi s từ định dạng from sang định dạng to
+func convert(s, from, to string) (string, error) {
+	fromBase, fromOk := utils.BaseOf(from)
+	toBase, toOk := utils.BaseOf(to)
+
+	// Số có dấu với độ rộng bit cố định
+	if width > 0 && fromOk && toOk {
+		return signed.ConvertE(s, fromBase, toBase, width, signedRep)
+	}
+	return format.Convert(s, from, to, options())
+}
+
+// options trả về các tuỳ chọn chuyển đổi lấy từ dòng lệnh
+func options() utils.Options {
+	return utils.Options{Precision: precision, Rounding: rounding, Saturate: saturate, Method: method}
+}
+
+// getStepsFunc trả về hàm giải từng bước cho chuyển đổi từ định dạng from sang định dạng to
+func getStepsFunc(from, to string) (stepbystep.StepsFunc, bool) {
+	fromBase, fromOk := utils.BaseOf(from)
+	toBase, toOk := utils.BaseOf(to)
+
+	// Giải từng bước cho số có dấu
+	if width > 0 && fromOk && toOk {
+		if fromBase == toBase {
+			return nil, false
+		}
+		return func(s string) (*stepbystep.StepByStepResult, error) {
+			return stepbystep.SignedSteps(s, fromBase, toBase, width, signedRep)
+		}, true
 	}
+	return format.Steps(from, to, options())
+}
+
+// solveSteps giải từng bước s bằng stepsFunc, thoát với mã lỗi tương ứng nếu s không hợp lệ
+func solveSteps(stepsFunc stepbystep.StepsFunc, s, from, to string) *stepbystep.StepByStepResult {
+	result, err := stepsFunc(s)
+	if err != nil {
+		fail(i18n.Errorf("error converting %s to %s: %w", from, to, err))
+	}
+	return result
 }
 
 // main ()
 func main() {
+	// Ngôn ngữ mặc định lấy từ LANG, để cả lỗi khi đọc tuỳ chọn cũng được dịch
+	loc = i18n.New(i18n.Detect(os.Getenv("LANG")))
 	flag.Parse()
 
+	// Kiểm tra ngôn ngữ
+	if lang != "" {
+		if !i18n.Supported(strings.ToLower(lang)) {
+			fmt.Fprintln(os.Stderr, loc.T("--lang must be %s or %s", i18n.EN, i18n.VI))
+			os.Exit(exitUsage)
+		}
+		loc = i18n.New(strings.ToLower(lang))
+	}
+
 	if version {
 		printVersion() // version and EXIT
 	}
 
+	// Kiểm tra tuỳ chọn số có dấu
+	if signedRep != "" && width == 0 {
+		fmt.Fprintln(os.Stderr, loc.T("--signed needs --width"))
+		os.Exit(exitUsage)
+	}
+	if width > 0 {
+		if signedRep == "" {
+			signedRep = utils.TWOS_COMPLEMENT
+		}
+		if _, _, err := signed.Range(width, signedRep); err != nil {
+			fmt.Fprintln(os.Stderr, loc.Error(err))
+			os.Exit(exitUsage)
+		}
+	}
+
+	// Kiểm tra độ chính xác phần phân số
+	if precision < 1 {
+		fmt.Fprintln(os.Stderr, loc.T("--precision must be greater than 0"))
+		os.Exit(exitUsage)
+	}
+
+	// Kiểm tra chế độ làm tròn số dấu phẩy tĩnh
+	switch rounding {
+	case utils.ROUND_TRUNCATE, utils.ROUND_NEAREST, utils.ROUND_CONVERGENT:
+	default:
+		fmt.Fprintln(os.Stderr, loc.T("--round must be %s, %s or %s", utils.ROUND_TRUNCATE, utils.ROUND_NEAREST, utils.ROUND_CONVERGENT))
+		os.Exit(exitUsage)
+	}
+
+	// Kiểm tra tuỳ chọn trình bày số
+	if presentation.Prefix != "" && presentation.Prefix != utils.PREFIX_C && presentation.Prefix != utils.PREFIX_SUBSCRIPT {
+		fmt.Fprintln(os.Stderr, loc.T("--prefix must be %s or %s", utils.PREFIX_C, utils.PREFIX_SUBSCRIPT))
+		os.Exit(exitUsage)
+	}
+	if presentation.Pad < 0 || presentation.Group < 0 {
+		fmt.Fprintln(os.Stderr, loc.T("--pad and --group must not be negative"))
+		os.Exit(exitUsage)
+	}
+
+	// Kiểm tra phương pháp giải từng bước
+	switch method {
+	case "", utils.METHOD_GROUPING, utils.METHOD_DECIMAL, utils.METHOD_HORNER, utils.BORROW, utils.COMPLEMENT:
+	default:
+		fmt.Fprintln(os.Stderr, loc.T("--method must be %s, %s, %s, %s or %s",
+			utils.METHOD_GROUPING, utils.METHOD_DECIMAL, utils.METHOD_HORNER, utils.BORROW, utils.COMPLEMENT))
+		os.Exit(exitUsage)
+	}
+
+	// Kiểm tra tuỳ chọn thứ tự byte
+	switch endian {
+	case "", utils.BIG_ENDIAN, utils.LITTLE_ENDIAN, utils.BOTH_ENDIAN:
+	default:
+		fmt.Fprintln(os.Stderr, loc.T("--endian must be %s, %s or %s", utils.LITTLE_ENDIAN, utils.BIG_ENDIAN, utils.BOTH_ENDIAN))
+		os.Exit(exitUsage)
+	}
+	if word != 0 && !slices.Contains(utils.WordSizes, word) {
+		fmt.Fprintln(os.Stderr, loc.T("--word must be 1, 2, 4 or 8"))
+		os.Exit(exitUsage)
+	}
+	if word == 0 {
+		word = 1
+	} else if endian == "" {
+		endian = utils.BIG_ENDIAN // chỉ có --word: xếp theo big-endian
+	}
+
+	// Phép toán bit: ncalc bit X OP Y
+	if isBitCommand(flag.Args()) {
+		runBit(flag.Args())
+		return
+	}
+
 	// Kiểm tra nếu có file đầu vào
 	if inputFile != "" {
 		processInputFile()
@@ -276,10 +591,19 @@ func main() {
 		flag.Usage() // usage and EXIT
 	}
 
-	arg := flag.Args()[0] // extract arg
+	arg := strings.Join(flag.Args(), " ") // extract arg: several args form one space-separated sequence
+
+	// Biểu thức số học: ncalc -x "0x1F * 3 + 0b101"
+	if expressionMode {
+		evaluate(arg)
+		return
+	}
 
 	if len(inputFormat) < 1 {
 		setDefaultInputFormat(arg)
+		if !quiet {
+			noteAmbiguity(arg)
+		}
 	}
 
 	// Kiểm tra xem có cần xuất ra file Excel không
@@ -294,30 +618,251 @@ func main() {
 		return
 	}
 
+	// Không chọn một định dạng cụ thể: bỏ qua các định dạng không biểu diễn được giá trị,
+	// ví dụ ascii của 3.5 hay roman của 5000; chỉ báo lỗi khi không định dạng nào được
+	explicit := explicitOutput()
+	var firstErr error
+	printed := false
+
 	buffer := bufio.NewWriter(os.Stdout)
 	defer buffer.Flush()
+	defer func() {
+		if !printed && firstErr != nil {
+			buffer.Flush()
+			fail(firstErr)
+		}
+	}()
 	for _, o := range outputFormat {
-		fn := funcMap[string(inputFormat[0])+"|"+string(o)]
-		result := utils.Invoke(fn, arg)
-		if !quiet {
-			fmt.Fprintf(buffer, "%v: %v\n", bold(o), result)
+		result, err := convert(arg, inputFormat[0], o)
+		if err != nil {
+			err = i18n.Errorf("error converting %s to %s: %w", inputFormat[0], o, err)
+			if !explicit {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			buffer.Flush()
+			fail(err)
+		}
+		printed = true
+		labels, results := []string{o}, []string{result}
+		if endian != "" && (o == utils.HEXADECIMAL || o == utils.BINARY) {
+			buffer.Flush()
+			labels, results = byteOrder(o, result)
+		}
+		for i, result := range results {
+			if base, ok := utils.BaseOf(o); ok {
+				result = presentation.Present(result, base)
+			}
+			if !quiet {
+				fmt.Fprintf(buffer, "%v: %v\n", bold(labels[i]), result)
+			} else {
+				fmt.Fprintf(buffer, "%v\n", result)
+			}
+		}
+	}
+}
+
+// byteOrder xếp các byte của kết quả hệ 16 hoặc hệ 2 thành các word theo --endian và --word;
+// kết quả không có dạng byte thì được giữ nguyên kèm một cảnh báo
+func byteOrder(o, result string) ([]string, []string) {
+	base, _ := utils.BaseOf(o)
+	unchanged := func(reason string) ([]string, []string) {
+		fmt.Fprintln(os.Stderr, loc.T("warning: %s: %s; byte order not applied", o, reason))
+		return []string{o}, []string{result}
+	}
+
+	var bytes []byte
+	fields := strings.Fields(result)
+	switch {
+	case strings.Contains(result, "."):
+		return unchanged(loc.T("a fractional value has no byte representation"))
+	case strings.HasPrefix(result, "-"):
+		return unchanged(loc.T("a negative value has no byte representation without --width"))
+	case len(fields) == 1:
+		b, err := utils.BytesOf(result, base)
+		if err != nil {
+			return unchanged(loc.Error(err))
+		}
+		bytes = b
+	default:
+		// một dãy mã, mỗi mã là một byte, ví dụ 48 65 6c 6c 6f
+		for _, f := range fields {
+			b, err := utils.BytesOf(f, base)
+			if err != nil || len(b) > 1 {
+				return unchanged(loc.T("a sequence of values wider than one byte"))
+			}
+			bytes = append(bytes, b...)
+		}
+	}
+
+	if !utils.Aligned(bytes, word) {
+		padded := (len(bytes) + word - 1) / word * word
+		fmt.Fprintln(os.Stderr, loc.T("warning: %d bytes is not a whole number of %d-byte words; padded to %d bytes", len(bytes), word, padded))
+	}
+
+	switch endian {
+	case utils.BOTH_ENDIAN:
+		return []string{o + " (big-endian)", o + " (little-endian)"},
+			[]string{utils.Words(bytes, word, utils.BIG_ENDIAN, base), utils.Words(bytes, word, utils.LITTLE_ENDIAN, base)}
+	default:
+		return []string{o}, []string{utils.Words(bytes, word, endian, base)}
+	}
+}
+
+// printInteger in số nguyên i ở các định dạng đầu ra; w > 0 là độ rộng bit của kết quả
+func printInteger(i *big.Int, w int) {
+	// Không chọn một định dạng cụ thể: bỏ qua các định dạng không biểu diễn được kết quả, ví dụ ascii của 200
+	explicit := explicitOutput()
+
+	buffer := bufio.NewWriter(os.Stdout)
+	defer buffer.Flush()
+	for _, o := range outputFormat {
+		var s string
+		var err error
+		base, ok := utils.BaseOf(o)
+		switch {
+		case ok && w > 0 && base != utils.DECIMAL_BASE && i.Sign() >= 0:
+			s = signed.Pad(i, w, base)
+		default:
+			s, err = format.Convert(i.String(), utils.DECIMAL, o, options())
+			if err != nil {
+				if !explicit {
+					continue
+				}
+				buffer.Flush()
+				fail(i18n.Errorf("error converting result to %s: %w", o, err))
+			}
+		}
+		if ok {
+			s = presentation.Present(s, base)
+		}
+		printLine(buffer, o, s)
+
+		// kết quả có bit dấu: in thêm giá trị bù hai
+		if o == utils.DECIMAL && w > 0 && i.Bit(w-1) == 1 {
+			printLine(buffer, loc.T("%s (%d-bit signed)", o, w), bitwise.Signed(i, w).String())
+		}
+	}
+}
+
+// explicitOutput cho biết -o có chọn một định dạng cụ thể hay không; khi không có -o hoặc có -o all,
+// các định dạng không biểu diễn được giá trị được bỏ qua thay vì báo lỗi
+func explicitOutput() bool {
+	return flag.Lookup("output").Changed && len(outputFormat) == 1
+}
+
+// printLine in một dòng kết quả, kèm tên định dạng nếu không có -q
+func printLine(buffer *bufio.Writer, label, s string) {
+	if !quiet {
+		fmt.Fprintf(buffer, "%v: %v\n", bold(label), s)
+	} else {
+		fmt.Fprintf(buffer, "%v\n", s)
+	}
+}
+
+// evaluate tính biểu thức số học arg và in kết quả ở các định dạng đầu ra
+func evaluate(arg string) {
+	base := utils.DECIMAL_BASE
+	if len(inputFormat) > 0 {
+		b, ok := utils.BaseOf(inputFormat[0])
+		if !ok {
+			fmt.Fprintln(os.Stderr, loc.T("-x needs an integer input format, not %s", inputFormat[0]))
+			os.Exit(exitUsage)
+		}
+		base = b
+	}
+	if showSteps || excelFile != "" {
+		arithmeticSteps(arg, base)
+		return
+	}
+
+	i, err := expression.EvalE(arg, base)
+	if err != nil {
+		fail(i18n.Errorf("error evaluating %s: %w", arg, err))
+	}
+	printInteger(i, 0)
+}
+
+// arithmeticSteps giải từng bước phép toán "a op b" ở cơ số base, in ra màn hình hoặc xuất ra Excel
+func arithmeticSteps(arg string, base int) {
+	results, err := stepbystep.ArithmeticSteps(arg, base, options())
+	if err != nil {
+		fail(i18n.Errorf("error evaluating %s: %w", arg, err))
+	}
+	if results == nil {
+		fmt.Fprintln(os.Stderr, loc.T("step-by-step solutions support a single operation a + b, a - b, a * b or a / b"))
+		os.Exit(exitUsage)
+	}
+
+	if excelFile != "" {
+		var err error
+		if useLaTeX {
+			err = stepbystep.ExportToExcelWithLaTeX(results, excelFile, loc)
 		} else {
-			fmt.Fprintf(buffer, "%v\n", result)
+			err = stepbystep.ExportToExcel(results, excelFile, loc)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, loc.T("Error exporting Excel file: %v", err))
+			os.Exit(1)
+		}
+		fmt.Println(loc.T("Exported the solution to Excel file: %s", excelFile))
+		return
+	}
+
+	for i, result := range results {
+		if i > 0 {
+			fmt.Println()
+		}
+		for _, step := range result.Steps(loc) {
+			fmt.Println(step)
 		}
 	}
 }
 
+// exitCode ánh xạ lỗi sang mã thoát tương ứng
+func exitCode(err error) int {
+	var digitErr *utils.ErrInvalidDigit
+	var asciiErr *utils.ErrNotASCII
+	var encodingErr *utils.ErrInvalidEncoding
+	var codePointErr *utils.ErrInvalidCodePoint
+	var codeErr *utils.ErrInvalidCode
+	var nibbleErr *utils.ErrInvalidNibble
+	var lengthErr *utils.ErrInvalidLength
+	var paddingErr *utils.ErrInvalidPadding
+	var numeralErr *utils.ErrInvalidNumeral
+	var overflowErr *utils.ErrOverflow
+	var expressionErr *utils.ErrInvalidExpression
+	var numErr *strconv.NumError
+	switch {
+	case errors.As(err, &overflowErr):
+		return exitOverflow
+	case errors.As(err, &digitErr), errors.As(err, &asciiErr), errors.As(err, &numErr),
+		errors.As(err, &encodingErr), errors.As(err, &codePointErr), errors.As(err, &codeErr), errors.As(err, &nibbleErr),
+		errors.As(err, &lengthErr), errors.As(err, &paddingErr), errors.As(err, &numeralErr), errors.As(err, &expressionErr):
+		return exitInvalid
+	}
+	return exitError
+}
+
+// fail in lỗi ra stderr và thoát với mã tương ứng
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, loc.Error(err))
+	os.Exit(exitCode(err))
+}
+
 // processInputFile xử lý dữ liệu từ file đầu vào
 func processInputFile() {
 	// Đọc dữ liệu từ file
 	inputs, err := stepbystep.ReadInputFromTxt(inputFile)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Lỗi khi đọc file %s: %v\n", inputFile, err)
+		fmt.Fprintln(os.Stderr, loc.T("Error reading file %s: %v", inputFile, err))
 		os.Exit(1)
 	}
 	
 	if len(inputs) == 0 {
-		fmt.Fprintf(os.Stderr, "Không có dữ liệu đầu vào từ file %s\n", inputFile)
+		fmt.Fprintln(os.Stderr, loc.T("No input data in file %s", inputFile))
 		os.Exit(1)
 	}
 	
@@ -325,33 +870,47 @@ func processInputFile() {
 	var results []*stepbystep.StepByStepResult
 	
 	for _, input := range inputs {
-		fromBase := baseNameToFormat(input.FromBase)
-		toBase := baseNameToFormat(input.ToBase)
+		fromBase, err := baseNameToFormat(input.FromBase)
+		if err != nil {
+			fail(err)
+		}
+
+		// Phép toán a op b: giải ở cơ số đầu vào
+		if base, ok := utils.BaseOf(fromBase); ok && stepbystep.IsArithmetic(input.Input) {
+			steps, err := stepbystep.ArithmeticSteps(input.Input, base, options())
+			if err != nil {
+				fail(i18n.Errorf("error evaluating %s: %w", input.Input, err))
+			}
+			results = append(results, steps...)
+			continue
+		}
+		toBase, err := baseNameToFormat(input.ToBase)
+		if err != nil {
+			fail(err)
+		}
 		
 		if toBase == "all" {
 			// Nếu đầu ra là "all", thực hiện chuyển đổi sang tất cả các cơ số khác
 			possibleOutputs := []string{utils.BINARY, utils.OCTAL, utils.DECIMAL, utils.HEXADECIMAL}
 			for _, outBase := range possibleOutputs {
 				if outBase != fromBase && outBase != utils.ASCII {
-					key := fromBase + "|" + outBase
-					stepsFunc, exists := stepsFuncMap[key]
+					stepsFunc, exists := getStepsFunc(fromBase, outBase)
 					if exists {
-						result := stepsFunc(input.Input)
+						result := solveSteps(stepsFunc, input.Input, fromBase, outBase)
 						results = append(results, result)
 					}
 				}
 			}
 		} else {
 			// Nếu đầu ra là một cơ số cụ thể
-			key := fromBase + "|" + toBase
-			stepsFunc, exists := stepsFuncMap[key]
+			stepsFunc, exists := getStepsFunc(fromBase, toBase)
 			
 			if exists {
-				result := stepsFunc(input.Input)
+				result := solveSteps(stepsFunc, input.Input, fromBase, toBase)
 				results = append(results, result)
 			} else {
-				fmt.Fprintf(os.Stderr, "Không hỗ trợ chuyển đổi từ %s sang %s\n", 
-					input.FromBase, input.ToBase)
+				fmt.Fprintln(os.Stderr, loc.T("Conversion from %s to %s is not supported",
+					input.FromBase, input.ToBase))
 			}
 		}
 	}
@@ -362,96 +921,108 @@ func processInputFile() {
 			// Xuất ra file Excel
 			var err error
 			if useLaTeX {
-				err = stepbystep.ExportToExcelWithLaTeX(results, excelFile)
+				err = stepbystep.ExportToExcelWithLaTeX(results, excelFile, loc)
 			} else {
-				err = stepbystep.ExportToExcel(results, excelFile)
+				err = stepbystep.ExportToExcel(results, excelFile, loc)
 			}
 			
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Lỗi khi xuất file Excel: %v\n", err)
+				fmt.Fprintln(os.Stderr, loc.T("Error exporting Excel file: %v", err))
 				os.Exit(1)
 			}
-			fmt.Printf("Đã xuất kết quả ra file Excel: %s\n", excelFile)
+			fmt.Println(loc.T("Exported the results to Excel file: %s", excelFile))
 		} else {
 			// Hiển thị trên màn hình
 			for _, result := range results {
-				fmt.Printf("Chuyển đổi %s (cơ số %s) sang %s (cơ số %s):\n",
+				fmt.Println(loc.T("Convert %s (base %s) to %s (base %s):",
 					result.Input, stepbystep.FormatBaseName(result.InputBase),
-					result.Output, stepbystep.FormatBaseName(result.OutputBase))
+					result.Answer(loc), stepbystep.FormatBaseName(result.OutputBase)))
 				
-				for _, step := range result.Steps {
+				for _, step := range result.Steps(loc) {
 					fmt.Println(step)
 				}
 				fmt.Println()
 			}
 		}
 	} else {
-		fmt.Fprintln(os.Stderr, "Không có kết quả nào để xuất")
+		fmt.Fprintln(os.Stderr, loc.T("No results to export"))
 	}
 }
 
 // showStepByStep hiển thị giải pháp từng bước
 func showStepByStep(arg string) {
 	// Nếu định dạng đầu ra là "all", thực hiện tất cả các chuyển đổi
-	if len(outputFormat) == len(utils.ALL) {
-		for _, o := range outputFormat {
-			if o == inputFormat[0] || o == utils.ASCII {
-				continue // Bỏ qua chuyển đổi cùng định dạng hoặc ASCII
-			}
-			key := string(inputFormat[0]) + "|" + string(o)
-			stepsFunc, exists := stepsFuncMap[key]
-			if exists {
-				result := stepsFunc(arg)
-				fmt.Println(bold("Chuyển đổi từ " + inputFormat[0] + " sang " + o))
-				for _, step := range result.Steps {
-					fmt.Println(step)
-				}
-				fmt.Println()
+	if !explicitOutput() {
+		outputs, results := solveAllSteps(arg)
+		for i, result := range results {
+			fmt.Println(bold(loc.T("Conversion from %s to %s", inputFormat[0], outputs[i])))
+			for _, step := range result.Steps(loc) {
+				fmt.Println(step)
 			}
+			fmt.Println()
 		}
 		return
 	}
 
 	// Thực hiện chuyển đổi cụ thể
 	for _, o := range outputFormat {
-		key := string(inputFormat[0]) + "|" + string(o)
-		stepsFunc, exists := stepsFuncMap[key]
+		stepsFunc, exists := getStepsFunc(inputFormat[0], o)
 		if exists {
-			result := stepsFunc(arg)
-			for _, step := range result.Steps {
+			result := solveSteps(stepsFunc, arg, inputFormat[0], o)
+			for _, step := range result.Steps(loc) {
 				fmt.Println(step)
 			}
 		} else {
-			fmt.Fprintf(os.Stderr, "Không có giải pháp từng bước cho chuyển đổi từ %s sang %s\n", 
-				inputFormat[0], o)
+			fmt.Fprintln(os.Stderr, loc.T("No step-by-step solution for conversion from %s to %s",
+				inputFormat[0], o))
 		}
 	}
 }
 
+// solveAllSteps giải từng bước arg sang mọi định dạng đầu ra có lời giải, trừ chính định dạng đầu vào
+// và ASCII; các định dạng không biểu diễn được giá trị được bỏ qua, ví dụ roman của 0, và chỉ báo lỗi
+// khi không định dạng nào giải được
+func solveAllSteps(arg string) ([]string, []*stepbystep.StepByStepResult) {
+	var outputs []string
+	var results []*stepbystep.StepByStepResult
+	var firstErr error
+	for _, o := range outputFormat {
+		if o == inputFormat[0] || o == utils.ASCII {
+			continue // Bỏ qua chuyển đổi cùng định dạng hoặc ASCII
+		}
+		stepsFunc, exists := getStepsFunc(inputFormat[0], o)
+		if !exists {
+			continue
+		}
+		result, err := stepsFunc(arg)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = i18n.Errorf("error converting %s to %s: %w", inputFormat[0], o, err)
+			}
+			continue
+		}
+		outputs = append(outputs, o)
+		results = append(results, result)
+	}
+	if len(results) == 0 && firstErr != nil {
+		fail(firstErr)
+	}
+	return outputs, results
+}
+
 // exportToExcel xuất kết quả giải pháp từng bước ra file Excel
 func exportToExcel(arg string) {
 	var results []*stepbystep.StepByStepResult
 
 	// Nếu định dạng đầu ra là "all", thực hiện tất cả các chuyển đổi
-	if len(outputFormat) == len(utils.ALL) {
-		for _, o := range outputFormat {
-			if o == inputFormat[0] || o == utils.ASCII {
-				continue // Bỏ qua chuyển đổi cùng định dạng hoặc ASCII
-			}
-			key := string(inputFormat[0]) + "|" + string(o)
-			stepsFunc, exists := stepsFuncMap[key]
-			if exists {
-				result := stepsFunc(arg)
-				results = append(results, result)
-			}
-		}
+	if !explicitOutput() {
+		_, results = solveAllSteps(arg)
 	} else {
 		// Thực hiện chuyển đổi cụ thể
 		for _, o := range outputFormat {
-			key := string(inputFormat[0]) + "|" + string(o)
-			stepsFunc, exists := stepsFuncMap[key]
+			stepsFunc, exists := getStepsFunc(inputFormat[0], o)
 			if exists {
-				result := stepsFunc(arg)
+				result := solveSteps(stepsFunc, arg, inputFormat[0], o)
 				results = append(results, result)
 			}
 		}
@@ -461,17 +1032,17 @@ func exportToExcel(arg string) {
 	if len(results) > 0 {
 		var err error
 		if useLaTeX {
-			err = stepbystep.ExportToExcelWithLaTeX(results, excelFile)
+			err = stepbystep.ExportToExcelWithLaTeX(results, excelFile, loc)
 		} else {
-			err = stepbystep.ExportToExcel(results, excelFile)
+			err = stepbystep.ExportToExcel(results, excelFile, loc)
 		}
 		
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Lỗi khi xuất file Excel: %v\n", err)
+			fmt.Fprintln(os.Stderr, loc.T("Error exporting Excel file: %v", err))
 			os.Exit(1)
 		}
-		fmt.Printf("Đã xuất giải pháp ra file Excel: %s\n", excelFile)
+		fmt.Println(loc.T("Exported the solution to Excel file: %s", excelFile))
 	} else {
-		fmt.Fprintf(os.Stderr, "Không có giải pháp nào để xuất ra Excel\n")
+		fmt.Fprintln(os.Stderr, loc.T("No solutions to export to Excel"))
 	}
 }
@@ -0,0 +1,39 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/clarketm/ncalc/i18n"
+	flag "github.com/clarketm/pflag"
+)
+
+// Không có -o: ascii không biểu diễn được 300 nên bị bỏ qua, các định dạng khác vẫn được in
+func Example() {
+	os.Args = []string{"ncalc", "--lang", "en", "300"}
+	main()
+	// Output:
+	// binary: 100101100
+	// octal: 454
+	// decimal: 300
+	// hexadecimal: 12c
+	// roman: CCC
+}
+
+// Dòng trợ giúp của tuỳ chọn không nằm trong lời gọi T nên TestCatalogs không thấy, kiểm tra riêng ở đây
+func TestFlagUsageTranslated(t *testing.T) {
+	vi := i18n.New(i18n.VI)
+	flag.VisitAll(func(f *flag.Flag) {
+		if vi.T(f.Usage) == f.Usage {
+			t.Errorf("--%s: %q has no vi translation", f.Name, f.Usage)
+		}
+	})
+}
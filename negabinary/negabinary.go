@@ -0,0 +1,103 @@
+/*
+
+NEGABINARY
+
+*/
+
+package negabinary
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/clarketm/ncalc/decimal"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Base of negabinary: every integer, negative or not, is a sum of powers of -2
+const Base = -2
+
+// Decimal2Negabinary (s string) string - Decimal2NegabinaryE, but panics if it fails
+func Decimal2Negabinary(s string) string {
+	r, err := Decimal2NegabinaryE(s)
+	utils.CheckError(err, utils.DECIMAL, utils.NEGABINARY)
+	return r
+}
+
+// Decimal2NegabinaryE (s string) (string, error)
+func Decimal2NegabinaryE(s string) (string, error) {
+	i, err := decimal.ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return String(i), nil
+}
+
+// Negabinary2Decimal (s string) string - Negabinary2DecimalE, but panics if it fails
+func Negabinary2Decimal(s string) string {
+	r, err := Negabinary2DecimalE(s)
+	utils.CheckError(err, utils.NEGABINARY, utils.DECIMAL)
+	return r
+}
+
+// Negabinary2DecimalE (s string) (string, error)
+func Negabinary2DecimalE(s string) (string, error) {
+	i, err := ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return i.String(), nil
+}
+
+// Divide (n *big.Int) (q *big.Int, r int, corrected bool) - one division by -2
+// with the remainder kept at 0 or 1. A negative remainder is corrected by adding
+// 2 to it and 1 to the quotient.
+func Divide(n *big.Int) (*big.Int, int, bool) {
+	q, m := new(big.Int).QuoRem(n, big.NewInt(Base), new(big.Int))
+	if m.Sign() < 0 {
+		return q.Add(q, big.NewInt(1)), int(m.Int64()) - Base, true
+	}
+	return q, int(m.Int64()), false
+}
+
+// String (i *big.Int) string - i in base -2
+func String(i *big.Int) string {
+	if i.Sign() == 0 {
+		return "0"
+	}
+	var digits []byte
+	for n := new(big.Int).Set(i); n.Sign() != 0; {
+		var r int
+		n, r, _ = Divide(n)
+		digits = append(digits, byte('0'+r))
+	}
+	for l, r := 0, len(digits)-1; l < r; l, r = l+1, r-1 {
+		digits[l], digits[r] = digits[r], digits[l]
+	}
+	return string(digits)
+}
+
+// ValueOf (s string) *big.Int - ValueOfE, but panics if it fails
+func ValueOf(s string) *big.Int {
+	i, err := ValueOfE(s)
+	utils.CheckError(err, utils.STRING, utils.NEGABINARY)
+	return i
+}
+
+// ValueOfE (s string) (*big.Int, error) - the value of the base -2 digits s
+func ValueOfE(s string) (*big.Int, error) {
+	digits := strings.TrimSpace(s)
+	if digits == "" {
+		return nil, &strconv.NumError{Func: "ValueOf", Num: s, Err: strconv.ErrSyntax}
+	}
+	i := new(big.Int)
+	for pos, r := range []rune(digits) {
+		if r != '0' && r != '1' {
+			// negabinary has no sign: negative values are written with digits alone
+			return nil, &utils.ErrInvalidDigit{Pos: pos + 1, Rune: r, Base: Base}
+		}
+		i.Mul(i, big.NewInt(Base)).Add(i, big.NewInt(int64(r-'0')))
+	}
+	return i, nil
+}
@@ -0,0 +1,40 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package negabinary_test
+
+import (
+	"fmt"
+
+	"github.com/clarketm/ncalc/negabinary"
+)
+
+func Example() {
+
+	// NEGABINARY
+	fmt.Println(negabinary.Decimal2Negabinary("6"))
+	fmt.Println(negabinary.Decimal2Negabinary("-7"))
+	fmt.Println(negabinary.Negabinary2Decimal("11010"))
+	fmt.Println(negabinary.Negabinary2Decimal("1001"))
+
+	// Output:
+	// 11010
+	// 1001
+	// 6
+	// -7
+}
+
+func ExampleNegabinary2DecimalE() {
+
+	// NEGABINARY (no sign)
+	_, err := negabinary.Negabinary2DecimalE("-101")
+	fmt.Println(err)
+
+	// Output:
+	// invalid digit '-' at position 1 for base -2
+}
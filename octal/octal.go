@@ -8,48 +8,116 @@ package octal
 
 import (
 	"fmt"
-	"strconv"
+	"math/big"
 
 	"github.com/clarketm/ncalc/decimal"
 	"github.com/clarketm/ncalc/utils"
 )
 
-// Octal2Ascii (s string) string
+// Octal2Ascii (s string) string - Octal2AsciiE, but panics if it fails
 func Octal2Ascii(s string) string {
-	return fmt.Sprintf("%q", ValueOf(s))
+	r, err := Octal2AsciiE(s)
+	utils.CheckError(err, utils.OCTAL, utils.ASCII)
+	return r
 }
 
-// Octal2Binary (s string) string
+// Octal2AsciiE (s string) (string, error)
+func Octal2AsciiE(s string) (string, error) {
+	var codes []*big.Int
+	for _, f := range utils.Fields(s) {
+		i, err := ValueOfE(f)
+		if err != nil {
+			return "", err
+		}
+		codes = append(codes, i)
+	}
+	return utils.QuoteASCII(codes)
+}
+
+// Octal2Binary (s string) string - Octal2BinaryE, but panics if it fails
 func Octal2Binary(s string) string {
-	i, err := utils.Parse(s, utils.OCTAL_BASE)
+	r, err := Octal2BinaryE(s)
 	utils.CheckError(err, utils.OCTAL, utils.BINARY)
-	s = strconv.Itoa(int(i))
-	return decimal.Decimal2Binary(s)
+	return r
 }
 
-// Octal2Decimal (s string) string
-func Octal2Decimal(s string) string {
+// Octal2BinaryE (s string) (string, error)
+func Octal2BinaryE(s string) (string, error) {
+	if utils.IsFraction(s) {
+		return utils.ConvertFractionE(s, utils.OCTAL_BASE, utils.BINARY_BASE)
+	}
 	i, err := utils.Parse(s, utils.OCTAL_BASE)
+	if err != nil {
+		return "", err
+	}
+	return decimal.Decimal2BinaryE(i.String())
+}
+
+// Octal2Decimal (s string) string - Octal2DecimalE, but panics if it fails
+func Octal2Decimal(s string) string {
+	r, err := Octal2DecimalE(s)
 	utils.CheckError(err, utils.OCTAL, utils.DECIMAL)
-	return strconv.Itoa(int(i))
+	return r
 }
 
-// Octal2Hexadecimal (s string) string
-func Octal2Hexadecimal(s string) string {
+// Octal2DecimalE (s string) (string, error)
+func Octal2DecimalE(s string) (string, error) {
+	if utils.IsFraction(s) {
+		return utils.ConvertFractionE(s, utils.OCTAL_BASE, utils.DECIMAL_BASE)
+	}
 	i, err := utils.Parse(s, utils.OCTAL_BASE)
+	if err != nil {
+		return "", err
+	}
+	return i.String(), nil
+}
+
+// Octal2Hexadecimal (s string) string - Octal2HexadecimalE, but panics if it fails
+func Octal2Hexadecimal(s string) string {
+	r, err := Octal2HexadecimalE(s)
 	utils.CheckError(err, utils.OCTAL, utils.HEXADECIMAL)
-	s = strconv.Itoa(int(i))
-	return decimal.Decimal2Hexadecimal(s)
+	return r
 }
 
-// String (s string) string
+// Octal2HexadecimalE (s string) (string, error)
+func Octal2HexadecimalE(s string) (string, error) {
+	if utils.IsFraction(s) {
+		return utils.ConvertFractionE(s, utils.OCTAL_BASE, utils.HEXADECIMAL_BASE)
+	}
+	i, err := utils.Parse(s, utils.OCTAL_BASE)
+	if err != nil {
+		return "", err
+	}
+	return decimal.Decimal2HexadecimalE(i.String())
+}
+
+// String (s string) string - StringE, but panics if it fails
 func String(s string) string {
-	return fmt.Sprintf("%o", ValueOf(s))
+	r, err := StringE(s)
+	utils.CheckError(err, utils.STRING, utils.OCTAL)
+	return r
 }
 
-// ValueOf (s string) int
-func ValueOf(s string) int {
-	i, err := utils.Parse(s, utils.OCTAL_BASE)
+// StringE (s string) (string, error)
+func StringE(s string) (string, error) {
+	if utils.IsFraction(s) {
+		return utils.ConvertFractionE(s, utils.OCTAL_BASE, utils.OCTAL_BASE)
+	}
+	i, err := ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%o", i), nil
+}
+
+// ValueOf (s string) *big.Int - ValueOfE, but panics if it fails
+func ValueOf(s string) *big.Int {
+	i, err := ValueOfE(s)
 	utils.CheckError(err, utils.STRING, utils.OCTAL)
-	return int(i)
+	return i
+}
+
+// ValueOfE (s string) (*big.Int, error)
+func ValueOfE(s string) (*big.Int, error) {
+	return utils.Parse(s, utils.OCTAL_BASE)
 }
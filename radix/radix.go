@@ -0,0 +1,137 @@
+/*
+
+RADIX
+
+*/
+
+package radix
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/clarketm/ncalc/ascii"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Radix2Ascii (s string, base int) string - Radix2AsciiE, but panics if it fails
+func Radix2Ascii(s string, base int) string {
+	r, err := Radix2AsciiE(s, base)
+	utils.CheckError(err, utils.RadixFormat(base), utils.ASCII)
+	return r
+}
+
+// Radix2AsciiE (s string, base int) (string, error)
+func Radix2AsciiE(s string, base int) (string, error) {
+	var codes []*big.Int
+	for _, f := range utils.Fields(s) {
+		i, err := ValueOfE(f, base)
+		if err != nil {
+			return "", err
+		}
+		codes = append(codes, i)
+	}
+	return utils.QuoteASCII(codes)
+}
+
+// Radix2Decimal (s string, base int) string
+func Radix2Decimal(s string, base int) string {
+	return Convert(s, base, utils.DECIMAL_BASE)
+}
+
+// Radix2DecimalE (s string, base int) (string, error)
+func Radix2DecimalE(s string, base int) (string, error) {
+	return ConvertE(s, base, utils.DECIMAL_BASE)
+}
+
+// Decimal2Radix (s string, base int) string
+func Decimal2Radix(s string, base int) string {
+	return Convert(s, utils.DECIMAL_BASE, base)
+}
+
+// Decimal2RadixE (s string, base int) (string, error)
+func Decimal2RadixE(s string, base int) (string, error) {
+	return ConvertE(s, utils.DECIMAL_BASE, base)
+}
+
+// Ascii2Radix (s string, base int) string - Ascii2RadixE, but panics if it fails
+func Ascii2Radix(s string, base int) string {
+	r, err := Ascii2RadixE(s, base)
+	utils.CheckError(err, utils.ASCII, utils.RadixFormat(base))
+	return r
+}
+
+// Ascii2RadixE (s string, base int) (string, error)
+func Ascii2RadixE(s string, base int) (string, error) {
+	v, err := ascii.ValuesOfE(s)
+	if err != nil {
+		return "", err
+	}
+	out := make([]string, len(v))
+	for i, c := range v {
+		if out[i], err = Decimal2RadixE(strconv.Itoa(c), base); err != nil {
+			return "", err
+		}
+	}
+	return strings.Join(out, " "), nil
+}
+
+// Convert (s string, from, to int) string - ConvertE, but panics if it fails
+func Convert(s string, from, to int) string {
+	r, err := ConvertE(s, from, to)
+	utils.CheckError(err, utils.RadixFormat(from), utils.RadixFormat(to))
+	return r
+}
+
+// ConvertE (s string, from, to int) (string, error)
+func ConvertE(s string, from, to int) (string, error) {
+	if err := checkBase(to); err != nil {
+		return "", err
+	}
+	if utils.IsFraction(s) {
+		if err := checkBase(from); err != nil {
+			return "", err
+		}
+		return utils.ConvertFractionE(s, from, to)
+	}
+	i, err := ValueOfE(s, from)
+	if err != nil {
+		return "", err
+	}
+	return i.Text(to), nil
+}
+
+// String (s string, base int) string
+func String(s string, base int) string {
+	return Convert(s, base, base)
+}
+
+// StringE (s string, base int) (string, error)
+func StringE(s string, base int) (string, error) {
+	return ConvertE(s, base, base)
+}
+
+// ValueOf (s string, base int) *big.Int - ValueOfE, but panics if it fails
+func ValueOf(s string, base int) *big.Int {
+	i, err := ValueOfE(s, base)
+	utils.CheckError(err, utils.STRING, utils.RadixFormat(base))
+	return i
+}
+
+// ValueOfE (s string, base int) (*big.Int, error)
+func ValueOfE(s string, base int) (*big.Int, error) {
+	if err := checkBase(base); err != nil {
+		return nil, err
+	}
+	return utils.ParseRadix(s, base)
+}
+
+// checkBase (base int) error
+func checkBase(base int) error {
+	if base < utils.MIN_BASE || base > utils.MAX_BASE {
+		return i18n.Errorf("base %d out of range [%d, %d]", base, utils.MIN_BASE, utils.MAX_BASE)
+	}
+	return nil
+}
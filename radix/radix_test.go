@@ -0,0 +1,35 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package radix_test
+
+import (
+	"fmt"
+
+	"github.com/clarketm/ncalc/radix"
+)
+
+func Example() {
+
+	// RADIX
+	r := "202"
+	fmt.Println(radix.Radix2Ascii(r, 7))
+	fmt.Println(radix.Radix2Decimal(r, 7))
+	fmt.Println(radix.Decimal2Radix("100", 36))
+	fmt.Println(radix.Ascii2Radix("d", 3))
+	fmt.Println(radix.Convert(r, 7, 5))
+	fmt.Printf("%v:%T", radix.String(r, 7), radix.String(r, 7))
+
+	// Output:
+	// 'd'
+	// 100
+	// 2s
+	// 10201
+	// 400
+	// 202:string
+}
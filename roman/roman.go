@@ -0,0 +1,145 @@
+/*
+
+ROMAN
+
+*/
+
+package roman
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/clarketm/ncalc/decimal"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Numeral is one term of a Roman numeral: a single symbol, or a subtractive
+// pair such as CM where the smaller symbol is taken from the larger one.
+type Numeral struct {
+	Value  int
+	Symbol string
+}
+
+// Subtractive reports whether n is a pair like IV or CM
+func (n Numeral) Subtractive() bool {
+	return len(n.Symbol) == 2
+}
+
+// Numerals lists every term of canonical Roman numerals, largest first
+var Numerals = []Numeral{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"},
+	{100, "C"}, {90, "XC"}, {50, "L"}, {40, "XL"},
+	{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+}
+
+// Max is the largest value with a canonical Roman numeral
+const Max = 3999
+
+// symbols maps each Roman digit to its value
+var symbols = map[rune]int{'I': 1, 'V': 5, 'X': 10, 'L': 50, 'C': 100, 'D': 500, 'M': 1000}
+
+// Decimal2Roman (s string) string - Decimal2RomanE, but panics if it fails
+func Decimal2Roman(s string) string {
+	r, err := Decimal2RomanE(s)
+	utils.CheckError(err, utils.DECIMAL, utils.ROMAN)
+	return r
+}
+
+// Decimal2RomanE (s string) (string, error)
+func Decimal2RomanE(s string) (string, error) {
+	i, err := decimal.ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return StringE(i)
+}
+
+// Roman2Decimal (s string) string - Roman2DecimalE, but panics if it fails
+func Roman2Decimal(s string) string {
+	r, err := Roman2DecimalE(s)
+	utils.CheckError(err, utils.ROMAN, utils.DECIMAL)
+	return r
+}
+
+// Roman2DecimalE (s string) (string, error)
+func Roman2DecimalE(s string) (string, error) {
+	i, err := ValueOfE(s)
+	if err != nil {
+		return "", err
+	}
+	return i.String(), nil
+}
+
+// Terms (i int) []Numeral - the greedy split of i into Numerals, i.e. its canonical numeral
+func Terms(i int) []Numeral {
+	var terms []Numeral
+	for _, n := range Numerals {
+		for ; i >= n.Value; i -= n.Value {
+			terms = append(terms, n)
+		}
+	}
+	return terms
+}
+
+// StringE (i *big.Int) (string, error) - the canonical Roman numeral of i
+func StringE(i *big.Int) (string, error) {
+	if i.Sign() <= 0 || i.Cmp(big.NewInt(Max)) > 0 {
+		return "", &utils.ErrOverflow{Value: i.String(), Kind: i18n.M("Roman numerals"), Min: "1", Max: strconv.Itoa(Max)}
+	}
+	var sb strings.Builder
+	for _, n := range Terms(int(i.Int64())) {
+		sb.WriteString(n.Symbol)
+	}
+	return sb.String(), nil
+}
+
+// ValueOf (s string) *big.Int - ValueOfE, but panics if it fails
+func ValueOf(s string) *big.Int {
+	i, err := ValueOfE(s)
+	utils.CheckError(err, utils.STRING, utils.ROMAN)
+	return i
+}
+
+// ValueOfE (s string) (*big.Int, error) - the value of the canonical Roman numeral s (any case)
+func ValueOfE(s string) (*big.Int, error) {
+	numeral := strings.ToUpper(strings.TrimSpace(s))
+	if numeral == "" {
+		return nil, &utils.ErrInvalidNumeral{Numeral: numeral, Reason: i18n.M("empty")}
+	}
+
+	// đọc từ trái sang phải: ký hiệu nhỏ hơn ký hiệu đứng sau thì bị trừ
+	digits := []rune(numeral)
+	value := 0
+	for i, r := range digits {
+		v, ok := symbols[r]
+		if !ok {
+			return nil, &utils.ErrInvalidNumeral{Pos: i + 1, Numeral: s, Reason: i18n.M("%q is not a Roman digit", r)}
+		}
+		if i+1 < len(digits) && v < symbols[digits[i+1]] {
+			value -= v
+		} else {
+			value += v
+		}
+	}
+
+	// chỉ chấp nhận dạng chuẩn, ví dụ IV chứ không phải IIII hay IIV
+	canonical := ""
+	if value > 0 && value <= Max {
+		canonical, _ = StringE(big.NewInt(int64(value)))
+	}
+	if canonical != numeral {
+		pos := 1
+		for pos <= len(digits) && pos <= len(canonical) && digits[pos-1] == rune(canonical[pos-1]) {
+			pos++
+		}
+		reason := i18n.M("not a canonical numeral")
+		if canonical != "" {
+			reason = i18n.M("not canonical, %d is written %s", value, canonical)
+		}
+		return nil, &utils.ErrInvalidNumeral{Pos: pos, Numeral: s, Reason: reason}
+	}
+	return big.NewInt(int64(value)), nil
+}
@@ -0,0 +1,62 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package roman_test
+
+import (
+	"fmt"
+
+	"github.com/clarketm/ncalc/roman"
+)
+
+func Example() {
+
+	// ROMAN
+	fmt.Println(roman.Decimal2Roman("1994"))
+	fmt.Println(roman.Decimal2Roman("3999"))
+	fmt.Println(roman.Roman2Decimal("MCMXCIV"))
+	fmt.Println(roman.Roman2Decimal("xlii"))
+
+	// Output:
+	// MCMXCIV
+	// MMMCMXCIX
+	// 1994
+	// 42
+}
+
+func ExampleRoman2DecimalE() {
+
+	// ROMAN (not canonical, not a Roman digit)
+	_, err := roman.Roman2DecimalE("IIII")
+	fmt.Println(err)
+	_, err = roman.Roman2DecimalE("MCMXCZ")
+	fmt.Println(err)
+	_, err = roman.Roman2DecimalE("IC")
+	fmt.Println(err)
+	_, err = roman.Roman2DecimalE(" ")
+	fmt.Println(err)
+
+	// Output:
+	// invalid Roman numeral IIII at position 2: not canonical, 4 is written IV
+	// invalid Roman numeral MCMXCZ at position 6: 'Z' is not a Roman digit
+	// invalid Roman numeral IC at position 1: not canonical, 99 is written XCIX
+	// invalid Roman numeral: empty
+}
+
+func ExampleDecimal2RomanE() {
+
+	// ROMAN (out of range)
+	_, err := roman.Decimal2RomanE("4000")
+	fmt.Println(err)
+	_, err = roman.Decimal2RomanE("0")
+	fmt.Println(err)
+
+	// Output:
+	// overflow: 4000 is out of range for Roman numerals (range 1 to 3999)
+	// overflow: 0 is out of range for Roman numerals (range 1 to 3999)
+}
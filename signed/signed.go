@@ -0,0 +1,206 @@
+/*
+
+SIGNED
+
+*/
+
+package signed
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Decimal2Binary (s string, width int, rep string) string
+func Decimal2Binary(s string, width int, rep string) string {
+	return Convert(s, utils.DECIMAL_BASE, utils.BINARY_BASE, width, rep)
+}
+
+// Decimal2BinaryE (s string, width int, rep string) (string, error)
+func Decimal2BinaryE(s string, width int, rep string) (string, error) {
+	return ConvertE(s, utils.DECIMAL_BASE, utils.BINARY_BASE, width, rep)
+}
+
+// Decimal2Octal (s string, width int, rep string) string
+func Decimal2Octal(s string, width int, rep string) string {
+	return Convert(s, utils.DECIMAL_BASE, utils.OCTAL_BASE, width, rep)
+}
+
+// Decimal2OctalE (s string, width int, rep string) (string, error)
+func Decimal2OctalE(s string, width int, rep string) (string, error) {
+	return ConvertE(s, utils.DECIMAL_BASE, utils.OCTAL_BASE, width, rep)
+}
+
+// Decimal2Hexadecimal (s string, width int, rep string) string
+func Decimal2Hexadecimal(s string, width int, rep string) string {
+	return Convert(s, utils.DECIMAL_BASE, utils.HEXADECIMAL_BASE, width, rep)
+}
+
+// Decimal2HexadecimalE (s string, width int, rep string) (string, error)
+func Decimal2HexadecimalE(s string, width int, rep string) (string, error) {
+	return ConvertE(s, utils.DECIMAL_BASE, utils.HEXADECIMAL_BASE, width, rep)
+}
+
+// Binary2Decimal (s string, width int, rep string) string
+func Binary2Decimal(s string, width int, rep string) string {
+	return Convert(s, utils.BINARY_BASE, utils.DECIMAL_BASE, width, rep)
+}
+
+// Binary2DecimalE (s string, width int, rep string) (string, error)
+func Binary2DecimalE(s string, width int, rep string) (string, error) {
+	return ConvertE(s, utils.BINARY_BASE, utils.DECIMAL_BASE, width, rep)
+}
+
+// Octal2Decimal (s string, width int, rep string) string
+func Octal2Decimal(s string, width int, rep string) string {
+	return Convert(s, utils.OCTAL_BASE, utils.DECIMAL_BASE, width, rep)
+}
+
+// Octal2DecimalE (s string, width int, rep string) (string, error)
+func Octal2DecimalE(s string, width int, rep string) (string, error) {
+	return ConvertE(s, utils.OCTAL_BASE, utils.DECIMAL_BASE, width, rep)
+}
+
+// Hexadecimal2Decimal (s string, width int, rep string) string
+func Hexadecimal2Decimal(s string, width int, rep string) string {
+	return Convert(s, utils.HEXADECIMAL_BASE, utils.DECIMAL_BASE, width, rep)
+}
+
+// Hexadecimal2DecimalE (s string, width int, rep string) (string, error)
+func Hexadecimal2DecimalE(s string, width int, rep string) (string, error) {
+	return ConvertE(s, utils.HEXADECIMAL_BASE, utils.DECIMAL_BASE, width, rep)
+}
+
+// Convert (s string, from, to, width int, rep string) string - ConvertE, but panics if it fails
+func Convert(s string, from, to, width int, rep string) string {
+	r, err := ConvertE(s, from, to, width, rep)
+	utils.CheckError(err, utils.RadixFormat(from), utils.RadixFormat(to))
+	return r
+}
+
+// ConvertE (s string, from, to, width int, rep string) (string, error)
+func ConvertE(s string, from, to, width int, rep string) (string, error) {
+	var value, bits *big.Int
+
+	i, err := utils.ParseRadix(s, from)
+	if err != nil {
+		return "", err
+	}
+
+	// decimal input is a signed value; any other base is a raw bit pattern
+	if from == utils.DECIMAL_BASE {
+		value = i
+		bits, err = Encode(value, width, rep)
+	} else {
+		bits = i
+		value, err = Decode(bits, width, rep)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if to == utils.DECIMAL_BASE {
+		return value.String(), nil
+	}
+	return Pad(bits, width, to), nil
+}
+
+// Encode (i *big.Int, width int, rep string) (*big.Int, error)
+func Encode(i *big.Int, width int, rep string) (*big.Int, error) {
+	min, max, err := Range(width, rep)
+	if err != nil {
+		return nil, err
+	}
+	if i.Cmp(min) < 0 || i.Cmp(max) > 0 {
+		return nil, &utils.ErrOverflow{Value: i.String(), Width: width, Kind: i18n.M(Name(rep)), Min: min.String(), Max: max.String()}
+	}
+	if i.Sign() >= 0 {
+		return new(big.Int).Set(i), nil
+	}
+
+	abs := new(big.Int).Abs(i)
+	switch rep {
+	case utils.ONES_COMPLEMENT:
+		// invert every bit of the magnitude
+		return abs.Xor(abs, mask(width)), nil
+	case utils.SIGN_MAGNITUDE:
+		// set the sign bit on top of the magnitude
+		return abs.SetBit(abs, width-1, 1), nil
+	default:
+		// 2^width - |i|, i.e. invert and add one
+		return abs.Sub(new(big.Int).Lsh(big.NewInt(1), uint(width)), abs), nil
+	}
+}
+
+// Decode (bits *big.Int, width int, rep string) (*big.Int, error)
+func Decode(bits *big.Int, width int, rep string) (*big.Int, error) {
+	if _, _, err := Range(width, rep); err != nil {
+		return nil, err
+	}
+	if bits.Sign() < 0 || bits.BitLen() > width {
+		return nil, &utils.ErrOverflow{Value: "bit pattern " + bits.Text(utils.BINARY_BASE), Width: width}
+	}
+	if bits.Bit(width-1) == 0 {
+		return new(big.Int).Set(bits), nil
+	}
+
+	switch rep {
+	case utils.ONES_COMPLEMENT:
+		i := new(big.Int).Xor(bits, mask(width))
+		return i.Neg(i), nil
+	case utils.SIGN_MAGNITUDE:
+		i := new(big.Int).SetBit(bits, width-1, 0)
+		return i.Neg(i), nil
+	default:
+		return new(big.Int).Sub(bits, new(big.Int).Lsh(big.NewInt(1), uint(width))), nil
+	}
+}
+
+// Range (width int, rep string) (*big.Int, *big.Int, error)
+func Range(width int, rep string) (*big.Int, *big.Int, error) {
+	if rep != utils.TWOS_COMPLEMENT && rep != utils.ONES_COMPLEMENT && rep != utils.SIGN_MAGNITUDE {
+		return nil, nil, i18n.Errorf("unknown signed representation %q (want %s, %s or %s)",
+			rep, utils.TWOS_COMPLEMENT, utils.ONES_COMPLEMENT, utils.SIGN_MAGNITUDE)
+	}
+	if width < 2 {
+		return nil, nil, i18n.Errorf("invalid width %d: signed values need at least 2 bits", width)
+	}
+
+	max := new(big.Int).Lsh(big.NewInt(1), uint(width-1))
+	max.Sub(max, big.NewInt(1))
+	min := new(big.Int).Neg(max)
+	if rep == utils.TWOS_COMPLEMENT {
+		min.Sub(min, big.NewInt(1))
+	}
+	return min, max, nil
+}
+
+// Pad (bits *big.Int, width, base int) string
+func Pad(bits *big.Int, width, base int) string {
+	s := bits.Text(base)
+	if digits := len(mask(width).Text(base)); len(s) < digits {
+		s = strings.Repeat("0", digits-len(s)) + s
+	}
+	return s
+}
+
+// Name (rep string) string
+func Name(rep string) string {
+	switch rep {
+	case utils.ONES_COMPLEMENT:
+		return "one's complement"
+	case utils.SIGN_MAGNITUDE:
+		return "sign-magnitude"
+	default:
+		return "two's complement"
+	}
+}
+
+// mask (width int) *big.Int
+func mask(width int) *big.Int {
+	m := new(big.Int).Lsh(big.NewInt(1), uint(width))
+	return m.Sub(m, big.NewInt(1))
+}
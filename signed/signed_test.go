@@ -0,0 +1,50 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package signed_test
+
+import (
+	"fmt"
+
+	"github.com/clarketm/ncalc/signed"
+	"github.com/clarketm/ncalc/utils"
+)
+
+func Example() {
+
+	// SIGNED
+	d := "-5"
+	fmt.Println(signed.Decimal2Binary(d, 8, utils.TWOS_COMPLEMENT))
+	fmt.Println(signed.Decimal2Binary(d, 8, utils.ONES_COMPLEMENT))
+	fmt.Println(signed.Decimal2Binary(d, 8, utils.SIGN_MAGNITUDE))
+	fmt.Println(signed.Decimal2Octal(d, 8, utils.TWOS_COMPLEMENT))
+	fmt.Println(signed.Decimal2Hexadecimal(d, 16, utils.TWOS_COMPLEMENT))
+	fmt.Println(signed.Binary2Decimal("11111011", 8, utils.TWOS_COMPLEMENT))
+	fmt.Println(signed.Octal2Decimal("373", 8, utils.TWOS_COMPLEMENT))
+	fmt.Println(signed.Hexadecimal2Decimal("85", 8, utils.SIGN_MAGNITUDE))
+
+	// Output:
+	// 11111011
+	// 11111010
+	// 10000101
+	// 373
+	// fffb
+	// -5
+	// -5
+	// -5
+}
+
+func ExampleConvertE() {
+
+	// SIGNED (overflow)
+	_, err := signed.ConvertE("-200", utils.DECIMAL_BASE, utils.BINARY_BASE, 8, utils.TWOS_COMPLEMENT)
+	fmt.Println(err)
+
+	// Output:
+	// overflow: -200 does not fit in 8-bit two's complement (range -128 to 127)
+}
@@ -0,0 +1,545 @@
+package stepbystep
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// arithmeticOps là các phép toán có lời giải từng bước, theo ký hiệu
+var arithmeticOps = map[string]string{
+	"+": utils.ADDITION,
+	"-": utils.SUBTRACTION,
+	"*": utils.MULTIPLICATION,
+	"/": utils.DIVISION,
+}
+
+// ParseArithmetic tách biểu thức "a op b" (op là + - * /) thành hai toán hạng và phép toán
+func ParseArithmetic(s string) (a, op, b string, ok bool) {
+	s = strings.TrimSpace(s)
+	// bỏ qua ký tự đầu để không nhầm dấu của toán hạng đầu là phép trừ
+	for i := 1; i < len(s); i++ {
+		if _, isOp := arithmeticOps[s[i:i+1]]; isOp {
+			a, b = strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+			return a, s[i : i+1], b, a != "" && b != ""
+		}
+	}
+	return "", "", "", false
+}
+
+// IsArithmetic kiểm tra s có phải biểu thức "a op b" hay không
+func IsArithmetic(s string) bool {
+	_, _, _, ok := ParseArithmetic(s)
+	return ok
+}
+
+// ArithmeticSteps giải biểu thức "a op b" ở cơ số base; phép trừ được giải bằng cách
+// opts.Method chọn, hoặc bằng cả hai cách: mượn và cộng với số bù. s không phải biểu
+// thức "a op b" thì trả về nil; vị trí trong lỗi tính theo s
+func ArithmeticSteps(s string, base int, opts utils.Options) ([]*StepByStepResult, error) {
+	a, op, b, ok := ParseArithmetic(s)
+	if !ok {
+		return nil, nil
+	}
+	results, err := arithmeticSteps(a, op, b, base, opts)
+	if err != nil {
+		return nil, expressionPosition(err, s, a, op, b)
+	}
+	return results, nil
+}
+
+// arithmeticSteps giải "a op b" theo phép toán op
+func arithmeticSteps(a, op, b string, base int, opts utils.Options) ([]*StepByStepResult, error) {
+	// chỉ giải một phép toán: phần còn lại không được chứa phép toán nữa (trừ dấu ở đầu)
+	if _, next, _, ok := ParseArithmetic(b); ok {
+		return nil, &utils.ErrInvalidExpression{Pos: len(a) + 3 + strings.Index(b[1:], next) + 2,
+			Reason: i18n.M("step-by-step arithmetic supports a single operator")}
+	}
+
+	switch arithmeticOps[op] {
+	case utils.ADDITION:
+		return single(AddSteps(a, b, base))
+	case utils.SUBTRACTION:
+		// --method chọn một cách giải, mặc định giải bằng cả hai
+		if opts.Method == utils.BORROW || opts.Method == utils.COMPLEMENT {
+			return single(SubtractSteps(a, b, base, opts.Method))
+		}
+		borrow, err := SubtractSteps(a, b, base, utils.BORROW)
+		if err != nil {
+			return nil, err
+		}
+		complement, err := SubtractSteps(a, b, base, utils.COMPLEMENT)
+		if err != nil {
+			return nil, err
+		}
+		return []*StepByStepResult{borrow, complement}, nil
+	case utils.MULTIPLICATION:
+		return single(MultiplySteps(a, b, base))
+	default:
+		return single(DivideSteps(a, b, base))
+	}
+}
+
+// expressionPosition đổi vị trí trong lỗi từ biểu thức "a op b" sang vị trí trong s,
+// nơi a, op và b có thể cách nhau bởi số dấu cách bất kỳ
+func expressionPosition(err error, s, a, op, b string) error {
+	start := strings.Index(s, a)
+	opPos := start + len(a) + strings.Index(s[start+len(a):], op) + 1
+	bStart := strings.LastIndex(s, b)
+
+	position := func(pos int) int {
+		switch {
+		case pos <= len(a):
+			return start + pos
+		case pos == len(a)+2:
+			return opPos
+		}
+		return bStart + pos - (len(a) + 3)
+	}
+
+	var digitErr *utils.ErrInvalidDigit
+	var expressionErr *utils.ErrInvalidExpression
+	switch {
+	case errors.As(err, &digitErr):
+		digitErr.Pos = position(digitErr.Pos)
+	case errors.As(err, &expressionErr):
+		expressionErr.Pos = position(expressionErr.Pos)
+	}
+	return err
+}
+
+// single gói một lời giải thành danh sách một phần tử
+func single(result *StepByStepResult, err error) ([]*StepByStepResult, error) {
+	if err != nil {
+		return nil, err
+	}
+	return []*StepByStepResult{result}, nil
+}
+
+// AddSteps cộng hai số ở cơ số base theo từng cột, ghi rõ số nhớ
+func AddSteps(a, b string, base int) (*StepByStepResult, error) {
+	x, y, err := arithmeticOperands(a, b, base)
+	if err != nil {
+		return nil, err
+	}
+	result := newArithmeticResult(x, "+", y, base, utils.ADDITION)
+
+	result.Add(&Note{Line: i18n.M("Method: Add the columns from the right. A column sum of %d or more writes its last base-%d digit and carries the rest to the next column.", base, base)})
+	if base > utils.DECIMAL_BASE {
+		result.Add(&Note{Line: i18n.M("Note: %s", digitNote(base, false))})
+	}
+	n := max(len(x), len(y))
+	result.Add(&Note{Line: i18n.M("Step 1: Line up the digits on the right: %s + %s", digitsText(padDigits(x, n), base), digitsText(padDigits(y, n), base))})
+	result.Add(&Note{Line: i18n.M("Step 2: Add each column with the carry from the previous one:")})
+	sum, columns := addColumns([][]int{x, y}, base)
+	result.Add(columns...)
+
+	result.Output = digitsText(sum, base)
+	result.Add(&Result{Value: result.Output, Base: base})
+	return result, nil
+}
+
+// SubtractSteps trừ hai số ở cơ số base bằng phương pháp mượn (utils.BORROW) hoặc
+// cộng với số bù cơ số (utils.COMPLEMENT), tức số bù hai ở hệ nhị phân
+func SubtractSteps(a, b string, base int, method string) (*StepByStepResult, error) {
+	x, y, err := arithmeticOperands(a, b, base)
+	if err != nil {
+		return nil, err
+	}
+	result := newArithmeticResult(x, "-", y, base, utils.BORROW)
+	if method == utils.COMPLEMENT {
+		result.Method = utils.COMPLEMENT
+	}
+
+	if method == utils.COMPLEMENT {
+		complementSteps(result, x, y, base)
+	} else {
+		borrowSteps(result, x, y, base)
+	}
+	result.Add(&Result{Value: result.Output, Base: base})
+	return result, nil
+}
+
+// borrowSteps trừ từng cột từ phải sang trái, mượn 1 từ cột bên trái khi chữ số trên nhỏ hơn
+func borrowSteps(result *StepByStepResult, x, y []int, base int) {
+	result.Add(&Note{Line: i18n.M("Method: Subtract the columns from the right. When the top digit is too small, borrow 1 from the next column, which adds %d to this one.", base)})
+	if base > utils.DECIMAL_BASE {
+		result.Add(&Note{Line: i18n.M("Note: %s", digitNote(base, false))})
+	}
+
+	// số bị trừ nhỏ hơn thì đổi chỗ và đổi dấu kết quả
+	sign := ""
+	step := 1
+	if digitsValue(x, base).Cmp(digitsValue(y, base)) < 0 {
+		result.Add(&Note{Line: i18n.M("Step %d: %s is less than %s, so subtract the other way round and make the result negative.", step, digitsText(x, base), digitsText(y, base))})
+		x, y, sign = y, x, "-"
+		step++
+	}
+
+	n := len(x)
+	y = padDigits(y, n)
+	result.Add(&Note{Line: i18n.M("Step %d: Line up the digits on the right: %s - %s", step, digitsText(x, base), digitsText(y, base))})
+	result.Add(&Note{Line: i18n.M("Step %d: Subtract each column, taking away the borrow from the previous one:", step+1)})
+
+	diff := make([]int, n)
+	borrow := 0
+	for i := n - 1; i >= 0; i-- {
+		column := n - i
+		d := x[i] - y[i] - borrow
+		if d >= 0 {
+			result.Add(&Equation{
+				Label:   i18n.M("Column %d", column),
+				Terms:   []Expr{Chain(Minus, Num(x[i]), Num(y[i]), Num(borrow)), Num(d)},
+				Comment: i18n.M("write %s, borrow 0", radixDigit(int64(d), base)),
+			})
+			diff[i], borrow = d, 0
+			continue
+		}
+		d += base
+		result.Add(&Equation{
+			Label:   i18n.M("Column %d", column),
+			Terms:   []Expr{Chain(Minus, Chain(Plus, Num(base), Num(x[i])), Num(y[i]), Num(borrow)), Num(d)},
+			Comment: i18n.M("borrow from the next column: write %s, borrow 1", radixDigit(int64(d), base)),
+		})
+		diff[i], borrow = d, 1
+	}
+
+	result.Output = sign + digitsText(trimDigits(diff), base)
+}
+
+// complementSteps trừ bằng cách cộng số bị trừ với số bù cơ số của số trừ
+func complementSteps(result *StepByStepResult, x, y []int, base int) {
+	name := complementName(base)
+	result.Add(&Note{Line: i18n.M("Method: Add the %s of the subtrahend instead of subtracting it. A carry out of the top column is dropped and means the result is positive; no carry means the result is negative.", name)})
+	if base > utils.DECIMAL_BASE {
+		result.Add(&Note{Line: i18n.M("Note: %s", digitNote(base, false))})
+	}
+
+	n := max(len(x), len(y))
+	x, y = padDigits(x, n), padDigits(y, n)
+	result.Add(&Note{Line: i18n.M("Step 1: Pad both numbers to %d digits: %s - %s", n, digitsText(x, base), digitsText(y, base))})
+
+	// số bù: thay mỗi chữ số d bằng base-1-d rồi cộng 1
+	inverted := complementDigits(y, base)
+	result.Add(&Note{Line: i18n.M("Step 2: Find the %s of %s:", name, digitsText(y, base))})
+	result.Add(&Detail{Line: i18n.M("Replace each digit d with %d - d: %s", base-1, digitsText(inverted, base))})
+	complement := addOne(inverted, base)
+	result.Add(&Equation{Label: i18n.M("Add 1"), Terms: []Expr{Chain(Plus, Lit(digitsText(inverted, base)), Num(1)), Lit(digitsText(complement, base))}})
+	if len(complement) > n {
+		// số bù của 0 là 0: số nhớ ra khỏi hàng cao nhất bị bỏ đi
+		complement = complement[1:]
+		result.Add(&Detail{Line: i18n.M("Drop the carry out of the top digit: %s", digitsText(complement, base))})
+	}
+
+	result.Add(&Note{Line: i18n.M("Step 3: Add %s + %s:", digitsText(x, base), digitsText(complement, base))})
+	sum, columns := addColumns([][]int{x, complement}, base)
+	result.Add(columns...)
+
+	if len(sum) > n {
+		result.Output = digitsText(trimDigits(sum[len(sum)-n:]), base)
+		result.Add(&Note{Line: i18n.M("Step 4: The sum has more than %d digits: drop the carry, the result is positive: %s", n, digitsText(sum[len(sum)-n:], base))})
+		return
+	}
+
+	// trừ 0 thì không có số nhớ nhưng kết quả chính là số bị trừ
+	if digitsValue(y, base).Sign() == 0 {
+		result.Output = digitsText(trimDigits(sum), base)
+		result.Add(&Note{Line: i18n.M("Step 4: The subtrahend is 0, so there is no carry to drop and the sum is the result: %s", digitsText(padDigits(sum, n), base))})
+		return
+	}
+
+	// không có số nhớ: kết quả âm, độ lớn là số bù của tổng
+	sum = padDigits(sum, n)
+	magnitude := addOne(complementDigits(sum, base), base)
+	result.Output = "-" + digitsText(trimDigits(magnitude), base)
+	result.Add(&Note{Line: i18n.M("Step 4: The sum has no carry beyond %d digits: the result is negative, and its size is the %s of %s:", n, name, digitsText(sum, base))})
+	result.Add(&Equation{
+		Label: i18n.M("Replace each digit d with %d - d and add 1", base-1),
+		Terms: []Expr{Chain(Plus, Lit(digitsText(complementDigits(sum, base), base)), Num(1)), Lit(digitsText(magnitude, base))},
+	})
+}
+
+// MultiplySteps nhân hai số ở cơ số base bằng cách dịch và cộng các tích riêng
+func MultiplySteps(a, b string, base int) (*StepByStepResult, error) {
+	x, y, err := arithmeticOperands(a, b, base)
+	if err != nil {
+		return nil, err
+	}
+	result := newArithmeticResult(x, "*", y, base, utils.MULTIPLICATION)
+
+	result.Add(&Note{Line: i18n.M("Method: Shift and add. Multiply the first number by each digit of the second, from the right, shifting each partial product one place further left; then add the partial products.")})
+	if base > utils.DECIMAL_BASE {
+		result.Add(&Note{Line: i18n.M("Note: %s", digitNote(base, false))})
+	}
+
+	result.Add(&Note{Line: i18n.M("Step 1: Partial products of %s:", digitsText(x, base))})
+	var partials [][]int
+	multiplicand := digitsValue(x, base)
+	for i := len(y) - 1; i >= 0; i-- {
+		shift := len(y) - 1 - i
+		product := new(big.Int).Mul(multiplicand, big.NewInt(int64(y[i])))
+		digits := append(valueDigits(product, base), make([]int, shift)...)
+		partials = append(partials, digits)
+		result.Add(&Equation{
+			Label:   i18n.M("Digit %d", shift+1),
+			Terms:   []Expr{Chain(Times, Lit(digitsText(x, base)), Lit(radixDigit(int64(y[i]), base))), Lit(valueText(product, base))},
+			Comment: i18n.M("shifted %d place(s): %s", shift, digitsText(digits, base)),
+		})
+	}
+
+	result.Add(&Note{Line: i18n.M("Step 2: Add the partial products column by column:")})
+	sum, columns := addColumns(partials, base)
+	result.Add(columns...)
+
+	result.Output = digitsText(sum, base)
+	result.Add(&Result{Value: result.Output, Base: base})
+	return result, nil
+}
+
+// DivideSteps chia hai số ở cơ số base bằng phép chia dài, hạ từng chữ số của số bị chia.
+// Output là thương, số dư nằm trong Result cuối cùng (xem Answer)
+func DivideSteps(a, b string, base int) (*StepByStepResult, error) {
+	x, y, err := arithmeticOperands(a, b, base)
+	if err != nil {
+		return nil, err
+	}
+	result := newArithmeticResult(x, "/", y, base, utils.DIVISION)
+	divisor := digitsValue(y, base)
+	if divisor.Sign() == 0 {
+		return nil, &utils.ErrInvalidExpression{Pos: len(a) + 2, Reason: i18n.M("division by zero")}
+	}
+
+	result.Add(&Note{Line: i18n.M("Method: Long division. Bring down the digits of %s one at a time; each time, the number of times %s fits is the next quotient digit and what is left carries on.", digitsText(x, base), digitsText(y, base))})
+	if base > utils.DECIMAL_BASE {
+		result.Add(&Note{Line: i18n.M("Note: %s", digitNote(base, false))})
+	}
+	result.Add(&Note{Line: i18n.M("Step 1: Divide digit by digit from the left:")})
+
+	quotient := make([]int, len(x))
+	remainder := new(big.Int)
+	b64 := big.NewInt(int64(base))
+	for i, d := range x {
+		remainder.Mul(remainder, b64).Add(remainder, big.NewInt(int64(d)))
+		current := new(big.Int).Set(remainder)
+		q, r := new(big.Int).QuoRem(remainder, divisor, new(big.Int))
+		quotient[i] = int(q.Int64())
+		remainder = r
+		result.Add(&LongDivision{
+			Digit:     radixDigit(int64(d), base),
+			Current:   valueText(current, base),
+			Divisor:   digitsText(y, base),
+			Quotient:  radixDigit(q.Int64(), base),
+			Remainder: valueText(r, base),
+		})
+	}
+
+	q := digitsText(trimDigits(quotient), base)
+	r := valueText(remainder, base)
+	result.Output = q
+	result.Add(&Note{Line: i18n.M("Step 2: Read the quotient digits from the top: %s, remainder %s", q, r)})
+	result.Add(&Equation{Label: i18n.M("Check"), Terms: []Expr{Chain(Plus, Chain(Times, Lit(q), Lit(digitsText(y, base))), Lit(r)), Lit(digitsText(x, base))}})
+	result.Add(&Result{Value: q, Base: base, Remainder: r})
+	return result, nil
+}
+
+// newArithmeticResult tạo kết quả cho phép toán "x op y" ở cơ số base, viết hai toán hạng bằng
+// chữ số của base; method là cách giải: utils.ADDITION, utils.BORROW, utils.COMPLEMENT,
+// utils.MULTIPLICATION hoặc utils.DIVISION
+func newArithmeticResult(x []int, op string, y []int, base int, method string) *StepByStepResult {
+	return &StepByStepResult{
+		Input:      digitsText(x, base) + " " + op + " " + digitsText(y, base),
+		InputBase:  utils.RadixFormat(base),
+		Output:     "",
+		OutputBase: utils.RadixFormat(base),
+		Method:     method,
+	}
+}
+
+// arithmeticOperands đọc hai toán hạng không âm ở cơ số base, hoặc viết kiểu 0x10, thành
+// các chữ số ở cơ số base; vị trí trong lỗi tính theo biểu thức "a op b"
+func arithmeticOperands(a, b string, base int) ([]int, []int, error) {
+	var operands [][]int
+	for k, s := range []string{a, b} {
+		offset := 0
+		if k == 1 {
+			offset = len(a) + 3
+		}
+		i, err := utils.Parse(s, base)
+		if err != nil {
+			var digitErr *utils.ErrInvalidDigit
+			if errors.As(err, &digitErr) {
+				digitErr.Pos += offset
+			}
+			return nil, nil, err
+		}
+		if i.Sign() < 0 {
+			return nil, nil, &utils.ErrInvalidExpression{Pos: offset + 1, Reason: i18n.M("%s is negative; step-by-step arithmetic works on non-negative numbers", s)}
+		}
+		operands = append(operands, valueDigits(i, base))
+	}
+	return operands[0], operands[1], nil
+}
+
+// addColumns cộng các số (chữ số hàng cao trước) theo từng cột từ phải sang trái,
+// trả về tổng và một phép tính cho mỗi cột
+func addColumns(rows [][]int, base int) ([]int, []Record) {
+	n := 0
+	for _, r := range rows {
+		n = max(n, len(r))
+	}
+
+	var columns []Record
+	sum := make([]int, n)
+	carry := 0
+	for i := 0; i < n; i++ {
+		terms := []Expr{}
+		total := carry
+		for _, r := range rows {
+			d := padDigits(r, n)[n-1-i]
+			terms = append(terms, Num(d))
+			total += d
+		}
+		terms = append(terms, Num(carry))
+
+		write, next := total%base, total/base
+		column := &Equation{
+			Label:   i18n.M("Column %d", i+1),
+			Terms:   []Expr{Chain(Plus, terms...), Num(total)},
+			Comment: i18n.M("write %s, carry %d", radixDigit(int64(write), base), next),
+		}
+		if next > 0 {
+			column.Terms = append(column.Terms, Chain(Plus, Chain(Times, Num(next), Num(base)), Num(write)))
+		}
+		columns = append(columns, column)
+		sum[n-1-i], carry = write, next
+	}
+
+	// số nhớ cuối cùng trở thành các chữ số đứng đầu
+	if carry > 0 {
+		digits := valueDigits(big.NewInt(int64(carry)), base)
+		columns = append(columns, &Detail{Line: i18n.M("The final carry %s is written in front", digitsText(digits, base))})
+		sum = append(digits, sum...)
+	}
+	return sum, columns
+}
+
+// complementName trả về tên số bù cơ số, ví dụ two's complement hoặc 10's complement
+func complementName(base int) i18n.Message {
+	if base == utils.BINARY_BASE {
+		return i18n.M("two's complement")
+	}
+	return i18n.M("%d's complement", base)
+}
+
+// complementDigits thay mỗi chữ số d bằng base-1-d
+func complementDigits(digits []int, base int) []int {
+	out := make([]int, len(digits))
+	for i, d := range digits {
+		out[i] = base - 1 - d
+	}
+	return out
+}
+
+// addOne cộng 1 vào các chữ số; số nhớ ra khỏi hàng cao nhất thành một chữ số mới
+func addOne(digits []int, base int) []int {
+	out := append([]int{}, digits...)
+	for i := len(out) - 1; i >= 0; i-- {
+		if out[i]++; out[i] < base {
+			return out
+		}
+		out[i] = 0
+	}
+	return append([]int{1}, out...)
+}
+
+// trimDigits bỏ các chữ số 0 ở đầu, giữ lại ít nhất một chữ số
+func trimDigits(digits []int) []int {
+	for len(digits) > 1 && digits[0] == 0 {
+		digits = digits[1:]
+	}
+	return digits
+}
+
+// padDigits thêm các chữ số 0 vào đầu cho đủ n chữ số
+func padDigits(digits []int, n int) []int {
+	if len(digits) >= n {
+		return digits
+	}
+	return append(make([]int, n-len(digits)), digits...)
+}
+
+// valueDigits trả về các chữ số của i ở cơ số base, hàng cao trước
+func valueDigits(i *big.Int, base int) []int {
+	var digits []int
+	for _, r := range i.Text(base) {
+		d, _ := utils.DigitValue(r, base)
+		digits = append(digits, d)
+	}
+	return digits
+}
+
+// digitsValue trả về giá trị của các chữ số ở cơ số base
+func digitsValue(digits []int, base int) *big.Int {
+	i := new(big.Int)
+	for _, d := range digits {
+		i.Mul(i, big.NewInt(int64(base))).Add(i, big.NewInt(int64(d)))
+	}
+	return i
+}
+
+// digitsText viết các chữ số thành chuỗi, giữ nguyên các số 0 ở đầu
+func digitsText(digits []int, base int) string {
+	var sb strings.Builder
+	for _, d := range digits {
+		sb.WriteString(radixDigit(int64(d), base))
+	}
+	return sb.String()
+}
+
+// valueText viết i ở cơ số base với cùng kiểu chữ số như các bước
+func valueText(i *big.Int, base int) string {
+	return digitsText(valueDigits(i, base), base)
+}
+
+// isArithmeticResult kiểm tra kết quả có phải lời giải một phép toán hay không
+func isArithmeticResult(result *StepByStepResult) bool {
+	switch result.Method {
+	case utils.ADDITION, utils.BORROW, utils.COMPLEMENT, utils.MULTIPLICATION, utils.DIVISION:
+		return true
+	}
+	return false
+}
+
+// arithmeticQuestion tạo câu hỏi cho một phép toán, ví dụ "Compute $1011_{2} + 110_{2}$ in binary."
+func arithmeticQuestion(result *StepByStepResult, l i18n.Localizer) string {
+	a, op, b, _ := ParseArithmetic(result.Input)
+	base, _ := utils.BaseOf(result.InputBase)
+	symbol := map[string]string{"+": "+", "-": "-", "*": "\\times", "/": "\\div"}[op]
+
+	how := ""
+	switch result.Method {
+	case utils.BORROW:
+		how = " " + l.T("by borrowing")
+	case utils.COMPLEMENT:
+		how = " " + l.T("by adding the %s", complementName(base))
+	case utils.MULTIPLICATION:
+		how = " " + l.T("by shift and add")
+	case utils.DIVISION:
+		how = " " + l.T("by long division")
+	}
+	return l.T("Compute $%s_{%d} %s %s_{%d}$ in %s%s.",
+		a, base, symbol, b, base, getReadableBaseName(result.OutputBase), how)
+}
+
+// arithmeticAnswer định dạng kết quả một phép toán; phép chia có thêm số dư
+func arithmeticAnswer(result *StepByStepResult, l i18n.Localizer) string {
+	base, _ := utils.BaseOf(result.OutputBase)
+	if r := result.answer(); r != nil && r.Remainder != "" {
+		return l.T("$%s_{%d}$ remainder $%s_{%d}$", r.Value, base, r.Remainder, base)
+	}
+	return fmt.Sprintf("$%s_{%d}$", result.Output, base)
+}
@@ -0,0 +1,100 @@
+package stepbystep
+
+import "fmt"
+
+// Expr là một vế của phép tính trong Equation hoặc Note: Text() viết bằng x, ÷, ^, XOR và ->
+// như trong văn bản, LaTeX() viết cùng biểu thức đó bằng ký hiệu toán học
+type Expr interface {
+	Text() string
+	LaTeX() string
+}
+
+// Lit là một số, một dãy bit hay một ký hiệu viết y nguyên ở cả hai dạng
+type Lit string
+
+func (s Lit) Text() string {
+	return string(s)
+}
+
+func (s Lit) LaTeX() string {
+	return string(s)
+}
+
+// Num trả về giá trị v viết y nguyên, ví dụ Num(42) hoặc Num(big.NewInt(7))
+func Num(v any) Lit {
+	return Lit(fmt.Sprint(v))
+}
+
+// Operator là một phép toán hai ngôi trong Operation
+type Operator int
+
+const (
+	Plus   Operator = iota // a + b
+	Minus                  // a - b
+	Times                  // a x b
+	Divide                 // a ÷ b
+	Xor                    // a XOR b
+	Maps                   // a -> b: chữ số, ký tự hay byte mà a được viết thành
+)
+
+// operatorSymbols là ký hiệu của mỗi phép toán ở dạng văn bản và LaTeX
+var operatorSymbols = [...][2]string{
+	Plus:   {"+", "+"},
+	Minus:  {"-", "-"},
+	Times:  {"x", "\\times"},
+	Divide: {"÷", "\\div"},
+	Xor:    {"XOR", "\\oplus"},
+	Maps:   {"->", "\\to"},
+}
+
+// Operation là phép tính Left Op Right
+type Operation struct {
+	Left  Expr
+	Op    Operator
+	Right Expr
+}
+
+func (o *Operation) Text() string {
+	return o.Left.Text() + " " + operatorSymbols[o.Op][0] + " " + o.Right.Text()
+}
+
+func (o *Operation) LaTeX() string {
+	return o.Left.LaTeX() + " " + operatorSymbols[o.Op][1] + " " + o.Right.LaTeX()
+}
+
+// Chain nối các vế terms bằng cùng một phép toán op, tính từ trái sang, ví dụ
+// Chain(Plus, a, b, c) là a + b + c
+func Chain(op Operator, terms ...Expr) Expr {
+	e := terms[0]
+	for _, t := range terms[1:] {
+		e = &Operation{Left: e, Op: op, Right: t}
+	}
+	return e
+}
+
+// Pow là lũy thừa Base^Exp, số mũ có thể âm
+type Pow struct {
+	Base int
+	Exp  int
+}
+
+func (p *Pow) Text() string {
+	return fmt.Sprintf("%d^%d", p.Base, p.Exp)
+}
+
+func (p *Pow) LaTeX() string {
+	return fmt.Sprintf("%d^{%d}", p.Base, p.Exp)
+}
+
+// Paren là biểu thức X đặt trong ngoặc đơn
+type Paren struct {
+	X Expr
+}
+
+func (p *Paren) Text() string {
+	return "(" + p.X.Text() + ")"
+}
+
+func (p *Paren) LaTeX() string {
+	return "(" + p.X.LaTeX() + ")"
+}
@@ -0,0 +1,115 @@
+package stepbystep
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/clarketm/ncalc/fixed"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Decimal2FixedSteps mã hoá số thập phân sang Qm.n: nhân với 2^n, làm tròn, kiểm tra phạm vi rồi viết ở dạng bù hai
+func Decimal2FixedSteps(s string, q fixed.Format, opts utils.Options) (*StepByStepResult, error) {
+	result := &StepByStepResult{
+		Input:      s,
+		InputBase:  utils.DECIMAL,
+		Output:     "",
+		OutputBase: q.Name(),
+		Method:     q.Name(),
+	}
+
+	r, err := utils.ParseFraction(s, utils.DECIMAL_BASE)
+	var rounding fixed.Rounding
+	if err == nil {
+		rounding, err = q.Encode(r, opts.Rounding, opts.Saturate)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Output = q.Describe(rounding.Bits)
+
+	decimal := func(r *big.Rat) string { return utils.FormatFraction(r, utils.DECIMAL_BASE, opts.Precision) }
+	min, max := q.Range()
+	result.Add(&Note{Line: i18n.M("Method: %s has %d integer bit(s), the sign bit included, and %d fraction bit(s). Multiply by 2^%d to move the binary point, round to an integer, keep it within the %d-bit signed range and write it as a %d-bit two's complement word.",
+		q.Title(), q.M, q.N, q.N, q.Width(), q.Width())})
+	result.Add(&Note{Line: i18n.M("Step 1: Scale by 2^%d = %s: %s × %s = %s", q.N, q.Scale(), s, q.Scale(), decimal(rounding.Scaled))})
+
+	if rounding.Scaled.IsInt() {
+		result.Add(&Note{Line: i18n.M("Step 2: %s is already an integer, no rounding needed", rounding.Raw)})
+	} else {
+		result.Add(&Note{Line: i18n.M("Step 2: Round to an integer (%s): %s -> %s", opts.Rounding, decimal(rounding.Scaled), rounding.Raw)})
+	}
+
+	if rounding.Saturated {
+		result.Add(&Note{Line: i18n.M("Step 3: %s is outside %s to %s, so it saturates to %s", rounding.Raw, min, max, rounding.Int)})
+	} else {
+		result.Add(&Note{Line: i18n.M("Step 3: %s is within %s to %s", rounding.Raw, min, max)})
+	}
+
+	if rounding.Int.Sign() < 0 {
+		result.Add(&Note{Line: i18n.M("Step 4: Negative, so add 2^%d: %s + %s = %s = %s",
+			q.Width(), rounding.Int, new(big.Int).Lsh(big.NewInt(1), uint(q.Width())), rounding.Bits, q.Pad(rounding.Bits, utils.BINARY_BASE))})
+	} else {
+		result.Add(&Note{Line: i18n.M("Step 4: In %d bits: %s = %s", q.Width(), rounding.Bits, q.Pad(rounding.Bits, utils.BINARY_BASE))})
+	}
+	result.Add(&Note{Line: i18n.M("Step 5: Group the bits by 4 for hexadecimal: %s = %s", nibbles(q.Pad(rounding.Bits, utils.BINARY_BASE)), result.Output)})
+
+	// Giá trị thực sự được lưu và sai số làm tròn
+	stored := q.Rat(rounding.Int)
+	result.Add(&Note{Line: i18n.M("Step 6: Stored value: %s / %s = %s (error %s)",
+		rounding.Int, q.Scale(), decimal(stored), decimal(new(big.Rat).Sub(stored, r)))})
+
+	result.Add(&Result{Value: result.Output})
+	return result, nil
+}
+
+// Fixed2RadixSteps đọc một từ Qm.n: lấy số nguyên bù hai rồi chia cho 2^n, sau đó đổi sang cơ số base
+func Fixed2RadixSteps(s string, q fixed.Format, base int, opts utils.Options) (*StepByStepResult, error) {
+	result := &StepByStepResult{
+		Input:      s,
+		InputBase:  q.Name(),
+		Output:     "",
+		OutputBase: utils.RadixFormat(base),
+		Method:     q.Name(),
+	}
+
+	bits, err := q.ParseBits(s)
+	if err != nil {
+		return nil, err
+	}
+	value := q.Rat(bits)
+	i := q.Int(bits)
+
+	result.Add(&Note{Line: i18n.M("Method: Read the word as a %d-bit two's complement integer, then divide by 2^%d to put the binary point back after %d integer bit(s).",
+		q.Width(), q.N, q.M)})
+	result.Add(&Note{Line: i18n.M("Step 1: Word in binary: %s", q.Pad(bits, utils.BINARY_BASE))})
+	if i.Sign() < 0 {
+		result.Add(&Note{Line: i18n.M("Step 2: The sign bit is 1, so subtract 2^%d: %s - %s = %s",
+			q.Width(), bits, new(big.Int).Lsh(big.NewInt(1), uint(q.Width())), i)})
+	} else {
+		result.Add(&Note{Line: i18n.M("Step 2: The sign bit is 0, so the integer is %s", i)})
+	}
+	decimal := utils.FormatFraction(value, utils.DECIMAL_BASE, opts.Precision)
+	result.Add(&Note{Line: i18n.M("Step 3: Divide by 2^%d = %s: %s / %s = %s", q.N, q.Scale(), i, q.Scale(), decimal)})
+
+	result.Output = decimal
+	// Đổi tiếp sang cơ số đích nếu không phải thập phân
+	if base != utils.DECIMAL_BASE {
+		result.Output = utils.FormatFraction(value, base, opts.Precision)
+		result.Add(&Note{Line: i18n.M("Step 4: Convert %s to base %d: %s", decimal, base, result.Output)})
+	}
+
+	result.Add(&Result{Value: result.Output, Base: base})
+	return result, nil
+}
+
+// nibbles chia chuỗi bit thành các nhóm 4 bit tính từ bên phải
+func nibbles(bits string) string {
+	var groups []string
+	for len(bits) > 4 {
+		groups = append([]string{bits[len(bits)-4:]}, groups...)
+		bits = bits[:len(bits)-4]
+	}
+	return strings.Join(append([]string{bits}, groups...), " ")
+}
@@ -0,0 +1,231 @@
+package stepbystep
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// FractionSteps chọn các bước chuyển đổi số có phần phân số giữa hai cơ số bất kỳ, với tối đa
+// precision chữ số sau dấu chấm
+func FractionSteps(s string, from, to, precision int) (*StepByStepResult, error) {
+	switch {
+	case from == utils.DECIMAL_BASE:
+		return Decimal2RadixFractionSteps(s, to, precision)
+	case to == utils.DECIMAL_BASE:
+		return Radix2DecimalFractionSteps(s, from, precision)
+	default:
+		return Radix2RadixFractionSteps(s, from, to, precision)
+	}
+}
+
+// Decimal2BinaryFractionSteps chuyển đổi số thập phân có phần phân số sang nhị phân
+func Decimal2BinaryFractionSteps(s string, precision int) (*StepByStepResult, error) {
+	return Decimal2RadixFractionSteps(s, utils.BINARY_BASE, precision)
+}
+
+// Decimal2OctalFractionSteps chuyển đổi số thập phân có phần phân số sang bát phân
+func Decimal2OctalFractionSteps(s string, precision int) (*StepByStepResult, error) {
+	return Decimal2RadixFractionSteps(s, utils.OCTAL_BASE, precision)
+}
+
+// Decimal2HexadecimalFractionSteps chuyển đổi số thập phân có phần phân số sang thập lục phân
+func Decimal2HexadecimalFractionSteps(s string, precision int) (*StepByStepResult, error) {
+	return Decimal2RadixFractionSteps(s, utils.HEXADECIMAL_BASE, precision)
+}
+
+// Binary2DecimalFractionSteps chuyển đổi số nhị phân có phần phân số sang thập phân
+func Binary2DecimalFractionSteps(s string, precision int) (*StepByStepResult, error) {
+	return Radix2DecimalFractionSteps(s, utils.BINARY_BASE, precision)
+}
+
+// Octal2DecimalFractionSteps chuyển đổi số bát phân có phần phân số sang thập phân
+func Octal2DecimalFractionSteps(s string, precision int) (*StepByStepResult, error) {
+	return Radix2DecimalFractionSteps(s, utils.OCTAL_BASE, precision)
+}
+
+// Hexadecimal2DecimalFractionSteps chuyển đổi số thập lục phân có phần phân số sang thập phân
+func Hexadecimal2DecimalFractionSteps(s string, precision int) (*StepByStepResult, error) {
+	return Radix2DecimalFractionSteps(s, utils.HEXADECIMAL_BASE, precision)
+}
+
+// Radix2DecimalFractionSteps chuyển đổi số có phần phân số ở cơ số base sang thập phân
+// bằng trọng số vị trí với số mũ âm
+func Radix2DecimalFractionSteps(s string, base, precision int) (*StepByStepResult, error) {
+	result := &StepByStepResult{
+		Input:      s,
+		InputBase:  utils.RadixFormat(base),
+		Output:     "",
+		OutputBase: utils.DECIMAL,
+		Method:     utils.FRACTION,
+	}
+
+	value, err := utils.ParseFraction(s, base)
+	if err != nil {
+		return nil, err
+	}
+
+	// Thêm tiêu đề và công thức tổng quát
+	result.Add(&Note{Line: i18n.M("Converting base-%d number %s to decimal:", base, s)})
+	result.Add(&Formula{Base: base, Fraction: true})
+	if base > utils.DECIMAL_BASE {
+		result.Add(&Note{Line: i18n.M("Note: %s", digitNote(base, false))})
+	}
+
+	digits := strings.TrimLeft(s, "+-")
+	if strings.HasPrefix(s, "-") {
+		result.Add(&Note{Line: i18n.M("Note: The number is negative, so convert %s and add the minus sign.", digits)})
+	}
+	result.Add(&Note{Line: i18n.M("For %s:", digits)})
+
+	// Số mũ của chữ số đầu tiên bằng số chữ số phần nguyên trừ 1
+	intPart, fracPart, _ := strings.Cut(digits, ".")
+	position := len(intPart) - 1
+
+	sum := new(big.Rat)
+	for _, digit := range intPart + fracPart {
+		d, _ := utils.DigitValue(digit, base)
+		digitValue := int64(d)
+
+		digitStr := string(digit)
+		if digitValue >= utils.DECIMAL_BASE {
+			digitStr += fmt.Sprintf(" (=%d)", digitValue)
+		}
+
+		var power *big.Rat
+		var powerStr string
+		if position >= 0 {
+			power = new(big.Rat).SetInt(bigPow(int64(base), position))
+			powerStr = power.Num().String()
+		} else {
+			power = new(big.Rat).SetFrac(big.NewInt(1), bigPow(int64(base), -position))
+			powerStr = power.String()
+		}
+
+		term := new(big.Rat).Mul(new(big.Rat).SetInt64(digitValue), power)
+		sum.Add(sum, term)
+
+		result.Add(&Equation{Terms: []Expr{
+			&Operation{Left: Lit(digitStr), Op: Times, Right: &Pow{Base: base, Exp: position}},
+			&Operation{Left: Num(digitValue), Op: Times, Right: Lit(powerStr)},
+			Lit(utils.FormatFraction(term, 10, precision)),
+		}})
+		position--
+	}
+
+	// Thêm tổng kết
+	result.Add(&Note{Line: i18n.M("Sum: %s", utils.FormatFraction(sum, 10, precision))})
+	result.Output = utils.FormatFraction(value, 10, precision)
+	result.Add(&Result{Value: result.Output, Base: utils.DECIMAL_BASE})
+
+	return result, nil
+}
+
+// Decimal2RadixFractionSteps chuyển đổi số thập phân có phần phân số sang cơ số base:
+// phần nguyên chia liên tiếp, phần phân số nhân liên tiếp với cơ số
+func Decimal2RadixFractionSteps(s string, base, precision int) (*StepByStepResult, error) {
+	result := &StepByStepResult{
+		Input:      s,
+		InputBase:  utils.DECIMAL,
+		Output:     "",
+		OutputBase: utils.RadixFormat(base),
+		Method:     utils.FRACTION,
+	}
+
+	value, err := utils.ParseFraction(s, utils.DECIMAL_BASE)
+	if err != nil {
+		return nil, err
+	}
+
+	rationalToRadixSteps(result, value, utils.FormatFraction(value, 10, precision), base, precision)
+	result.Output = utils.FormatFraction(value, base, precision)
+	result.Add(&Result{Value: result.Output, Base: base})
+
+	return result, nil
+}
+
+// Radix2RadixFractionSteps chuyển đổi số có phần phân số giữa hai cơ số bất kỳ thông qua thập phân
+func Radix2RadixFractionSteps(s string, from, to, precision int) (*StepByStepResult, error) {
+	result := &StepByStepResult{
+		Input:      s,
+		InputBase:  utils.RadixFormat(from),
+		Output:     "",
+		OutputBase: utils.RadixFormat(to),
+		Method:     utils.FRACTION,
+	}
+
+	value, err := utils.ParseFraction(s, from)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bước 1: Chuyển sang thập phân
+	decResult, err := Radix2DecimalFractionSteps(s, from, precision)
+	if err != nil {
+		return nil, err
+	}
+	result.Add(&Note{Line: i18n.M("Step 1: Convert base %d to decimal:", from)})
+	result.Add(decResult.Records...)
+
+	// Bước 2: Chuyển thập phân sang cơ số đích (dùng giá trị chính xác, không dùng chuỗi đã làm tròn)
+	result.Add(&Note{Line: i18n.M("Step 2: Convert decimal to base %d:", to)})
+	rationalToRadixSteps(result, value, decResult.Output, to, precision)
+
+	result.Output = utils.FormatFraction(value, to, precision)
+	result.Add(&Result{Value: result.Output, Base: to})
+
+	return result, nil
+}
+
+// rationalToRadixSteps ghi vào result các bước chuyển giá trị chính xác value (hiển thị là label) sang cơ số base
+func rationalToRadixSteps(result *StepByStepResult, value *big.Rat, label string, base, precision int) {
+	result.Add(&Note{Line: i18n.M("Method: Convert the integer part by repeated division by %d and the fractional part by repeated multiplication by %d.", base, base)})
+	if value.Sign() < 0 {
+		label = strings.TrimPrefix(label, "-")
+		result.Add(&Note{Line: i18n.M("Note: The number is negative, so convert %s and add the minus sign.", label)})
+	}
+
+	abs := new(big.Rat).Abs(value)
+	intPart, rem := new(big.Int).QuoRem(abs.Num(), abs.Denom(), new(big.Int))
+	frac := new(big.Rat).SetFrac(rem, abs.Denom())
+
+	// Bước 1: Phần nguyên
+	result.Add(&Note{Line: i18n.M("Step 1: Convert the integer part %d:", intPart)})
+	if intPart.Sign() == 0 {
+		result.Add(&Note{Line: i18n.M("The integer part is 0.")})
+	} else {
+		integer := newResult(intPart.String(), utils.DECIMAL, utils.RadixFormat(base))
+		divisionSteps(integer, intPart, base)
+		result.Add(integer.Records...)
+	}
+
+	// Bước 2: Phần phân số, nhân liên tiếp và ghi lại phần nguyên của tích
+	result.Add(&Note{Line: i18n.M("Step 2: Convert the fractional part %s by repeated multiplication by %d:",
+		utils.FormatFraction(frac, 10, precision), base)})
+
+	digits, repeat, truncated := utils.FractionDigits(rem, abs.Denom(), base, precision)
+	b := new(big.Rat).SetInt64(int64(base))
+	for _, d := range digits {
+		product := new(big.Rat).Mul(frac, b)
+		result.Add(&Note{Line: i18n.M("Digit %s: %s x %d = %s",
+			radixDigit(d, base), utils.FormatFraction(frac, 10, precision), base, utils.FormatFraction(product, 10, precision))})
+		frac = product.Sub(product, new(big.Rat).SetInt64(d))
+	}
+
+	switch {
+	case repeat >= 0:
+		var block string
+		for _, d := range digits[repeat:] {
+			block += radixDigit(d, base)
+		}
+		result.Add(&Note{Line: i18n.M("The fractional part %s appeared before (digit %d), so the digits %s repeat forever.",
+			utils.FormatFraction(frac, 10, precision), repeat+1, block)})
+	case truncated:
+		result.Add(&Note{Line: i18n.M("Stopped after %d digits (precision limit).", precision)})
+	default:
+		result.Add(&Note{Line: i18n.M("The fractional part is 0, so the expansion terminates.")})
+	}
+}
@@ -0,0 +1,155 @@
+package stepbystep
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// groupBits trả về số bit của một chữ số ở cơ số base nếu base là lũy thừa của 2
+func groupBits(base int) (int, bool) {
+	if base < utils.BINARY_BASE || base&(base-1) != 0 {
+		return 0, false
+	}
+	return bits.TrailingZeros(uint(base)), true
+}
+
+// isGrouping kiểm tra chuyển đổi giữa hai cơ số có giải được bằng cách nhóm bit hay không;
+// method là --method, và METHOD_DECIMAL buộc đi qua thập phân
+func isGrouping(from, to int, method string) bool {
+	_, fromOk := groupBits(from)
+	_, toOk := groupBits(to)
+	return fromOk && toOk && from != to && method != utils.METHOD_DECIMAL
+}
+
+// GroupingSteps chuyển đổi giữa hai cơ số là lũy thừa của 2 bằng cách nhóm bit:
+// mỗi chữ số ứng với đúng k bit, nên không cần qua thập phân; hai cơ số đều khác 2
+// (ví dụ bát phân và thập lục phân) thì đi qua nhị phân
+func GroupingSteps(s string, from, to int) (*StepByStepResult, error) {
+	sign, digits, _, err := radixDigits(s, from)
+	if err != nil {
+		return nil, err
+	}
+	result := newResult(s, utils.RadixFormat(from), utils.RadixFormat(to))
+	result.Method = utils.METHOD_GROUPING
+
+	fromBits, _ := groupBits(from)
+	toBits, _ := groupBits(to)
+	switch {
+	case from == utils.BINARY_BASE:
+		result.Add(&Note{Label: i18n.M("Method"), Line: i18n.M("Each %s digit stands for exactly %d bits, so group the bits in %s from the right and map each group to a digit.",
+			getReadableBaseName(result.OutputBase), toBits, groupName(toBits))})
+		if to > utils.DECIMAL_BASE {
+			result.Add(&Note{Label: i18n.M("Note"), Line: digitNote(to, true)})
+		}
+		result.Output, _ = groupSteps(result, digits, to, 1)
+	case to == utils.BINARY_BASE:
+		result.Add(&Note{Label: i18n.M("Method"), Line: i18n.M("Each %s digit stands for exactly %d bits, so write every digit as a %d-bit group and join the groups.",
+			getReadableBaseName(result.InputBase), fromBits, fromBits)})
+		if from > utils.DECIMAL_BASE {
+			result.Add(&Note{Label: i18n.M("Note"), Line: digitNote(from, false)})
+		}
+		result.Output, _ = expandSteps(result, digits, from, 1)
+	default:
+		result.Add(&Note{Label: i18n.M("Method"), Line: i18n.M("Go through binary. Write every %s digit as %d bits, then group the bits in %s from the right and map each group to a digit in %s.",
+			getReadableBaseName(result.InputBase), fromBits, groupName(toBits), getReadableBaseName(result.OutputBase))})
+		if max(from, to) > utils.DECIMAL_BASE {
+			result.Add(&Note{Label: i18n.M("Note"), Line: digitNote(max(from, to), from < to)})
+		}
+		b, step := expandSteps(result, digits, from, 1)
+		result.Output, _ = groupSteps(result, b, to, step)
+	}
+
+	result.Output = sign + result.Output
+	result.Add(&Result{Value: result.Output, Base: to})
+	return result, nil
+}
+
+// expandSteps viết mỗi chữ số ở cơ số base thành một nhóm bit rồi ghép lại, trả về
+// chuỗi bit (đã bỏ các số 0 ở đầu) và số thứ tự bước tiếp theo
+func expandSteps(result *StepByStepResult, digits string, base, step int) (string, int) {
+	k, _ := groupBits(base)
+	result.Add(&Note{Label: i18n.M("Step %d", step), Line: i18n.M("Write each digit of %s as %d bits:", digits, k)})
+
+	var groups []string
+	for _, r := range digits {
+		d, _ := utils.DigitValue(r, base)
+		group := fmt.Sprintf("%0*b", k, d)
+		groups = append(groups, group)
+		result.Add(&Grouping{Bits: group, Digit: radixDigit(int64(d), base), Value: int64(d), Expand: true})
+	}
+
+	b := strings.TrimLeft(strings.Join(groups, ""), "0")
+	if b == "" {
+		b = "0"
+	}
+	result.Add(&Note{Label: i18n.M("Step %d", step+1), Line: i18n.M("Join the groups and drop the leading zeros: %s = %s", strings.Join(groups, " "), b)})
+	return b, step + 2
+}
+
+// groupSteps nhóm chuỗi bit b thành các nhóm k bit từ bên phải và đổi mỗi nhóm thành
+// một chữ số ở cơ số base, trả về kết quả và số thứ tự bước tiếp theo
+func groupSteps(result *StepByStepResult, b string, base, step int) (string, int) {
+	k, _ := groupBits(base)
+	padded := b
+	if r := len(b) % k; r != 0 {
+		padded = strings.Repeat("0", k-r) + b
+	}
+	if padded != b {
+		result.Add(&Note{Label: i18n.M("Step %d", step), Line: i18n.M("Pad %s with leading zeros to a multiple of %d bits: %s", b, k, padded)})
+	} else {
+		result.Add(&Note{Label: i18n.M("Step %d", step), Line: i18n.M("%s already has a multiple of %d bits", b, k)})
+	}
+
+	var groups []string
+	for i := 0; i < len(padded); i += k {
+		groups = append(groups, padded[i:i+k])
+	}
+	result.Add(&Note{Label: i18n.M("Step %d", step+1), Line: i18n.M("Split into %d-bit groups: %s", k, strings.Join(groups, " "))})
+
+	result.Add(&Note{Label: i18n.M("Step %d", step+2), Line: i18n.M("Map each group to a digit in %s:", getReadableBaseName(utils.RadixFormat(base)))})
+	var out strings.Builder
+	for _, group := range groups {
+		d := int64(0)
+		for _, bit := range group {
+			d = d*2 + int64(bit-'0')
+		}
+		digit := radixDigit(d, base)
+		result.Add(&Grouping{Bits: group, Digit: digit, Value: d})
+		out.WriteString(digit)
+	}
+	return out.String(), step + 3
+}
+
+// weights viết nhóm bit thành tổng các trọng số, ví dụ 1011 thành 8 + 0 + 2 + 1
+func weights(group string) string {
+	terms := make([]string, len(group))
+	for i, bit := range group {
+		w := 0
+		if bit == '1' {
+			w = 1 << (len(group) - 1 - i)
+		}
+		terms[i] = fmt.Sprint(w)
+	}
+	return strings.Join(terms, " + ")
+}
+
+// groupName trả về tên của nhóm k bit, ví dụ threes hoặc fours
+func groupName(k int) i18n.Message {
+	switch k {
+	case 1:
+		return i18n.M("ones")
+	case 2:
+		return i18n.M("twos")
+	case 3:
+		return i18n.M("threes")
+	case 4:
+		return i18n.M("fours")
+	case 5:
+		return i18n.M("fives")
+	}
+	return i18n.M("groups of %d", k)
+}
@@ -0,0 +1,69 @@
+package stepbystep
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// HornerSteps chuyển đổi số ở cơ số base sang thập phân bằng sơ đồ Horner: đi từ trái
+// sang phải, mỗi bước nhân giá trị đang có với base rồi cộng chữ số tiếp theo
+func HornerSteps(s string, base int) (*StepByStepResult, error) {
+	sign, digits, values, err := radixDigits(s, base)
+	if err != nil {
+		return nil, err
+	}
+	result := newResult(s, utils.RadixFormat(base), utils.DECIMAL)
+	result.Method = utils.METHOD_HORNER
+
+	result.Add(&Note{Label: i18n.M("Method"), Line: i18n.M("Horner's scheme. Start from 0 and, for each digit from the left, multiply the running value by %d and add the digit.", base)})
+	if base > utils.DECIMAL_BASE {
+		result.Add(&Note{Label: i18n.M("Note"), Line: digitNote(base, false)})
+	}
+	result.Add(&Note{Label: i18n.M("Step %d", 1), Line: i18n.M("Write %s in nested form:", digits), Math: hornerNested(values, base)})
+	result.Add(&Note{Label: i18n.M("Step %d", 2), Line: i18n.M("Multiply and add from the left:")})
+
+	v := new(big.Int)
+	for i, d := range values {
+		step := &HornerStep{Index: i + 1, Digit: radixDigit(d, base), Value: d, Base: base, Prev: v}
+		v = new(big.Int).Mul(v, big.NewInt(int64(base)))
+		v.Add(v, big.NewInt(d))
+		step.Total = v
+		result.Add(step)
+	}
+
+	result.Output = sign + v.String()
+	result.Add(&Result{Value: result.Output, Base: utils.DECIMAL_BASE})
+	return result, nil
+}
+
+// hornerNested viết các chữ số ở dạng lồng nhau, ví dụ 1011 thành ((1 x 2 + 0) x 2 + 1) x 2 + 1
+func hornerNested(values []int64, base int) Expr {
+	var nested Expr = Num(values[0])
+	for i, d := range values[1:] {
+		if i > 0 {
+			nested = &Paren{nested}
+		}
+		nested = Chain(Plus, Chain(Times, nested, Num(base)), Num(d))
+	}
+	return nested
+}
+
+// hornerTableToLaTeX viết các bước nhân rồi cộng liên tiếp của sơ đồ Horner thành một bảng,
+// mỗi hàng là một bước
+func hornerTableToLaTeX(steps []*HornerStep) string {
+	var latex strings.Builder
+	latex.WriteString("\\begin{center}\n")
+	latex.WriteString("\\begin{tabular}{c|c|l}\n")
+	latex.WriteString(fmt.Sprintf("\\(i\\) & \\(d_i\\) & \\(v_i = v_{i-1} \\times %d + d_i\\) \\\\\n", steps[0].Base))
+	latex.WriteString("\\hline\n")
+	for _, step := range steps {
+		latex.WriteString(fmt.Sprintf("%d & %s & \\(%s \\times %d + %d = %s\\) \\\\\n", step.Index, step.Digit, step.Prev, step.Base, step.Value, step.Total))
+	}
+	latex.WriteString("\\end{tabular}\n")
+	latex.WriteString("\\end{center}\n")
+	return latex.String()
+}
@@ -0,0 +1,231 @@
+package stepbystep
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/ieee754"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Decimal2FloatSteps mã hoá số thập phân theo chuẩn IEEE 754 với các bước chuẩn hoá,
+// cộng độ lệch số mũ và làm tròn phần định trị
+func Decimal2FloatSteps(s string, f ieee754.Format, opts utils.Options) (*StepByStepResult, error) {
+	result := &StepByStepResult{
+		Input:      s,
+		InputBase:  utils.DECIMAL,
+		Output:     "",
+		OutputBase: f.Name,
+		Method:     f.Name,
+	}
+
+	bits, err := f.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Add(&Note{Line: i18n.M("Encode %s as IEEE 754 %s (%d bits: 1 sign, %d exponent, %d mantissa bits).",
+		s, i18n.M(f.Title), f.Width, f.Exponent, f.Mantissa)})
+
+	// Bước 1: Bit dấu
+	sign, _, _ := f.Fields(bits)
+	if sign == 1 {
+		result.Add(&Note{Line: i18n.M("Step 1: Sign: %s is negative, so the sign bit is 1.", s)})
+	} else {
+		result.Add(&Note{Line: i18n.M("Step 1: Sign: %s is not negative, so the sign bit is 0.", s)})
+	}
+
+	// Các giá trị đặc biệt (Inf, NaN) không cần chuẩn hoá
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		result.Add(&Note{Line: i18n.M("Step 2: %s is a special value: the exponent field is all ones (%s) and the mantissa is %s.",
+			f.Text(bits), strings.Repeat("1", f.Exponent), specialMantissa(f, bits))})
+		return finishFloatSteps(result, f, bits, 3)
+	}
+
+	abs := new(big.Rat).Abs(r)
+	if abs.Sign() == 0 {
+		result.Add(&Note{Line: i18n.M("Step 2: Zero is stored with an all-zero exponent and mantissa.")})
+		return finishFloatSteps(result, f, bits, 3)
+	}
+
+	rounding := f.Round(abs)
+
+	// Bước 2: Viết giá trị tuyệt đối ở hệ nhị phân
+	result.Add(&Note{Line: i18n.M("Step 2: Write %s in binary: %s",
+		utils.FormatFraction(abs, 10, opts.Precision), utils.FormatFraction(abs, 2, max(f.Mantissa-rounding.Exponent, 0)+3))})
+
+	// Bước 3: Chuẩn hoá về dạng 1.xxx x 2^e (hoặc 0.xxx x 2^emin với số dưới chuẩn)
+	normalized := new(big.Rat).Mul(abs, pow2Rat(-rounding.Exponent))
+	normalizedStr := utils.FormatFraction(normalized, 2, f.Mantissa+3)
+	if normalized.Cmp(big.NewRat(1, 1)) < 0 {
+		result.Add(&Note{Line: i18n.M("Step 3: Normalize: the exponent would be below the minimum %d, so the value is subnormal: %s x 2^%d",
+			f.MinExponent(), normalizedStr, rounding.Exponent)})
+	} else {
+		result.Add(&Note{Line: i18n.M("Step 3: Normalize: %s x 2^%d", normalizedStr, rounding.Exponent)})
+	}
+
+	// Bước 4: Làm tròn phần định trị về số bit cho phép
+	kept := fmt.Sprintf("%0*s", f.Mantissa+1, rounding.Significand.Text(2))
+	stored := kept[1:]
+	switch c := new(big.Rat).Mul(rounding.Remainder, big.NewRat(2, 1)).Cmp(big.NewRat(1, 1)); {
+	case rounding.Remainder.Sign() == 0:
+		result.Add(&Note{Line: i18n.M("Step 4: Round the mantissa to %d bits: %s, nothing is discarded so the value is exact.",
+			f.Mantissa, stored)})
+	case c < 0:
+		result.Add(&Note{Line: i18n.M("Step 4: Round the mantissa to %d bits: keep %s; the discarded part is less than half of the last place, so round down.",
+			f.Mantissa, stored)})
+	case c > 0:
+		result.Add(&Note{Line: i18n.M("Step 4: Round the mantissa to %d bits: keep %s; the discarded part is more than half of the last place, so round up.",
+			f.Mantissa, stored)})
+	case rounding.Up:
+		result.Add(&Note{Line: i18n.M("Step 4: Round the mantissa to %d bits: keep %s; the discarded part is exactly half and the last bit is 1, so round up to even.",
+			f.Mantissa, stored)})
+	default:
+		result.Add(&Note{Line: i18n.M("Step 4: Round the mantissa to %d bits: keep %s; the discarded part is exactly half and the last bit is 0, so round down to even.",
+			f.Mantissa, stored)})
+	}
+
+	_, exponent, mantissa := f.Fields(bits)
+	switch {
+	case rounding.Overflow:
+		result.Add(&Note{Line: i18n.M("The exponent exceeds the maximum %d, so the value overflows to %s.",
+			f.MaxExponent(), f.Text(bits))})
+	case rounding.Up && exponent != 0 && int(exponent)-f.Bias() != rounding.Exponent:
+		result.Add(&Note{Line: i18n.M("Rounding carried into the next power of two: mantissa %0*b, exponent %d.",
+			f.Mantissa, mantissa, int(exponent)-f.Bias())})
+	case rounding.Up:
+		result.Add(&Note{Line: i18n.M("Mantissa after rounding: %0*b", f.Mantissa, mantissa)})
+	}
+
+	// Bước 5: Cộng độ lệch cho số mũ
+	switch {
+	case rounding.Overflow:
+		result.Add(&Note{Line: i18n.M("Step 5: Infinity stores the exponent field %s.", strings.Repeat("1", f.Exponent))})
+	case exponent == 0:
+		result.Add(&Note{Line: i18n.M("Step 5: Subnormal values store the exponent field %0*b and use 2^%d.",
+			f.Exponent, 0, f.MinExponent())})
+	default:
+		result.Add(&Note{Line: i18n.M("Step 5: Bias the exponent: %d + %d = %d = %0*b",
+			int(exponent)-f.Bias(), f.Bias(), exponent, f.Exponent, exponent)})
+	}
+
+	return finishFloatSteps(result, f, bits, 6)
+}
+
+// Float2DecimalSteps giải mã mẫu bit IEEE 754 (ở cơ số base, 0 để tự nhận dạng) sang thập phân
+func Float2DecimalSteps(s string, base int, f ieee754.Format) (*StepByStepResult, error) {
+	result := &StepByStepResult{
+		Input:      s,
+		InputBase:  f.Name,
+		Output:     "",
+		OutputBase: utils.DECIMAL,
+		Method:     f.Name,
+	}
+	if base != 0 {
+		result.InputBase = utils.RadixFormat(base)
+	}
+
+	bits, err := f.ParseBits(s, base)
+	if err != nil {
+		return nil, err
+	}
+	sign, exponent, mantissa := f.Fields(bits)
+
+	// Bước 1 & 2: Viết các bit và tách các trường
+	result.Add(&Note{Line: i18n.M("Decode the IEEE 754 %s pattern %s (%d bits: 1 sign, %d exponent, %d mantissa bits).",
+		i18n.M(f.Title), s, f.Width, f.Exponent, f.Mantissa)})
+	result.Add(&Note{Line: i18n.M("Step 1: Write the %d bits: %s", f.Width, f.Pad(bits, utils.BINARY_BASE))})
+	result.Add(&Note{Line: i18n.M("Step 2: Split the fields: sign = %d, exponent = %0*b, mantissa = %0*b",
+		sign, f.Exponent, exponent, f.Mantissa, mantissa)})
+
+	// Bước 3: Bit dấu
+	if sign == 1 {
+		result.Add(&Note{Line: i18n.M("Step 3: The sign bit is 1, so the value is negative.")})
+	} else {
+		result.Add(&Note{Line: i18n.M("Step 3: The sign bit is 0, so the value is positive.")})
+	}
+
+	// Bước 4: Trường số mũ
+	value := f.Text(bits)
+	switch f.Class(bits) {
+	case "infinity", "nan":
+		result.Add(&Note{Line: i18n.M("Step 4: The exponent field is all ones and the mantissa is %s, so the value is %s.",
+			specialMantissa(f, bits), value)})
+		result.Output = value
+		result.Add(&Result{Value: result.Output})
+		return result, nil
+	case "zero":
+		result.Add(&Note{Line: i18n.M("Step 4: The exponent and mantissa fields are all zeros, so the value is zero.")})
+		result.Output = value
+		result.Add(&Result{Value: result.Output})
+		return result, nil
+	case "subnormal":
+		result.Add(&Note{Line: i18n.M("Step 4: The exponent field is 0, so the value is subnormal: exponent = 1 - %d = %d and there is no hidden 1.",
+			f.Bias(), f.MinExponent())})
+	default:
+		result.Add(&Note{Line: i18n.M("Step 4: Exponent field %0*b = %d, remove the bias: %d - %d = %d",
+			f.Exponent, exponent, exponent, exponent, f.Bias(), int(exponent)-f.Bias())})
+	}
+
+	// Bước 5: Phần định trị có bit ẩn
+	e := f.MinExponent()
+	significand := new(big.Rat).SetFrac(new(big.Int).SetUint64(mantissa), new(big.Int).Lsh(big.NewInt(1), uint(f.Mantissa)))
+	hidden := "0"
+	if exponent != 0 {
+		e = int(exponent) - f.Bias()
+		significand.Add(significand, big.NewRat(1, 1))
+		hidden = "1"
+	}
+	// Giá trị luôn là phân số hữu hạn ở hệ thập phân: đủ f.Mantissa - e chữ số
+	digits := f.Mantissa - e + 1
+	result.Add(&Note{Line: i18n.M("Step 5: Significand = %s.%0*b (binary) = %s",
+		hidden, f.Mantissa, mantissa, utils.FormatFraction(significand, 10, f.Mantissa+1))})
+
+	// Bước 6: Giá trị
+	signStr := "+"
+	if sign == 1 {
+		signStr = "-"
+	}
+	result.Add(&Note{Line: i18n.M("Step 6: Value = %s%s x 2^%d = %s",
+		signStr, utils.FormatFraction(significand, 10, f.Mantissa+1), e, utils.FormatFraction(f.Rat(bits), 10, max(digits, 1)))})
+	result.Add(&Note{Line: i18n.M("Step 7: The shortest decimal that reads back to the same bits is %s.", value)})
+
+	result.Output = value
+	result.Add(&Result{Value: result.Output})
+
+	return result, nil
+}
+
+// finishFloatSteps ghép các trường và viết mẫu bit ở dạng thập lục phân
+func finishFloatSteps(result *StepByStepResult, f ieee754.Format, bits uint64, step int) (*StepByStepResult, error) {
+	sign, exponent, mantissa := f.Fields(bits)
+	fields := fmt.Sprintf("%d %0*b %0*b", sign, f.Exponent, exponent, f.Mantissa, mantissa)
+
+	result.Add(&Note{Line: i18n.M("Step %d: Assemble sign | exponent | mantissa: %d | %0*b | %0*b",
+		step, sign, f.Exponent, exponent, f.Mantissa, mantissa)})
+	result.Add(&Note{Line: i18n.M("Step %d: In hexadecimal: 0x%s", step+1, strings.ToUpper(f.Pad(bits, utils.HEXADECIMAL_BASE)))})
+
+	result.Output = fields
+	result.Add(&Result{Value: result.Output})
+	return result, nil
+}
+
+// specialMantissa mô tả phần định trị của Inf/NaN
+func specialMantissa(f ieee754.Format, bits uint64) i18n.Message {
+	_, _, mantissa := f.Fields(bits)
+	if mantissa == 0 {
+		return i18n.M("zero")
+	}
+	return i18n.M("%0*b (non-zero)", f.Mantissa, mantissa)
+}
+
+// pow2Rat trả về 2^n dưới dạng số hữu tỉ
+func pow2Rat(n int) *big.Rat {
+	if n >= 0 {
+		return new(big.Rat).SetInt(bigPow(2, n))
+	}
+	return new(big.Rat).SetFrac(big.NewInt(1), bigPow(2, -n))
+}
@@ -0,0 +1,70 @@
+package stepbystep
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/clarketm/ncalc/i18n"
+)
+
+// LaTeX trả về lời giải LaTeX của result viết bằng ngôn ngữ của l, đúng như trong cột Solution khi xuất Excel
+func (result *StepByStepResult) LaTeX(l i18n.Localizer) string {
+	return convertRecordsToLaTeX(result, l)
+}
+
+// convertRecordsToLaTeX tạo lời giải LaTeX từ các bước có cấu trúc của result: các dòng
+// giải thích là \item, các phép tính nằm trong itemize ngay bên dưới và các bước của sơ
+// đồ Horner được gom thành một bảng
+func convertRecordsToLaTeX(result *StepByStepResult, l i18n.Localizer) string {
+	var latex strings.Builder
+	latex.WriteString("\\begin{enumerate}\n")
+
+	inList := false
+	endList := func() {
+		if inList {
+			latex.WriteString("\\end{itemize}\n")
+			inList = false
+		}
+	}
+	for i := 0; i < len(result.Records); i++ {
+		switch record := result.Records[i].(type) {
+		case *HornerStep:
+			endList()
+			steps := []*HornerStep{record}
+			for i+1 < len(result.Records) {
+				next, ok := result.Records[i+1].(*HornerStep)
+				if !ok {
+					break
+				}
+				steps = append(steps, next)
+				i++
+			}
+			latex.WriteString(hornerTableToLaTeX(steps))
+		case *Note, *Formula, *Result:
+			endList()
+			// Kết quả cuối cùng được ghi riêng bên dưới
+			if _, ok := record.(*Result); ok && i == len(result.Records)-1 {
+				continue
+			}
+			latex.WriteString("\\item " + record.LaTeX(l) + "\n")
+		default:
+			if !inList {
+				latex.WriteString("\\begin{itemize}\n")
+				inList = true
+			}
+			latex.WriteString("    \\item " + record.LaTeX(l) + "\n")
+		}
+	}
+	endList()
+
+	// Kết quả cuối cùng
+	latex.WriteString(finalAnswerToLaTeX(result, l))
+	latex.WriteString("\\end{enumerate}\n")
+
+	return latex.String()
+}
+
+// finalAnswerToLaTeX trả về dòng đáp án cuối cùng, căn giữa, của một lời giải LaTeX
+func finalAnswerToLaTeX(result *StepByStepResult, l i18n.Localizer) string {
+	return fmt.Sprintf("\\begin{center}\n\\textbf{%s:} %s\n\\end{center}\n", l.T("Final Answer"), formatOutputAnswer(result, l))
+}
@@ -0,0 +1,191 @@
+package stepbystep
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// namedSteps là các hàm giải từng bước riêng cho từng cặp cơ số có tên
+var namedSteps = map[[2]int]StepsFunc{
+	{utils.BINARY_BASE, utils.DECIMAL_BASE}:      Binary2DecimalSteps,
+	{utils.BINARY_BASE, utils.OCTAL_BASE}:        Binary2OctalSteps,
+	{utils.BINARY_BASE, utils.HEXADECIMAL_BASE}:  Binary2HexadecimalSteps,
+	{utils.OCTAL_BASE, utils.DECIMAL_BASE}:       Octal2DecimalSteps,
+	{utils.OCTAL_BASE, utils.BINARY_BASE}:        Octal2BinarySteps,
+	{utils.OCTAL_BASE, utils.HEXADECIMAL_BASE}:   Octal2HexadecimalSteps,
+	{utils.DECIMAL_BASE, utils.BINARY_BASE}:      Decimal2BinarySteps,
+	{utils.DECIMAL_BASE, utils.OCTAL_BASE}:       Decimal2OctalSteps,
+	{utils.DECIMAL_BASE, utils.HEXADECIMAL_BASE}: Decimal2HexadecimalSteps,
+	{utils.HEXADECIMAL_BASE, utils.DECIMAL_BASE}: Hexadecimal2DecimalSteps,
+	{utils.HEXADECIMAL_BASE, utils.BINARY_BASE}:  Hexadecimal2BinarySteps,
+	{utils.HEXADECIMAL_BASE, utils.OCTAL_BASE}:   Hexadecimal2OctalSteps,
+}
+
+// RadixSteps chọn phương pháp giải từng bước phù hợp cho chuyển đổi giữa hai cơ số bất kỳ;
+// method là --method
+func RadixSteps(s string, from, to int, method string) (*StepByStepResult, error) {
+	// Hai cơ số là lũy thừa của 2: nhóm bit thay vì đi qua thập phân
+	if isGrouping(from, to, method) {
+		return GroupingSteps(s, from, to)
+	}
+	// --method horner: đổi sang thập phân bằng sơ đồ Horner
+	if to == utils.DECIMAL_BASE && from != utils.DECIMAL_BASE && method == utils.METHOD_HORNER {
+		return HornerSteps(s, from)
+	}
+	if stepsFunc, ok := namedSteps[[2]int{from, to}]; ok {
+		return stepsFunc(s)
+	}
+
+	switch {
+	case from == utils.DECIMAL_BASE:
+		return Decimal2RadixSteps(s, to)
+	case to == utils.DECIMAL_BASE:
+		return Radix2DecimalSteps(s, from)
+	default:
+		return Radix2RadixSteps(s, from, to)
+	}
+}
+
+// Radix2DecimalSteps chuyển đổi số ở cơ số base sang thập phân với các bước chi tiết
+func Radix2DecimalSteps(s string, base int) (*StepByStepResult, error) {
+	result := newResult(s, utils.RadixFormat(base), utils.DECIMAL)
+	result.Add(&Note{Line: i18n.M("Converting base-%d number %s to decimal:", base, s)})
+	return result, powerSteps(result, s, base)
+}
+
+// Decimal2RadixSteps chuyển đổi số thập phân sang cơ số base với các bước chi tiết
+func Decimal2RadixSteps(s string, base int) (*StepByStepResult, error) {
+	n, err := utils.ParseRadix(s, utils.DECIMAL_BASE)
+	if err != nil {
+		return nil, err
+	}
+	result := newResult(s, utils.DECIMAL, utils.RadixFormat(base))
+	divisionSteps(result, n, base)
+	return result, nil
+}
+
+// Radix2RadixSteps chuyển đổi giữa hai cơ số bất kỳ thông qua thập phân
+func Radix2RadixSteps(s string, from, to int) (*StepByStepResult, error) {
+	result := newResult(s, utils.RadixFormat(from), utils.RadixFormat(to))
+	result.Add(&Note{Line: i18n.M("Converting base-%d number %s to base %d:", from, s, to)})
+	toDecimal := func(s string) (*StepByStepResult, error) { return Radix2DecimalSteps(s, from) }
+	fromDecimal := func(s string) (*StepByStepResult, error) { return Decimal2RadixSteps(s, to) }
+	return result, chainSteps(result, s, toDecimal, fromDecimal)
+}
+
+// powerSteps khai triển s ở cơ số base theo lũy thừa của base, ghi từng số hạng vào
+// result và đặt tổng của chúng làm kết quả; s không hợp lệ ở cơ số base thì trả về lỗi
+func powerSteps(result *StepByStepResult, s string, base int) error {
+	sign, digits, values, err := radixDigits(s, base)
+	if err != nil {
+		return err
+	}
+
+	result.Add(&Formula{Base: base})
+	if base > utils.DECIMAL_BASE {
+		result.Add(&Note{Label: i18n.M("Note"), Line: digitNote(base, false)})
+	}
+	if sign != "" {
+		result.Add(&Note{Label: i18n.M("Note"), Line: i18n.M("The number is negative, so convert %s and add the minus sign.", digits)})
+	}
+	result.Add(&Note{Line: i18n.M("For %s:", digits)})
+
+	sum := &Sum{Total: new(big.Int)}
+	for i, v := range values {
+		term := &PowerTerm{
+			Digit: radixDigit(v, base),
+			Value: v,
+			Base:  base,
+			Exp:   len(values) - i - 1,
+		}
+		term.Term = new(big.Int).Mul(big.NewInt(term.Value), term.Power())
+		result.Add(term)
+
+		sum.Terms = append(sum.Terms, term.Term)
+		sum.Total.Add(sum.Total, term.Term)
+	}
+	result.Add(sum)
+	result.Output = sign + sum.Total.String()
+	if sign != "" {
+		result.Add(&Result{Value: result.Output, Base: utils.DECIMAL_BASE})
+	}
+	return nil
+}
+
+// divisionSteps chia liên tiếp n cho base, ghi từng phép chia vào result và đặt các số dư
+// đọc từ dưới lên làm kết quả
+func divisionSteps(result *StepByStepResult, n *big.Int, base int) {
+	result.Add(&Note{Label: i18n.M("Method"), Line: i18n.M("Divide continuously by %d, note the remainders, read the result from bottom to top.", base)})
+	if base > utils.DECIMAL_BASE {
+		result.Add(&Note{Label: i18n.M("Note"), Line: digitNote(base, true)})
+	}
+
+	sign := ""
+	if n.Sign() < 0 {
+		sign = "-"
+		result.Add(&Note{Label: i18n.M("Note"), Line: i18n.M("The number is negative, so convert %s and add the minus sign.", new(big.Int).Abs(n))})
+	}
+
+	digits := ""
+	for temp := new(big.Int).Abs(n); temp.Sign() > 0; {
+		quotient, rem := new(big.Int).QuoRem(temp, big.NewInt(int64(base)), new(big.Int))
+		result.Add(&Division{Dividend: temp, Divisor: base, Quotient: quotient, Remainder: rem.Int64()})
+		digits = radixDigit(rem.Int64(), base) + digits
+		temp = quotient
+	}
+	if digits == "" {
+		digits = "0"
+	}
+
+	result.Output = sign + digits
+	result.Add(&Result{Value: result.Output, Base: base})
+}
+
+// radixDigits tách dấu, các chữ số và giá trị từng chữ số của s ở cơ số base
+func radixDigits(s string, base int) (string, string, []int64, error) {
+	if _, err := utils.ParseRadix(s, base); err != nil {
+		return "", "", nil, err
+	}
+	sign, digits := "", strings.TrimPrefix(s, "+")
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	var values []int64
+	for _, r := range digits {
+		d, _ := utils.DigitValue(r, base)
+		values = append(values, int64(d))
+	}
+	return sign, digits, values, nil
+}
+
+// radixDigit trả về ký tự biểu diễn chữ số v trong cơ số base
+func radixDigit(v int64, base int) string {
+	digit := big.NewInt(v).Text(base)
+	if base <= 36 {
+		digit = strings.ToUpper(digit)
+	}
+	return digit
+}
+
+// digitNote tạo ghi chú về giá trị của các chữ số lớn hơn 9
+func digitNote(base int, valueFirst bool) i18n.Message {
+	var notes []string
+	for v := int64(utils.DECIMAL_BASE); v < int64(base); v++ {
+		if base > 16 && v > 11 && v < int64(base)-1 {
+			if v == 12 {
+				notes = append(notes, "...")
+			}
+			continue
+		}
+		if valueFirst {
+			notes = append(notes, fmt.Sprintf("%d=%s", v, radixDigit(v, base)))
+		} else {
+			notes = append(notes, fmt.Sprintf("%s=%d", radixDigit(v, base), v))
+		}
+	}
+	return i18n.M(strings.Join(notes, ", "))
+}
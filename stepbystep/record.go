@@ -0,0 +1,336 @@
+package stepbystep
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Record là một bước giải có cấu trúc: dòng văn bản trong Steps và lời giải LaTeX
+// đều được tạo từ dữ liệu của nó thay vì phân tích lại câu chữ
+type Record interface {
+	Text(l i18n.Localizer) string  // Dòng văn bản thông thường
+	LaTeX(l i18n.Localizer) string // Cùng bước đó ở dạng LaTeX
+}
+
+// Add thêm các bước có cấu trúc vào result
+func (result *StepByStepResult) Add(records ...Record) {
+	result.Records = append(result.Records, records...)
+}
+
+// Steps trả về các bước của lời giải dạng văn bản, viết bằng ngôn ngữ của l
+func (result *StepByStepResult) Steps(l i18n.Localizer) []string {
+	steps := make([]string, len(result.Records))
+	for i, record := range result.Records {
+		steps[i] = record.Text(l)
+	}
+	return steps
+}
+
+// answer trả về bản ghi kết quả cuối cùng của lời giải, nil nếu lời giải không kết thúc bằng kết quả
+func (result *StepByStepResult) answer() *Result {
+	if len(result.Records) == 0 {
+		return nil
+	}
+	r, _ := result.Records[len(result.Records)-1].(*Result)
+	return r
+}
+
+// Answer trả về kết quả của lời giải viết bằng ngôn ngữ của l: Output, kèm số dư nếu là phép chia
+func (result *StepByStepResult) Answer(l i18n.Localizer) string {
+	if r := result.answer(); r != nil && r.Remainder != "" {
+		return l.T("%s remainder %s", r.Value, r.Remainder)
+	}
+	return result.Output
+}
+
+// Note là một dòng giải thích, ví dụ "Method: ...", "Step 1: ..." hoặc một tiêu đề
+type Note struct {
+	Label i18n.Message // Nhãn đứng trước dấu ":" (có thể rỗng)
+	Line  i18n.Message // Nội dung
+	Math  Expr         // Phép tính viết sau nội dung, như các vế của Equation (có thể nil)
+}
+
+func (n *Note) Text(l i18n.Localizer) string {
+	if n.Math == nil {
+		return n.line(l, "")
+	}
+	return n.line(l, n.Math.Text())
+}
+
+func (n *Note) LaTeX(l i18n.Localizer) string {
+	if n.Math == nil {
+		return n.line(l, "")
+	}
+	return n.line(l, "\\("+n.Math.LaTeX()+"\\)")
+}
+
+// line ghép nhãn, nội dung và phép tính
+func (n *Note) line(l i18n.Localizer, math string) string {
+	line := n.Line.Localize(l)
+	if math != "" {
+		line += " " + math
+	}
+	if n.Label.Msg == "" {
+		return line
+	}
+	return n.Label.Localize(l) + ": " + line
+}
+
+// Detail là một dòng giải thích con nằm dưới một bước, ví dụ "The final carry 1 is written in front"
+type Detail struct {
+	Line i18n.Message
+}
+
+func (d *Detail) Text(l i18n.Localizer) string {
+	return "  " + d.Line.Localize(l)
+}
+
+func (d *Detail) LaTeX(l i18n.Localizer) string {
+	return d.Line.Localize(l)
+}
+
+// Equation là một dòng tính toán con: các vế Terms nối bằng dấu "=", có thể kèm nhãn
+// Label đứng trước và lời giải thích Comment trong ngoặc
+type Equation struct {
+	Label   i18n.Message
+	Terms   []Expr
+	Comment i18n.Message
+}
+
+func (e *Equation) Text(l i18n.Localizer) string {
+	text := make([]string, len(e.Terms))
+	for i, t := range e.Terms {
+		text[i] = t.Text()
+	}
+	return "  " + e.line(l, strings.Join(text, " = "))
+}
+
+func (e *Equation) LaTeX(l i18n.Localizer) string {
+	math := make([]string, len(e.Terms))
+	for i, t := range e.Terms {
+		math[i] = t.LaTeX()
+	}
+	return e.line(l, "\\("+strings.Join(math, " = ")+"\\)")
+}
+
+// line thêm nhãn và lời giải thích vào phần tính toán
+func (e *Equation) line(l i18n.Localizer, math string) string {
+	if e.Label.Msg != "" {
+		math = e.Label.Localize(l) + ": " + math
+	}
+	if e.Comment.Msg != "" {
+		math += " (" + e.Comment.Localize(l) + ")"
+	}
+	return math
+}
+
+// Formula là công thức khai triển một số ở cơ số Base theo lũy thừa; Fraction là công thức
+// cho số có phần lẻ, đánh số chữ số theo vị trí so với dấu chấm
+type Formula struct {
+	Base     int
+	Fraction bool
+}
+
+func (f *Formula) Text(l i18n.Localizer) string {
+	return l.T("Formula: %s\\\\", f.expansion(l))
+}
+
+func (f *Formula) LaTeX(l i18n.Localizer) string {
+	if f.Fraction {
+		return l.T("Use the formula \\(%s\\), where \\(d_i\\) is the digit in position \\(i\\), counting from 0 left of the point and from -1 right of it.", f.expansion(l))
+	}
+	return l.T("Use the formula \\(%s\\), where \\(d_i\\) is the \\(i\\)-th digit from the left.", f.expansion(l))
+}
+
+// expansion trả về vế phải của công thức ở dạng LaTeX
+func (f *Formula) expansion(l i18n.Localizer) string {
+	if f.Fraction {
+		return l.T("\\text{Decimal} = d_{n-1} \\times %d^{n-1} + \\dots + d_0 \\times %d^{0} + d_{-1} \\times %d^{-1} + \\dots + d_{-m} \\times %d^{-m}", f.Base, f.Base, f.Base, f.Base)
+	}
+	return l.T("\\text{Decimal} = d_1 \\times %d^{n-1} + d_2 \\times %d^{n-2} + \\dots + d_n \\times %d^{0}", f.Base, f.Base, f.Base)
+}
+
+// PowerTerm là một số hạng của phép khai triển: chữ số Digit (giá trị Value) nhân với Base^Exp
+type PowerTerm struct {
+	Digit string
+	Value int64
+	Base  int
+	Exp   int
+	Term  *big.Int // Value x Base^Exp
+}
+
+// Power trả về Base^Exp
+func (p *PowerTerm) Power() *big.Int {
+	return bigPow(int64(p.Base), p.Exp)
+}
+
+func (p *PowerTerm) Text(l i18n.Localizer) string {
+	digit := p.Digit
+	if p.Value >= utils.DECIMAL_BASE {
+		digit += fmt.Sprintf(" (=%d)", p.Value)
+	}
+	return fmt.Sprintf("  %s x %d^%d = %d x %d = %d", digit, p.Base, p.Exp, p.Value, p.Power(), p.Term)
+}
+
+func (p *PowerTerm) LaTeX(l i18n.Localizer) string {
+	return fmt.Sprintf("\\(%s \\times %d^{%d} = %d \\times %d = %d\\)", p.Digit, p.Base, p.Exp, p.Value, p.Power(), p.Term)
+}
+
+// Sum là tổng của các số hạng Terms
+type Sum struct {
+	Terms []*big.Int
+	Total *big.Int
+}
+
+func (s *Sum) Text(l i18n.Localizer) string {
+	return l.T("Sum: %d", s.Total)
+}
+
+func (s *Sum) LaTeX(l i18n.Localizer) string {
+	// Bỏ các số hạng bằng 0 cho gọn
+	var terms []string
+	for _, t := range s.Terms {
+		if t.Sign() != 0 {
+			terms = append(terms, t.String())
+		}
+	}
+	if len(terms) == 0 {
+		terms = append(terms, "0")
+	}
+	return l.T("Sum: \\(%s = %d\\)", strings.Join(terms, " + "), s.Total)
+}
+
+// Division là một phép chia lấy dư: Dividend = Quotient x Divisor + Remainder
+type Division struct {
+	Dividend  *big.Int
+	Divisor   int
+	Quotient  *big.Int
+	Remainder int64
+}
+
+// Digit trả về số dư viết thành một chữ số ở cơ số Divisor
+func (d *Division) Digit() string {
+	return radixDigit(d.Remainder, d.Divisor)
+}
+
+func (d *Division) Text(l i18n.Localizer) string {
+	line := l.T("%d ÷ %d = %d remainder %d", d.Dividend, d.Divisor, d.Quotient, d.Remainder)
+	if d.Remainder >= utils.DECIMAL_BASE {
+		line += fmt.Sprintf(" (%s)", d.Digit())
+	}
+	return line
+}
+
+func (d *Division) LaTeX(l i18n.Localizer) string {
+	if d.Remainder >= utils.DECIMAL_BASE {
+		return l.T("\\(%d \\div %d = %d\\), remainder \\(%d = %s\\)", d.Dividend, d.Divisor, d.Quotient, d.Remainder, d.Digit())
+	}
+	return l.T("\\(%d \\div %d = %d\\), remainder \\(%d\\)", d.Dividend, d.Divisor, d.Quotient, d.Remainder)
+}
+
+// Grouping là một nhóm bit Bits ứng với đúng một chữ số Digit có giá trị Value.
+// Expand cho biết chữ số được viết thành nhóm bit (ngược lại là nhóm bit đổi thành chữ số)
+type Grouping struct {
+	Bits   string
+	Digit  string
+	Value  int64
+	Expand bool
+}
+
+func (g *Grouping) Text(l i18n.Localizer) string {
+	return "  " + g.equation()
+}
+
+func (g *Grouping) LaTeX(l i18n.Localizer) string {
+	return "\\(" + g.equation() + "\\)"
+}
+
+// equation viết nhóm bit thành tổng các trọng số, ví dụ B = 11 = 8 + 0 + 2 + 1 = 1011
+func (g *Grouping) equation() string {
+	if g.Expand {
+		line := g.Digit
+		if g.Value >= utils.DECIMAL_BASE {
+			line += fmt.Sprintf(" = %d", g.Value)
+		}
+		return fmt.Sprintf("%s = %s = %s", line, weights(g.Bits), g.Bits)
+	}
+	line := fmt.Sprintf("%s = %s = %d", g.Bits, weights(g.Bits), g.Value)
+	if g.Value >= utils.DECIMAL_BASE {
+		line += " = " + g.Digit
+	}
+	return line
+}
+
+// HornerStep là một lần nhân rồi cộng của sơ đồ Horner: Total = Prev x Base + Value
+type HornerStep struct {
+	Index int // Thứ tự chữ số tính từ bên trái, bắt đầu từ 1
+	Digit string
+	Value int64
+	Base  int
+	Prev  *big.Int
+	Total *big.Int
+}
+
+func (h *HornerStep) Text(l i18n.Localizer) string {
+	return l.T("  Digit %d (%s): %s x %d + %d = %s", h.Index, h.Digit, h.Prev, h.Base, h.Value, h.Total)
+}
+
+func (h *HornerStep) LaTeX(l i18n.Localizer) string {
+	return fmt.Sprintf("\\(v_{%d} = %s \\times %d + %d = %s\\)", h.Index, h.Prev, h.Base, h.Value, h.Total)
+}
+
+// LongDivision là một lần hạ chữ số Digit trong phép chia dài: Current ÷ Divisor = Quotient
+// dư Remainder, tất cả viết ở cơ số của phép chia
+type LongDivision struct {
+	Digit     string
+	Current   string
+	Divisor   string
+	Quotient  string
+	Remainder string
+}
+
+func (d *LongDivision) Text(l i18n.Localizer) string {
+	return l.T("  Bring down %s: %s ÷ %s = %s remainder %s", d.Digit, d.Current, d.Divisor, d.Quotient, d.Remainder)
+}
+
+func (d *LongDivision) LaTeX(l i18n.Localizer) string {
+	return l.T("Bring down %s: \\(%s \\div %s = %s\\), remainder \\(%s\\)", d.Digit, d.Current, d.Divisor, d.Quotient, d.Remainder)
+}
+
+// Result là kết quả Value ở cơ số Base (0 khi kết quả không phải một số ở cơ số nào);
+// phép chia có thêm số dư Remainder
+type Result struct {
+	Value     string
+	Base      int
+	Remainder string
+}
+
+func (r *Result) Text(l i18n.Localizer) string {
+	if r.Remainder != "" {
+		return l.T("Result: %s remainder %s", r.Value, r.Remainder)
+	}
+	return l.T("Result: %s", r.Value)
+}
+
+func (r *Result) LaTeX(l i18n.Localizer) string {
+	switch {
+	case r.Remainder != "":
+		return l.T("Result: \\(%s_{%d}\\) remainder \\(%s_{%d}\\)", r.Value, r.Base, r.Remainder, r.Base)
+	case r.Base == 0:
+		return l.T("Result: \\(%s\\)", r.Value)
+	}
+	return l.T("Result: \\(%s_{%d}\\)", r.Value, r.Base)
+}
+
+// Indented là bước của một lời giải con, ví dụ một số trong dãy số, viết lùi vào một mức
+type Indented struct {
+	Record
+}
+
+func (i *Indented) Text(l i18n.Localizer) string {
+	return "  " + i.Record.Text(l)
+}
@@ -0,0 +1,42 @@
+package stepbystep
+
+import (
+	"strings"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// SequenceSteps giải từng bước cho một dãy số cách nhau bởi dấu cách, mỗi số một lần
+func SequenceSteps(s string, stepsFunc StepsFunc) (*StepByStepResult, error) {
+	fields := strings.Fields(s)
+	result := &StepByStepResult{
+		Input:  s,
+		Method: utils.SEQUENCE,
+	}
+	result.Add(&Note{Line: i18n.M("Method: Convert each of the %d space-separated numbers separately.", len(fields))})
+
+	outputs := make([]string, len(fields))
+	for i, f := range fields {
+		r, err := stepsFunc(f)
+		if err != nil {
+			return nil, err
+		}
+		result.InputBase, result.OutputBase = r.InputBase, r.OutputBase
+		outputs[i] = r.Output
+
+		result.Add(&Note{Line: i18n.M("Number %d: %s", i+1, f)})
+		for _, record := range r.Records {
+			// Kết quả của từng số được gom lại ở bước cuối
+			if _, ok := record.(*Result); ok {
+				continue
+			}
+			result.Add(&Indented{record})
+		}
+		result.Add(&Equation{Terms: []Expr{Chain(Maps, Lit(f), Lit(r.Output))}})
+	}
+
+	result.Output = strings.Join(outputs, " ")
+	result.Add(&Result{Value: result.Output})
+	return result, nil
+}
@@ -0,0 +1,204 @@
+package stepbystep
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/signed"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// SignedSteps chọn các bước mã hoá hoặc giải mã số có dấu với độ rộng bit cố định
+func SignedSteps(s string, from, to, width int, rep string) (*StepByStepResult, error) {
+	var inner *StepByStepResult
+	var bits *big.Int
+	var err error
+	pattern := s
+
+	if from == utils.DECIMAL_BASE {
+		if inner, err = Decimal2SignedSteps(s, width, rep); err != nil {
+			return nil, err
+		}
+		bits, _ = new(big.Int).SetString(inner.Output, utils.BINARY_BASE)
+	} else {
+		// Viết lại mẫu bit ở dạng nhị phân trước khi giải mã
+		if bits, err = utils.ParseRadix(s, from); err != nil {
+			return nil, err
+		}
+		if from != utils.BINARY_BASE {
+			pattern = bits.Text(utils.BINARY_BASE)
+		}
+		if inner, err = Signed2DecimalSteps(pattern, width, rep); err != nil {
+			return nil, err
+		}
+	}
+
+	result := newResult(s, utils.RadixFormat(from), inner.OutputBase)
+	result.Method = rep
+	if from != utils.BINARY_BASE && from != utils.DECIMAL_BASE {
+		result.Add(&Note{Line: i18n.M("Write %s (base %d) in binary: %s", s, from, pattern)})
+	}
+	// Kết quả của lời giải bên trong được ghi lại ở cuối
+	result.Add(inner.Records[:len(inner.Records)-1]...)
+	result.Output = inner.Output
+
+	// Viết mẫu bit ở cơ số đích nếu đích không phải nhị phân hoặc thập phân
+	if to != utils.BINARY_BASE && to != utils.DECIMAL_BASE {
+		output := strings.ToUpper(signed.Pad(bits, width, to))
+		result.Add(&Note{Line: i18n.M("Write the %d-bit pattern in base %d: %s", width, to, output)})
+		result.Output = output
+	}
+	if to != utils.DECIMAL_BASE {
+		result.OutputBase = utils.RadixFormat(to)
+	}
+
+	result.Add(&Result{Value: result.Output, Base: to})
+	return result, nil
+}
+
+// Decimal2SignedSteps mã hoá số thập phân có dấu thành mẫu bit với các bước chi tiết
+func Decimal2SignedSteps(s string, width int, rep string) (*StepByStepResult, error) {
+	i, err := utils.ParseRadix(s, utils.DECIMAL_BASE)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &StepByStepResult{
+		Input:      s,
+		InputBase:  utils.DECIMAL,
+		Output:     "",
+		OutputBase: utils.BINARY,
+		Method:     rep,
+	}
+
+	result.Add(&Note{Line: i18n.M("Method: Encode %s in %d-bit %s.", s, width, i18n.M(signed.Name(rep)))})
+
+	// Kiểm tra phạm vi biểu diễn
+	min, max, err := signed.Range(width, rep)
+	if err != nil {
+		return nil, err
+	}
+	result.Add(&Note{Line: i18n.M("Range: %d to %d", min, max)})
+	bits, err := signed.Encode(i, width, rep)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bước 1: Chuyển giá trị tuyệt đối sang nhị phân
+	abs := new(big.Int).Abs(i)
+	result.Add(&Note{Line: i18n.M("Step 1: Convert the magnitude |%s| = %d to binary:", s, abs)})
+	if abs.Sign() > 0 {
+		magnitude := newResult(abs.String(), utils.DECIMAL, utils.BINARY)
+		divisionSteps(magnitude, abs, utils.BINARY_BASE)
+		result.Add(magnitude.Records...)
+	}
+
+	// Bước 2: Thêm các số 0 ở đầu cho đủ độ rộng
+	padded := signed.Pad(abs, width, utils.BINARY_BASE)
+	result.Add(&Note{Line: i18n.M("Step 2: Pad to %d bits: %s", width, padded)})
+
+	// Bước 3: Xử lý dấu
+	if i.Sign() >= 0 {
+		result.Add(&Note{Line: i18n.M("Step 3: The number is non-negative, so the padded pattern is the result.")})
+	} else {
+		switch rep {
+		case utils.ONES_COMPLEMENT:
+			result.Add(&Note{Line: i18n.M("Step 3: Invert all bits: %s", invertBits(padded))})
+		case utils.SIGN_MAGNITUDE:
+			result.Add(&Note{Line: i18n.M("Step 3: Set the sign bit (bit %d) to 1: %s", width-1, signed.Pad(bits, width, utils.BINARY_BASE))})
+		default:
+			inverted := invertBits(padded)
+			result.Add(&Note{Line: i18n.M("Step 3: Invert all bits: %s", inverted)})
+			result.Add(&Note{Line: i18n.M("Step 4: Add 1: %s + 1 = %s", inverted, signed.Pad(bits, width, utils.BINARY_BASE))})
+		}
+	}
+
+	result.Output = signed.Pad(bits, width, utils.BINARY_BASE)
+	result.Add(&Result{Value: result.Output, Base: utils.BINARY_BASE})
+
+	return result, nil
+}
+
+// Signed2DecimalSteps giải mã mẫu bit có dấu thành số thập phân với các bước chi tiết
+func Signed2DecimalSteps(s string, width int, rep string) (*StepByStepResult, error) {
+	result := &StepByStepResult{
+		Input:      s,
+		InputBase:  utils.BINARY,
+		Output:     "",
+		OutputBase: utils.DECIMAL,
+		Method:     rep,
+	}
+
+	result.Add(&Note{Line: i18n.M("Method: Decode %s from %d-bit %s.", s, width, i18n.M(signed.Name(rep)))})
+
+	bits, err := utils.ParseRadix(s, utils.BINARY_BASE)
+	if err != nil {
+		return nil, err
+	}
+	value, err := signed.Decode(bits, width, rep)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bước 1: Thêm các số 0 ở đầu cho đủ độ rộng
+	padded := signed.Pad(bits, width, utils.BINARY_BASE)
+	result.Add(&Note{Line: i18n.M("Step 1: Pad to %d bits: %s", width, padded)})
+
+	// Bước 2: Xét bit dấu
+	if value.Sign() >= 0 && bits.Bit(width-1) == 0 {
+		result.Add(&Note{Line: i18n.M("Step 2: The sign bit (leftmost) is 0, so the number is non-negative.")})
+		result.Add(&Note{Line: i18n.M("Step 3: Convert the pattern to decimal:")})
+		binary := newResult(trimZeros(padded), utils.BINARY, utils.DECIMAL)
+		powerSteps(binary, binary.Input, utils.BINARY_BASE)
+		result.Add(binary.Records...)
+	} else {
+		result.Add(&Note{Line: i18n.M("Step 2: The sign bit (leftmost) is 1, so the number is negative.")})
+
+		var magnitude string
+		step := 4
+		switch rep {
+		case utils.ONES_COMPLEMENT:
+			magnitude = invertBits(padded)
+			result.Add(&Note{Line: i18n.M("Step 3: Invert all bits to get the magnitude: %s", magnitude)})
+		case utils.SIGN_MAGNITUDE:
+			magnitude = "0" + padded[1:]
+			result.Add(&Note{Line: i18n.M("Step 3: Clear the sign bit to get the magnitude: %s", magnitude)})
+		default:
+			inverted := invertBits(padded)
+			abs := new(big.Int).Neg(value)
+			magnitude = signed.Pad(abs, width, utils.BINARY_BASE)
+			result.Add(&Note{Line: i18n.M("Step 3: Invert all bits: %s", inverted)})
+			result.Add(&Note{Line: i18n.M("Step 4: Add 1 to get the magnitude: %s + 1 = %s", inverted, magnitude)})
+			step = 5
+		}
+
+		result.Add(&Note{Line: i18n.M("Step %d: Convert the magnitude to decimal and apply the minus sign:", step)})
+		binary := newResult(trimZeros(magnitude), utils.BINARY, utils.DECIMAL)
+		powerSteps(binary, binary.Input, utils.BINARY_BASE)
+		result.Add(binary.Records...)
+	}
+
+	result.Output = value.String()
+	result.Add(&Result{Value: result.Output, Base: utils.DECIMAL_BASE})
+
+	return result, nil
+}
+
+// invertBits đảo từng bit trong chuỗi nhị phân
+func invertBits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '0' {
+			return '1'
+		}
+		return '0'
+	}, s)
+}
+
+// trimZeros bỏ các số 0 ở đầu, giữ lại ít nhất một chữ số
+func trimZeros(s string) string {
+	if s = strings.TrimLeft(s, "0"); s == "" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,616 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package stepbystep_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/clarketm/ncalc/format"
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/stepbystep"
+	"github.com/clarketm/ncalc/utils"
+)
+
+var en = i18n.New(i18n.EN)
+
+func ExampleArithmeticSteps() {
+
+	// ARITHMETIC (literal operands, one operator, non-negative operands)
+	opts := utils.DefaultOptions()
+	opts.Method = utils.BORROW
+	for _, s := range []string{"0x10 + 1", "1011 * 11", "100 / 7", "3 - 10", "1 + 2 + 3", "10 - -3", "1 /  0"} {
+		results, err := stepbystep.ArithmeticSteps(s, utils.DECIMAL_BASE, opts)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println(results[0].Input, "=", results[0].Answer(en))
+	}
+
+	// Output:
+	// 16 + 1 = 17
+	// 1011 * 11 = 11121
+	// 100 / 7 = 14 remainder 2
+	// 3 - 10 = -7
+	// invalid expression at position 7: step-by-step arithmetic supports a single operator
+	// invalid expression at position 6: -3 is negative; step-by-step arithmetic works on non-negative numbers
+	// invalid expression at position 3: division by zero
+}
+
+func ExampleSubtractSteps() {
+
+	// ARITHMETIC (the complement of 0 keeps the width of the operands)
+	result, _ := stepbystep.SubtractSteps("101", "0", utils.BINARY_BASE, utils.COMPLEMENT)
+	for _, step := range result.Steps(en) {
+		fmt.Println(step)
+	}
+
+	// Output:
+	// Method: Add the two's complement of the subtrahend instead of subtracting it. A carry out of the top column is dropped and means the result is positive; no carry means the result is negative.
+	// Step 1: Pad both numbers to 3 digits: 101 - 000
+	// Step 2: Find the two's complement of 000:
+	//   Replace each digit d with 1 - d: 111
+	//   Add 1: 111 + 1 = 1000
+	//   Drop the carry out of the top digit: 000
+	// Step 3: Add 101 + 000:
+	//   Column 1: 1 + 0 + 0 = 1 (write 1, carry 0)
+	//   Column 2: 0 + 0 + 0 = 0 (write 0, carry 0)
+	//   Column 3: 1 + 0 + 0 = 1 (write 1, carry 0)
+	// Step 4: The subtrahend is 0, so there is no carry to drop and the sum is the result: 101
+	// Result: 101
+}
+
+func ExampleStepByStepResult_Steps() {
+
+	// I18N (the same records in each language)
+	stepsFunc, _ := format.Steps(utils.DECIMAL, utils.BINARY, utils.DefaultOptions())
+	result, _ := stepsFunc("6")
+	for _, lang := range i18n.Languages {
+		for _, step := range result.Steps(i18n.New(lang)) {
+			fmt.Println(step)
+		}
+	}
+
+	// Output:
+	// Method: Divide continuously by 2, note the remainders, read the result from bottom to top.
+	// 6 ÷ 2 = 3 remainder 0
+	// 3 ÷ 2 = 1 remainder 1
+	// 1 ÷ 2 = 0 remainder 1
+	// Result: 110
+	// Phương pháp: Chia liên tục cho 2, ghi lại các số dư, đọc kết quả từ dưới lên.
+	// 6 ÷ 2 = 3 dư 0
+	// 3 ÷ 2 = 1 dư 1
+	// 1 ÷ 2 = 0 dư 1
+	// Kết quả: 110
+}
+
+func ExampleNote() {
+
+	// RECORD (plain text and LaTeX)
+	nested := stepbystep.Chain(stepbystep.Times, &stepbystep.Paren{X: stepbystep.Chain(stepbystep.Plus, stepbystep.Chain(stepbystep.Times, stepbystep.Num(2), stepbystep.Num(16)), stepbystep.Num(15))}, stepbystep.Num(16))
+	var record stepbystep.Record = &stepbystep.Note{Label: i18n.M("Step %d", 1), Line: i18n.M("Write %s in nested form:", "2F3"), Math: stepbystep.Chain(stepbystep.Plus, nested, stepbystep.Num(3))}
+	fmt.Println(record.Text(en))
+	fmt.Println(record.LaTeX(en))
+
+	// Output:
+	// Step 1: Write 2F3 in nested form: (2 x 16 + 15) x 16 + 3
+	// Step 1: Write 2F3 in nested form: \((2 \times 16 + 15) \times 16 + 3\)
+}
+
+func ExampleDetail() {
+
+	// RECORD (plain text and LaTeX)
+	var record stepbystep.Record = &stepbystep.Detail{Line: i18n.M("The final carry %s is written in front", "1")}
+	fmt.Println(record.Text(en))
+	fmt.Println(record.LaTeX(en))
+
+	// Output:
+	// The final carry 1 is written in front
+	// The final carry 1 is written in front
+}
+
+func ExampleEquation() {
+
+	// RECORD (plain text and LaTeX)
+	var record stepbystep.Record = &stepbystep.Equation{Label: i18n.M("Column %d", 2), Terms: []stepbystep.Expr{
+		stepbystep.Chain(stepbystep.Plus, stepbystep.Num(1), stepbystep.Num(1), stepbystep.Num(0)),
+		stepbystep.Num(2),
+		stepbystep.Chain(stepbystep.Plus, stepbystep.Chain(stepbystep.Times, stepbystep.Num(1), stepbystep.Num(2)), stepbystep.Num(0)),
+	}, Comment: i18n.M("write %s, carry %d", "0", 1)}
+	fmt.Println(record.Text(en))
+	fmt.Println(record.LaTeX(en))
+
+	// Output:
+	// Column 2: 1 + 1 + 0 = 2 = 1 x 2 + 0 (write 0, carry 1)
+	// Column 2: \(1 + 1 + 0 = 2 = 1 \times 2 + 0\) (write 0, carry 1)
+}
+
+func ExampleFormula() {
+
+	// RECORD (plain text and LaTeX)
+	var record stepbystep.Record = &stepbystep.Formula{Base: 2}
+	fmt.Println(record.Text(en))
+	fmt.Println(record.LaTeX(en))
+
+	// Output:
+	// Formula: \text{Decimal} = d_1 \times 2^{n-1} + d_2 \times 2^{n-2} + \dots + d_n \times 2^{0}\\
+	// Use the formula \(\text{Decimal} = d_1 \times 2^{n-1} + d_2 \times 2^{n-2} + \dots + d_n \times 2^{0}\), where \(d_i\) is the \(i\)-th digit from the left.
+}
+
+func ExampleFormula_fraction() {
+
+	// RECORD (plain text and LaTeX)
+	var record stepbystep.Record = &stepbystep.Formula{Base: 2, Fraction: true}
+	fmt.Println(record.Text(en))
+	fmt.Println(record.LaTeX(en))
+
+	// Output:
+	// Formula: \text{Decimal} = d_{n-1} \times 2^{n-1} + \dots + d_0 \times 2^{0} + d_{-1} \times 2^{-1} + \dots + d_{-m} \times 2^{-m}\\
+	// Use the formula \(\text{Decimal} = d_{n-1} \times 2^{n-1} + \dots + d_0 \times 2^{0} + d_{-1} \times 2^{-1} + \dots + d_{-m} \times 2^{-m}\), where \(d_i\) is the digit in position \(i\), counting from 0 left of the point and from -1 right of it.
+}
+
+func ExamplePowerTerm() {
+
+	// RECORD (plain text and LaTeX)
+	var record stepbystep.Record = &stepbystep.PowerTerm{Digit: "F", Value: 15, Base: 16, Exp: 1, Term: big.NewInt(240)}
+	fmt.Println(record.Text(en))
+	fmt.Println(record.LaTeX(en))
+
+	// Output:
+	// F (=15) x 16^1 = 15 x 16 = 240
+	// \(F \times 16^{1} = 15 \times 16 = 240\)
+}
+
+func ExampleSum() {
+
+	// RECORD (plain text and LaTeX)
+	var record stepbystep.Record = &stepbystep.Sum{Terms: []*big.Int{big.NewInt(8), big.NewInt(0), big.NewInt(2)}, Total: big.NewInt(10)}
+	fmt.Println(record.Text(en))
+	fmt.Println(record.LaTeX(en))
+
+	// Output:
+	// Sum: 10
+	// Sum: \(8 + 2 = 10\)
+}
+
+func ExampleDivision() {
+
+	// RECORD (plain text and LaTeX)
+	var record stepbystep.Record = &stepbystep.Division{Dividend: big.NewInt(47), Divisor: 16, Quotient: big.NewInt(2), Remainder: 15}
+	fmt.Println(record.Text(en))
+	fmt.Println(record.LaTeX(en))
+
+	// Output:
+	// 47 ÷ 16 = 2 remainder 15 (F)
+	// \(47 \div 16 = 2\), remainder \(15 = F\)
+}
+
+func ExampleGrouping() {
+
+	// RECORD (plain text and LaTeX)
+	var record stepbystep.Record = &stepbystep.Grouping{Bits: "1010", Digit: "A", Value: 10}
+	fmt.Println(record.Text(en))
+	fmt.Println(record.LaTeX(en))
+
+	// Output:
+	// 1010 = 8 + 0 + 2 + 0 = 10 = A
+	// \(1010 = 8 + 0 + 2 + 0 = 10 = A\)
+}
+
+func ExampleHornerStep() {
+
+	// RECORD (plain text and LaTeX)
+	var record stepbystep.Record = &stepbystep.HornerStep{Index: 2, Digit: "F", Value: 15, Base: 16, Prev: big.NewInt(2), Total: big.NewInt(47)}
+	fmt.Println(record.Text(en))
+	fmt.Println(record.LaTeX(en))
+
+	// Output:
+	// Digit 2 (F): 2 x 16 + 15 = 47
+	// \(v_{2} = 2 \times 16 + 15 = 47\)
+}
+
+func ExampleLongDivision() {
+
+	// RECORD (plain text and LaTeX)
+	var record stepbystep.Record = &stepbystep.LongDivision{Digit: "0", Current: "30", Divisor: "7", Quotient: "4", Remainder: "2"}
+	fmt.Println(record.Text(en))
+	fmt.Println(record.LaTeX(en))
+
+	// Output:
+	// Bring down 0: 30 ÷ 7 = 4 remainder 2
+	// Bring down 0: \(30 \div 7 = 4\), remainder \(2\)
+}
+
+func ExampleResult() {
+
+	// RECORD (plain text and LaTeX)
+	var record stepbystep.Record = &stepbystep.Result{Value: "14", Base: 10, Remainder: "2"}
+	fmt.Println(record.Text(en))
+	fmt.Println(record.LaTeX(en))
+
+	// Output:
+	// Result: 14 remainder 2
+	// Result: \(14_{10}\) remainder \(2_{10}\)
+}
+
+func ExampleIndented() {
+
+	// RECORD (plain text and LaTeX)
+	var record stepbystep.Record = &stepbystep.Indented{Record: &stepbystep.Result{Value: "1011"}}
+	fmt.Println(record.Text(en))
+	fmt.Println(record.LaTeX(en))
+
+	// Output:
+	// Result: 1011
+	// Result: \(1011\)
+}
+
+func ExampleStepByStepResult_LaTeX_radix() {
+
+	// LATEX (decimal to binary)
+	opts := utils.DefaultOptions()
+	stepsFunc, _ := format.Steps("decimal", "binary", opts)
+	result, _ := stepsFunc("13")
+	fmt.Print(result.LaTeX(en))
+
+	// Output:
+	// \begin{enumerate}
+	// \item Method: Divide continuously by 2, note the remainders, read the result from bottom to top.
+	// \begin{itemize}
+	//     \item \(13 \div 2 = 6\), remainder \(1\)
+	//     \item \(6 \div 2 = 3\), remainder \(0\)
+	//     \item \(3 \div 2 = 1\), remainder \(1\)
+	//     \item \(1 \div 2 = 0\), remainder \(1\)
+	// \end{itemize}
+	// \begin{center}
+	// \textbf{Final Answer:} $1101_{2}$
+	// \end{center}
+	// \end{enumerate}
+}
+
+func ExampleStepByStepResult_LaTeX_grouping() {
+
+	// LATEX (binary to hexadecimal)
+	opts := utils.DefaultOptions()
+	stepsFunc, _ := format.Steps("binary", "hexadecimal", opts)
+	result, _ := stepsFunc("1011010")
+	fmt.Print(result.LaTeX(en))
+
+	// Output:
+	// \begin{enumerate}
+	// \item Method: Each hexadecimal digit stands for exactly 4 bits, so group the bits in fours from the right and map each group to a digit.
+	// \item Note: 10=A, 11=B, 12=C, 13=D, 14=E, 15=F
+	// \item Step 1: Pad 1011010 with leading zeros to a multiple of 4 bits: 01011010
+	// \item Step 2: Split into 4-bit groups: 0101 1010
+	// \item Step 3: Map each group to a digit in hexadecimal:
+	// \begin{itemize}
+	//     \item \(0101 = 0 + 4 + 0 + 1 = 5\)
+	//     \item \(1010 = 8 + 0 + 2 + 0 = 10 = A\)
+	// \end{itemize}
+	// \begin{center}
+	// \textbf{Final Answer:} $5A_{16}$
+	// \end{center}
+	// \end{enumerate}
+}
+
+func ExampleStepByStepResult_LaTeX_horner() {
+
+	// LATEX (hexadecimal to decimal)
+	opts := utils.DefaultOptions()
+	opts.Method = utils.METHOD_HORNER
+	stepsFunc, _ := format.Steps("hexadecimal", "decimal", opts)
+	result, _ := stepsFunc("2F3")
+	fmt.Print(result.LaTeX(en))
+
+	// Output:
+	// \begin{enumerate}
+	// \item Method: Horner's scheme. Start from 0 and, for each digit from the left, multiply the running value by 16 and add the digit.
+	// \item Note: A=10, B=11, C=12, D=13, E=14, F=15
+	// \item Step 1: Write 2F3 in nested form: \((2 \times 16 + 15) \times 16 + 3\)
+	// \item Step 2: Multiply and add from the left:
+	// \begin{center}
+	// \begin{tabular}{c|c|l}
+	// \(i\) & \(d_i\) & \(v_i = v_{i-1} \times 16 + d_i\) \\
+	// \hline
+	// 1 & 2 & \(0 \times 16 + 2 = 2\) \\
+	// 2 & F & \(2 \times 16 + 15 = 47\) \\
+	// 3 & 3 & \(47 \times 16 + 3 = 755\) \\
+	// \end{tabular}
+	// \end{center}
+	// \begin{center}
+	// \textbf{Final Answer:} $755_{10}$
+	// \end{center}
+	// \end{enumerate}
+}
+
+func ExampleStepByStepResult_LaTeX_fraction() {
+
+	// LATEX (hexadecimal to decimal)
+	opts := utils.DefaultOptions()
+	stepsFunc, _ := format.Steps("hexadecimal", "decimal", opts)
+	result, _ := stepsFunc("1A.8")
+	fmt.Print(result.LaTeX(en))
+
+	// Output:
+	// \begin{enumerate}
+	// \item Converting base-16 number 1A.8 to decimal:
+	// \item Use the formula \(\text{Decimal} = d_{n-1} \times 16^{n-1} + \dots + d_0 \times 16^{0} + d_{-1} \times 16^{-1} + \dots + d_{-m} \times 16^{-m}\), where \(d_i\) is the digit in position \(i\), counting from 0 left of the point and from -1 right of it.
+	// \item Note: A=10, B=11, C=12, D=13, E=14, F=15
+	// \item For 1A.8:
+	// \begin{itemize}
+	//     \item \(1 \times 16^{1} = 1 \times 16 = 16\)
+	//     \item \(A (=10) \times 16^{0} = 10 \times 1 = 10\)
+	//     \item \(8 \times 16^{-1} = 8 \times 1/16 = 0.5\)
+	// \end{itemize}
+	// \item Sum: 26.5
+	// \begin{center}
+	// \textbf{Final Answer:} $26.5_{10}$
+	// \end{center}
+	// \end{enumerate}
+}
+
+func ExampleStepByStepResult_LaTeX_ieee754() {
+
+	// LATEX (decimal to ieee16)
+	opts := utils.DefaultOptions()
+	stepsFunc, _ := format.Steps("decimal", "ieee16", opts)
+	result, _ := stepsFunc("1.5")
+	fmt.Print(result.LaTeX(en))
+
+	// Output:
+	// \begin{enumerate}
+	// \item Encode 1.5 as IEEE 754 half precision (16 bits: 1 sign, 5 exponent, 10 mantissa bits).
+	// \item Step 1: Sign: 1.5 is not negative, so the sign bit is 0.
+	// \item Step 2: Write 1.5 in binary: 1.1
+	// \item Step 3: Normalize: 1.1 x 2^0
+	// \item Step 4: Round the mantissa to 10 bits: 1000000000, nothing is discarded so the value is exact.
+	// \item Step 5: Bias the exponent: 0 + 15 = 15 = 01111
+	// \item Step 6: Assemble sign | exponent | mantissa: 0 | 01111 | 1000000000
+	// \item Step 7: In hexadecimal: 0x3E00
+	// \begin{center}
+	// \textbf{Final Answer:} \texttt{0 01111 1000000000} (IEEE 754 half precision)
+	// \end{center}
+	// \end{enumerate}
+}
+
+func ExampleStepByStepResult_LaTeX_fixed() {
+
+	// LATEX (decimal to q1.15)
+	opts := utils.DefaultOptions()
+	stepsFunc, _ := format.Steps("decimal", "q1.15", opts)
+	result, _ := stepsFunc("-0.5")
+	fmt.Print(result.LaTeX(en))
+
+	// Output:
+	// \begin{enumerate}
+	// \item Method: Q1.15 has 1 integer bit(s), the sign bit included, and 15 fraction bit(s). Multiply by 2^15 to move the binary point, round to an integer, keep it within the 16-bit signed range and write it as a 16-bit two's complement word.
+	// \item Step 1: Scale by 2^15 = 32768: -0.5 × 32768 = -16384
+	// \item Step 2: -16384 is already an integer, no rounding needed
+	// \item Step 3: -16384 is within -32768 to 32767
+	// \item Step 4: Negative, so add 2^16: -16384 + 65536 = 49152 = 1100000000000000
+	// \item Step 5: Group the bits by 4 for hexadecimal: 1100 0000 0000 0000 = 0xC000
+	// \item Step 6: Stored value: -16384 / 32768 = -0.5 (error 0)
+	// \begin{center}
+	// \textbf{Final Answer:} \texttt{0xC000} (Q1.15 fixed point)
+	// \end{center}
+	// \end{enumerate}
+}
+
+func ExampleStepByStepResult_LaTeX_bcd() {
+
+	// LATEX (decimal to bcd-packed)
+	opts := utils.DefaultOptions()
+	stepsFunc, _ := format.Steps("decimal", "bcd-packed", opts)
+	result, _ := stepsFunc("29")
+	fmt.Print(result.LaTeX(en))
+
+	// Output:
+	// \begin{enumerate}
+	// \item Method: Replace each decimal digit by its 4-bit binary code with weights 8 4 2 1.
+	// \item Step 1: Map each digit of 29 to a nibble:
+	// \begin{itemize}
+	//     \item \(2 \to 0010\)
+	//     \item \(9 \to 1001\)
+	// \end{itemize}
+	// \item Step 2: Pack two nibbles per byte and write each byte in hex:
+	// \begin{itemize}
+	//     \item \(0010 1001 = 29\)
+	// \end{itemize}
+	// \begin{center}
+	// \textbf{Final Answer:} \texttt{29} (packed BCD)
+	// \end{center}
+	// \end{enumerate}
+}
+
+func ExampleStepByStepResult_LaTeX_gray() {
+
+	// LATEX (gray to decimal)
+	opts := utils.DefaultOptions()
+	stepsFunc, _ := format.Steps("gray", "decimal", opts)
+	result, _ := stepsFunc("110")
+	fmt.Print(result.LaTeX(en))
+
+	// Output:
+	// \begin{enumerate}
+	// \item Method: The first binary bit is the first Gray bit; every next binary bit is the previous binary bit XOR the next Gray bit.
+	// \item Step 1: Undo the XOR from left to right over 110:
+	// \begin{itemize}
+	//     \item \(b1 = g1 = 1\)
+	//     \item \(b2 = b1 \oplus g2 = 1 \oplus 1 = 0\)
+	//     \item \(b3 = b2 \oplus g3 = 0 \oplus 0 = 0\)
+	// \end{itemize}
+	// \item Step 2: Binary: 100
+	// \item Step 3: Convert 100 to base 10: 4
+	// \begin{center}
+	// \textbf{Final Answer:} $4_{10}$
+	// \end{center}
+	// \end{enumerate}
+}
+
+func ExampleStepByStepResult_LaTeX_roman() {
+
+	// LATEX (decimal to roman)
+	opts := utils.DefaultOptions()
+	stepsFunc, _ := format.Steps("decimal", "roman", opts)
+	result, _ := stepsFunc("14")
+	fmt.Print(result.LaTeX(en))
+
+	// Output:
+	// \begin{enumerate}
+	// \item Method: Repeatedly take away the largest value in the table that still fits and write its symbol. The subtractive pairs CM (900), CD (400), XC (90), XL (40), IX (9) and IV (4) write a smaller symbol before a larger one to take it away.
+	// \item Step 1: Take away the largest value that fits:
+	// \begin{itemize}
+	//     \item \(14 - 10 = 4 \to X\)
+	//     \item \(4 - 4 = 0 \to IV\) (subtractive pair: 5 - 1 = 4)
+	// \end{itemize}
+	// \item Step 2: Join the symbols: X IV = XIV
+	// \begin{center}
+	// \textbf{Final Answer:} \texttt{XIV} (Roman numerals)
+	// \end{center}
+	// \end{enumerate}
+}
+
+func ExampleStepByStepResult_LaTeX_positional() {
+
+	// LATEX (decimal to negabinary)
+	opts := utils.DefaultOptions()
+	stepsFunc, _ := format.Steps("decimal", "negabinary", opts)
+	result, _ := stepsFunc("-3")
+	fmt.Print(result.LaTeX(en))
+
+	// Output:
+	// \begin{enumerate}
+	// \item Method: Divide by (-2) repeatedly and keep each remainder 0 or 1, correcting the quotient when the plain remainder is out of range. The remainders read from bottom to top are the digits.
+	// \item Step 1: Divide by (-2):
+	// \begin{itemize}
+	//     \item \(-3 \div (-2) = 1\) (remainder -1 is negative: add 2 to it and 1 to the quotient, so -3 = (-2) × 2 + 1 -> 1)
+	//     \item \(2 \div (-2) = -1\) (remainder 0 -> 0)
+	//     \item \(-1 \div (-2) = 0\) (remainder -1 is negative: add 2 to it and 1 to the quotient, so -1 = (-2) × 1 + 1 -> 1)
+	//     \item \(1 \div (-2) = 0\) (remainder 1 -> 1)
+	// \end{itemize}
+	// \item Step 2: Read the digits from bottom to top: 1101
+	// \begin{center}
+	// \textbf{Final Answer:} \texttt{1101} (base -2)
+	// \end{center}
+	// \end{enumerate}
+}
+
+func ExampleStepByStepResult_LaTeX_bytestring() {
+
+	// LATEX (ascii to base64)
+	opts := utils.DefaultOptions()
+	stepsFunc, _ := format.Steps("ascii", "base64", opts)
+	result, _ := stepsFunc("Hi")
+	fmt.Print(result.LaTeX(en))
+
+	// Output:
+	// \begin{enumerate}
+	// \item Method: Write the bytes in binary, cut the bits into 6-bit groups, and look each group up in the Base64 alphabet.
+	// \item Step 1: Bytes: 48 69
+	// \item Step 2: In binary: 01001000 01101001
+	// \item Step 3: Regroup into 6-bit groups (the last group is filled with 0s): 010010 000110 100100
+	// \item Step 4: Map each group to a character:
+	// \begin{itemize}
+	//     \item \(010010 = 18 \to S\)
+	//     \item \(000110 = 6 \to G\)
+	//     \item \(100100 = 36 \to k\)
+	// \end{itemize}
+	// \item Step 5: Pad with '=' to a multiple of 4 characters: SGk=
+	// \begin{center}
+	// \textbf{Final Answer:} \texttt{SGk=} (Base64)
+	// \end{center}
+	// \end{enumerate}
+}
+
+func ExampleStepByStepResult_LaTeX_unicode() {
+
+	// LATEX (ascii to utf-8)
+	opts := utils.DefaultOptions()
+	stepsFunc, _ := format.Steps("ascii", "utf-8", opts)
+	result, _ := stepsFunc("é")
+	fmt.Print(result.LaTeX(en))
+
+	// Output:
+	// \begin{enumerate}
+	// \item Method: Choose the number of bytes from the code point, then fill the payload bits (x) of the byte templates from left to right.
+	// \item U+0000 to U+007F: 0xxxxxxx (7 payload bits)
+	// \item U+0080 to U+07FF: 110xxxxx 10xxxxxx (11 payload bits)
+	// \item U+0800 to U+FFFF: 1110xxxx 10xxxxxx 10xxxxxx (16 payload bits)
+	// \item U+10000 to U+10FFFF: 11110xxx 10xxxxxx 10xxxxxx 10xxxxxx (21 payload bits)
+	// \item Step 1: 'é' = U+00E9 = 11101001 in binary
+	// \begin{itemize}
+	//     \item U+00E9 is at most U+07FF, so it takes 2 byte(s): 110xxxxx 10xxxxxx
+	//     \item Pad to 11 payload bits: 00011101001
+	//     \item Split the payload: 00011 | 101001
+	//     \item Byte 1: \(110 + 00011 = 11000011 = C3\)
+	//     \item Byte 2: \(10 + 101001 = 10101001 = A9\)
+	//     \item \('é' \to C3 A9\)
+	// \end{itemize}
+	// \begin{center}
+	// \textbf{Final Answer:} \texttt{C3 A9 (U+00E9)} (UTF-8 bytes)
+	// \end{center}
+	// \end{enumerate}
+}
+
+func ExampleStepByStepResult_LaTeX_sequence() {
+
+	// LATEX (decimal to binary)
+	opts := utils.DefaultOptions()
+	stepsFunc, _ := format.Steps("decimal", "binary", opts)
+	result, _ := stepsFunc("2 3")
+	fmt.Print(result.LaTeX(en))
+
+	// Output:
+	// \begin{enumerate}
+	// \item Method: Convert each of the 2 space-separated numbers separately.
+	// \item Number 1: 2
+	// \begin{itemize}
+	//     \item Method: Divide continuously by 2, note the remainders, read the result from bottom to top.
+	//     \item \(2 \div 2 = 1\), remainder \(0\)
+	//     \item \(1 \div 2 = 0\), remainder \(1\)
+	//     \item \(2 \to 10\)
+	// \end{itemize}
+	// \item Number 2: 3
+	// \begin{itemize}
+	//     \item Method: Divide continuously by 2, note the remainders, read the result from bottom to top.
+	//     \item \(3 \div 2 = 1\), remainder \(1\)
+	//     \item \(1 \div 2 = 0\), remainder \(1\)
+	//     \item \(3 \to 11\)
+	// \end{itemize}
+	// \begin{center}
+	// \textbf{Final Answer:} $10 11_{2}$
+	// \end{center}
+	// \end{enumerate}
+}
+
+func ExampleStepByStepResult_LaTeX_arithmetic() {
+
+	// LATEX (long division)
+	result, _ := stepbystep.DivideSteps("100", "7", utils.DECIMAL_BASE)
+	fmt.Print(result.LaTeX(en))
+
+	// Output:
+	// \begin{enumerate}
+	// \item Method: Long division. Bring down the digits of 100 one at a time; each time, the number of times 7 fits is the next quotient digit and what is left carries on.
+	// \item Step 1: Divide digit by digit from the left:
+	// \begin{itemize}
+	//     \item Bring down 1: \(1 \div 7 = 0\), remainder \(1\)
+	//     \item Bring down 0: \(10 \div 7 = 1\), remainder \(3\)
+	//     \item Bring down 0: \(30 \div 7 = 4\), remainder \(2\)
+	// \end{itemize}
+	// \item Step 2: Read the quotient digits from the top: 14, remainder 2
+	// \begin{itemize}
+	//     \item Check: \(14 \times 7 + 2 = 100\)
+	// \end{itemize}
+	// \begin{center}
+	// \textbf{Final Answer:} $14_{10}$ remainder $2_{10}$
+	// \end{center}
+	// \end{enumerate}
+}
@@ -0,0 +1,336 @@
+/*
+
+UTF
+
+*/
+
+package utf
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/clarketm/ncalc/i18n"
+	"github.com/clarketm/ncalc/utils"
+)
+
+// Encoding is a Unicode encoding form: code units of Unit bytes, each stored
+// in big- or little-endian byte order
+type Encoding struct {
+	Name   string
+	Title  string
+	Unit   int  // size of a code unit in bytes
+	Little bool // little-endian code units
+}
+
+var (
+	UTF8    = Encoding{utils.UTF8, "UTF-8", 1, false}
+	UTF16LE = Encoding{utils.UTF16LE, "UTF-16LE", 2, true}
+	UTF16BE = Encoding{utils.UTF16BE, "UTF-16BE", 2, false}
+	UTF32   = Encoding{utils.UTF32, "UTF-32BE", 4, false}
+	UTF32LE = Encoding{utils.UTF32LE, "UTF-32LE", 4, true}
+)
+
+// Encodings lists every supported encoding
+var Encodings = []Encoding{UTF8, UTF16LE, UTF16BE, UTF32, UTF32LE}
+
+// Layout describes an n-byte UTF-8 sequence: the largest code point it holds
+// and the marker bits of its lead byte. Continuation bytes are always 10xxxxxx.
+type Layout struct {
+	Bytes int
+	Max   rune
+	Lead  string
+}
+
+// Layouts lists the UTF-8 sequence layouts from shortest to longest
+var Layouts = []Layout{
+	{1, 0x7F, "0"},
+	{2, 0x7FF, "110"},
+	{3, 0xFFFF, "1110"},
+	{4, utf8.MaxRune, "11110"},
+}
+
+// Lookup (name string) (Encoding, bool)
+func Lookup(name string) (Encoding, bool) {
+	name = strings.ReplaceAll(strings.ToLower(name), "-", "")
+	switch name {
+	case "utf16":
+		name = utils.UTF16BE
+	case "utf32be":
+		name = utils.UTF32
+	}
+	for _, e := range Encodings {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return Encoding{}, false
+}
+
+// LayoutOf (r rune) Layout
+func LayoutOf(r rune) Layout {
+	for _, l := range Layouts {
+		if r <= l.Max {
+			return l
+		}
+	}
+	return Layouts[len(Layouts)-1]
+}
+
+// PayloadBits () int - the number of code point bits the sequence carries
+func (l Layout) PayloadBits() int {
+	return 8 - len(l.Lead) + 6*(l.Bytes-1)
+}
+
+// IsScalar (c *big.Int) bool - c is a Unicode character: not a surrogate and at most U+10FFFF
+func IsScalar(c *big.Int) bool {
+	if c.Sign() < 0 || !c.IsInt64() || c.Int64() > utf8.MaxRune {
+		return false
+	}
+	return utf8.ValidRune(rune(c.Int64()))
+}
+
+// Runes (codes []*big.Int) ([]rune, error)
+func Runes(codes []*big.Int) ([]rune, error) {
+	runes := make([]rune, len(codes))
+	for i, c := range codes {
+		if !IsScalar(c) {
+			return nil, &utils.ErrInvalidCodePoint{Pos: i + 1, Code: c}
+		}
+		runes[i] = rune(c.Int64())
+	}
+	return runes, nil
+}
+
+// Encode (codes []*big.Int) ([]byte, error)
+func (e Encoding) Encode(codes []*big.Int) ([]byte, error) {
+	runes, err := Runes(codes)
+	if err != nil {
+		return nil, err
+	}
+	return e.EncodeRunes(runes), nil
+}
+
+// EncodeRunes (runes []rune) []byte
+func (e Encoding) EncodeRunes(runes []rune) []byte {
+	var b []byte
+	for _, r := range runes {
+		b = append(b, e.EncodeRune(r)...)
+	}
+	return b
+}
+
+// EncodeRune (r rune) []byte
+func (e Encoding) EncodeRune(r rune) []byte {
+	switch e.Unit {
+	case 1:
+		return utf8.AppendRune(nil, r)
+	case 2:
+		var b []byte
+		for _, u := range utf16.Encode([]rune{r}) {
+			b = e.appendUnit(b, uint32(u))
+		}
+		return b
+	default:
+		return e.appendUnit(nil, uint32(r))
+	}
+}
+
+// Decode (b []byte) ([]rune, error)
+func (e Encoding) Decode(b []byte) ([]rune, error) {
+	if len(b) == 0 {
+		return nil, e.invalid(0, i18n.M("no bytes"))
+	}
+	if e.Unit == 1 {
+		return e.decodeUTF8(b)
+	}
+	if n := len(b) % e.Unit; n != 0 {
+		return nil, e.invalid(len(b)-n, i18n.M("%d byte(s) left over, a code unit is %d bytes", n, e.Unit))
+	}
+
+	var runes []rune
+	for off := 0; off < len(b); off += e.Unit {
+		u := e.unit(b[off:])
+		switch {
+		case e.Unit == 4:
+			if !IsScalar(big.NewInt(int64(u))) {
+				return nil, e.invalid(off, i18n.M("0x%08X is not a Unicode character", u))
+			}
+			runes = append(runes, rune(u))
+		case u >= 0xDC00 && u <= 0xDFFF:
+			return nil, e.invalid(off, i18n.M("low surrogate 0x%04X without a high surrogate", u))
+		case u >= 0xD800 && u <= 0xDBFF:
+			if off+2*e.Unit > len(b) {
+				return nil, e.invalid(off, i18n.M("high surrogate 0x%04X at the end of the input", u))
+			}
+			lo := e.unit(b[off+e.Unit:])
+			if lo < 0xDC00 || lo > 0xDFFF {
+				return nil, e.invalid(off, i18n.M("high surrogate 0x%04X followed by 0x%04X, not a low surrogate", u, lo))
+			}
+			runes = append(runes, utf16.DecodeRune(rune(u), rune(lo)))
+			off += e.Unit
+		default:
+			runes = append(runes, rune(u))
+		}
+	}
+	return runes, nil
+}
+
+// decodeUTF8 (b []byte) ([]rune, error)
+func (e Encoding) decodeUTF8(b []byte) ([]rune, error) {
+	var runes []rune
+	for off := 0; off < len(b); {
+		l, payload, ok := Lead(b[off])
+		if !ok {
+			if b[off]&0xC0 == 0x80 {
+				return nil, e.invalid(off, i18n.M("continuation byte 0x%02X without a lead byte", b[off]))
+			}
+			return nil, e.invalid(off, i18n.M("0x%02X is never valid in UTF-8", b[off]))
+		}
+
+		r := payload
+		for i := 1; i < l.Bytes; i++ {
+			if off+i >= len(b) {
+				return nil, e.invalid(off, i18n.M("%d-byte sequence cut off after %d byte(s)", l.Bytes, i))
+			}
+			if c := b[off+i]; c&0xC0 != 0x80 {
+				return nil, e.invalid(off+i, i18n.M("expected a continuation byte 10xxxxxx, found 0x%02X", c))
+			}
+			r = r<<6 | rune(b[off+i]&0x3F)
+		}
+
+		switch {
+		case l.Bytes > 1 && r <= Layouts[l.Bytes-2].Max:
+			return nil, e.invalid(off, i18n.M("overlong %d-byte encoding of U+%04X", l.Bytes, r))
+		case r > utf8.MaxRune:
+			return nil, e.invalid(off, i18n.M("U+%04X is above U+10FFFF", r))
+		case !utf8.ValidRune(r):
+			return nil, e.invalid(off, i18n.M("U+%04X is a surrogate", r))
+		}
+		runes = append(runes, r)
+		off += l.Bytes
+	}
+	return runes, nil
+}
+
+// Lead (b byte) (Layout, rune, bool) - the layout a UTF-8 lead byte starts and its payload bits
+func Lead(b byte) (Layout, rune, bool) {
+	for _, l := range Layouts {
+		n := len(l.Lead)
+		marker, _ := strconv.ParseUint(l.Lead, 2, 8)
+		if uint64(b>>(8-n)) == marker {
+			return l, rune(b & (0xFF >> n)), true
+		}
+	}
+	return Layout{}, 0, false
+}
+
+// appendUnit (b []byte, u uint32) []byte
+func (e Encoding) appendUnit(b []byte, u uint32) []byte {
+	for i := 0; i < e.Unit; i++ {
+		shift := 8 * (e.Unit - 1 - i)
+		if e.Little {
+			shift = 8 * i
+		}
+		b = append(b, byte(u>>shift))
+	}
+	return b
+}
+
+// unit (b []byte) uint32 - the code unit at the start of b
+func (e Encoding) unit(b []byte) uint32 {
+	var u uint32
+	for i := 0; i < e.Unit; i++ {
+		shift := 8 * (e.Unit - 1 - i)
+		if e.Little {
+			shift = 8 * i
+		}
+		u |= uint32(b[i]) << shift
+	}
+	return u
+}
+
+// invalid (offset int, reason string) error
+func (e Encoding) invalid(offset int, reason i18n.Message) error {
+	return &utils.ErrInvalidEncoding{Encoding: e.Title, Offset: offset, Reason: reason}
+}
+
+// ParseBytes (s string) ([]byte, error) - hex bytes such as "C3 A9", "c3a9", "0xC3 0xA9" or `\xC3\xA9`
+func ParseBytes(s string) ([]byte, error) {
+	// separators keep their width so that positions still match s
+	s = strings.NewReplacer(`\x`, "  ", `\X`, "  ", ",", " ").Replace(s)
+
+	var b []byte
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		if runes[i] == ' ' || runes[i] == '\t' || runes[i] == '\n' {
+			i++
+			continue
+		}
+		start := i
+		for i < len(runes) && runes[i] != ' ' && runes[i] != '\t' && runes[i] != '\n' {
+			i++
+		}
+		token := runes[start:i]
+		if len(token) > 2 && token[0] == '0' && (token[1] == 'x' || token[1] == 'X') {
+			token, start = token[2:], start+2
+		}
+		if len(token) > 2 && len(token)%2 != 0 {
+			return nil, &strconv.NumError{Func: "ParseBytes", Num: string(token), Err: i18n.Errorf("odd number of hex digits")}
+		}
+
+		for j := 0; j < len(token); j += 2 {
+			end := j + 2
+			if end > len(token) {
+				end = len(token)
+			}
+			var v byte
+			for k := j; k < end; k++ {
+				d, ok := utils.DigitValue(token[k], utils.HEXADECIMAL_BASE)
+				if !ok {
+					return nil, &utils.ErrInvalidDigit{Pos: start + k + 1, Rune: token[k], Base: utils.HEXADECIMAL_BASE}
+				}
+				v = v<<4 | byte(d)
+			}
+			b = append(b, v)
+		}
+	}
+	if len(b) == 0 {
+		return nil, &strconv.NumError{Func: "ParseBytes", Num: s, Err: strconv.ErrSyntax}
+	}
+	return b, nil
+}
+
+// FormatBytes (b []byte) string - upper case hex bytes separated by spaces
+func FormatBytes(b []byte) string {
+	hex := make([]string, len(b))
+	for i, c := range b {
+		hex[i] = fmt.Sprintf("%02X", c)
+	}
+	return strings.Join(hex, " ")
+}
+
+// ParseCodePoint (s string) (*big.Int, error) - U+XXXX, or plain hex
+func ParseCodePoint(s string) (*big.Int, error) {
+	if strings.HasPrefix(s, "U+") || strings.HasPrefix(s, "u+") {
+		c, err := utils.ParseRadix(s[2:], utils.HEXADECIMAL_BASE)
+		if d, ok := err.(*utils.ErrInvalidDigit); ok {
+			d.Pos += 2
+		}
+		return c, err
+	}
+	return utils.ParseRadix(s, utils.HEXADECIMAL_BASE)
+}
+
+// FormatCodePoints (runes []rune) string - U+XXXX for each rune, separated by spaces
+func FormatCodePoints(runes []rune) string {
+	codes := make([]string, len(runes))
+	for i, r := range runes {
+		codes[i] = utils.CodePoint(big.NewInt(int64(r)))
+	}
+	return strings.Join(codes, " ")
+}
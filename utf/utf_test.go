@@ -0,0 +1,47 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package utf_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/clarketm/ncalc/utf"
+)
+
+func Example() {
+
+	// UTF
+	codes := []*big.Int{big.NewInt(0xE9), big.NewInt(0x1F600)}
+	for _, e := range utf.Encodings {
+		b, _ := e.Encode(codes)
+		fmt.Printf("%s: %s\n", e.Title, utf.FormatBytes(b))
+	}
+
+	// Output:
+	// UTF-8: C3 A9 F0 9F 98 80
+	// UTF-16LE: E9 00 3D D8 00 DE
+	// UTF-16BE: 00 E9 D8 3D DE 00
+	// UTF-32BE: 00 00 00 E9 00 01 F6 00
+	// UTF-32LE: E9 00 00 00 00 F6 01 00
+}
+
+func ExampleEncoding_Decode() {
+	b, _ := utf.ParseBytes("41 C3 A9")
+	runes, err := utf.UTF8.Decode(b)
+	fmt.Println(utf.FormatCodePoints(runes), err)
+
+	b, _ = utf.ParseBytes(`\xC3\x28`)
+	_, err = utf.UTF8.Decode(b)
+	fmt.Println(err)
+
+	// Output:
+	// U+0041 U+00E9 <nil>
+	// invalid UTF-8 at byte offset 1: expected a continuation byte 10xxxxxx, found 0x28
+}
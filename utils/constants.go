@@ -5,6 +5,10 @@ const (
 	OCTAL_BASE       = 8
 	DECIMAL_BASE     = 10
 	HEXADECIMAL_BASE = 16
+	MIN_BASE         = 2
+	MAX_BASE         = 62
+
+	DEFAULT_PRECISION = 32
 
 	VERBOSE = false
 
@@ -16,6 +20,66 @@ const (
 	OCTAL       = "octal"
 	DECIMAL     = "decimal"
 	HEXADECIMAL = "hexadecimal"
+	RADIX       = "base"
+
+	TWOS_COMPLEMENT = "twos"
+	ONES_COMPLEMENT = "ones"
+	SIGN_MAGNITUDE  = "signmag"
+
+	EXPRESSION = "expression"
+
+	ADDITION       = "addition"
+	SUBTRACTION    = "subtraction"
+	MULTIPLICATION = "multiplication"
+	DIVISION       = "division"
+	BORROW         = "borrow"
+	COMPLEMENT     = "complement"
+
+	METHOD_GROUPING = "grouping"
+	METHOD_DECIMAL  = "decimal"
+	METHOD_HORNER   = "horner"
+
+	FRACTION = "fraction"
+	SEQUENCE = "sequence"
+
+	IEEE16 = "ieee16"
+	IEEE32 = "ieee32"
+	IEEE64 = "ieee64"
+	FLOAT  = "float"
+	DOUBLE = "double"
+	HALF   = "half"
+
+	UNICODE = "unicode"
+	UTF8    = "utf8"
+	UTF16LE = "utf16le"
+	UTF16BE = "utf16be"
+	UTF32   = "utf32"
+	UTF32LE = "utf32le"
+
+	BCD        = "bcd"
+	BCD_PACKED = "bcd-packed"
+	GRAY       = "gray"
+	ROMAN      = "roman"
+
+	NEGABINARY       = "negabinary"
+	BALANCED_TERNARY = "balanced-ternary"
+	BIJECTIVE        = "bijective"
+
+	ROUND_TRUNCATE   = "truncate"
+	ROUND_NEAREST    = "nearest"
+	ROUND_CONVERGENT = "convergent"
+
+	PREFIX_C         = "0x"
+	PREFIX_SUBSCRIPT = "subscript"
+
+	BIG_ENDIAN    = "big"
+	LITTLE_ENDIAN = "little"
+	BOTH_ENDIAN   = "both"
+
+	BASE64    = "base64"
+	BASE64URL = "base64url"
+	BASE32    = "base32"
+	BASE58    = "base58"
 )
 
 var ALL = []string{
@@ -24,4 +88,27 @@ var ALL = []string{
 	OCTAL,
 	DECIMAL,
 	HEXADECIMAL,
+	ROMAN,
+}
+
+// Options are the settings of a conversion. Precision is the maximum number
+// of fractional digits produced when a fraction does not terminate (or
+// repeat) in the target base. Rounding is how a value is rounded to the last
+// place of a fixed-point format: truncate, nearest or convergent. Saturate
+// clamps a value that does not fit a fixed-point format to its largest or
+// smallest word instead of reporting an overflow. Method is the step-by-step
+// method: grouping or decimal for conversions between power-of-two bases,
+// horner for conversions to decimal, borrow or complement for subtraction.
+// An empty Method means the defaults: grouping, powers of the base, and both
+// subtraction methods.
+type Options struct {
+	Precision int
+	Rounding  string
+	Saturate  bool
+	Method    string
+}
+
+// DefaultOptions () Options - 32 fractional digits, rounding to nearest, overflow reported, default step-by-step methods
+func DefaultOptions() Options {
+	return Options{Precision: DEFAULT_PRECISION, Rounding: ROUND_NEAREST}
 }
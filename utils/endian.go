@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/clarketm/ncalc/i18n"
+)
+
+// WordSizes are the supported word sizes in bytes
+var WordSizes = []int{1, 2, 4, 8}
+
+// BytesOf (s string, base int) ([]byte, error) - the big-endian bytes of the
+// non-negative integer s in base 2 or 16, with its leading zero digits kept
+// and padded to whole bytes
+func BytesOf(s string, base int) ([]byte, error) {
+	i, err := Parse(s, base)
+	if err != nil {
+		return nil, err
+	}
+	if i.Sign() < 0 {
+		return nil, &ErrOverflow{Value: s, Kind: i18n.M("byte order without --width"), Min: "0"}
+	}
+
+	// leading zero digits of a power-of-two base count towards the width, e.g. 00ff is two bytes
+	bits := i.BitLen()
+	if digits, b := Digits(strings.TrimPrefix(s, "+"), base); b&(b-1) == 0 {
+		bits = max(bits, len(digits)*(big.NewInt(int64(b-1)).BitLen()))
+	}
+	n := max((bits+7)/8, 1)
+	return i.FillBytes(make([]byte, n)), nil
+}
+
+// Aligned (b []byte, size int) bool - b is a whole number of words of size bytes
+func Aligned(b []byte, size int) bool {
+	return len(b)%size == 0
+}
+
+// Words (b []byte, size int, order string, base int) string - the big-endian
+// bytes b as space-separated words of size bytes, each written in base at full
+// width. An unaligned value is first padded with leading zero bytes. The
+// little-endian view lists the least significant word first, as a memory dump
+// of a little-endian machine read in words of that size.
+func Words(b []byte, size int, order string, base int) string {
+	if r := len(b) % size; r != 0 {
+		b = append(make([]byte, size-r), b...)
+	}
+
+	digits := len(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(8*size)), big.NewInt(1)).Text(base))
+	words := make([]string, 0, len(b)/size)
+	for i := 0; i < len(b); i += size {
+		w := new(big.Int).SetBytes(b[i : i+size]).Text(base)
+		words = append(words, strings.Repeat("0", digits-len(w))+w)
+	}
+	if order == LITTLE_ENDIAN {
+		for l, r := 0, len(words)-1; l < r; l, r = l+1, r-1 {
+			words[l], words[r] = words[r], words[l]
+		}
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,279 @@
+package utils
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/clarketm/ncalc/i18n"
+)
+
+// ErrInvalidDigit is returned when the input contains a character that is not
+// a digit of the base being parsed. Pos is the 1-based position of the rune.
+type ErrInvalidDigit struct {
+	Pos  int
+	Rune rune
+	Base int
+}
+
+func (e *ErrInvalidDigit) Localize(l i18n.Localizer) string {
+	return l.T("invalid digit %q at position %d for base %d", e.Rune, e.Pos, e.Base)
+}
+
+func (e *ErrInvalidDigit) Error() string {
+	return e.Localize(i18n.Localizer{})
+}
+
+// ErrOverflow is returned when a value does not fit in a fixed bit width.
+// Kind names the representation (e.g. "two's complement") and Min/Max give
+// the representable range when there is one; Max is empty when the range has
+// no upper end. Width is 0 when the range is not a bit width, as for Roman
+// numerals.
+type ErrOverflow struct {
+	Value string
+	Width int
+	Kind  i18n.Message
+	Min   string
+	Max   string
+}
+
+func (e *ErrOverflow) Localize(l i18n.Localizer) string {
+	s := l.T("overflow: %s does not fit in %d bits", e.Value, e.Width)
+	if e.Width == 0 {
+		s = l.T("overflow: %s is out of range for %s", e.Value, e.Kind)
+	} else if e.Kind.Msg != "" {
+		s = l.T("overflow: %s does not fit in %d-bit %s", e.Value, e.Width, e.Kind)
+	}
+	if e.Max == "" && e.Min != "" {
+		s += l.T(" (at least %s)", e.Min)
+	} else if e.Min != "" || e.Max != "" {
+		s += l.T(" (range %s to %s)", e.Min, e.Max)
+	}
+	return s
+}
+
+func (e *ErrOverflow) Error() string {
+	return e.Localize(i18n.Localizer{})
+}
+
+// ErrNotASCII is returned when text contains, or a code decodes to, a
+// character outside 7-bit ASCII. Pos is the 1-based position of the character.
+type ErrNotASCII struct {
+	Pos  int
+	Code *big.Int
+}
+
+func (e *ErrNotASCII) Localize(l i18n.Localizer) string {
+	if e.Code.Sign() < 0 || e.Code.Cmp(big.NewInt(utf8.MaxRune)) > 0 {
+		return l.T("non-ASCII code %s at position %d", e.Code, e.Pos)
+	}
+	return l.T("non-ASCII character %q (%s) at position %d", rune(e.Code.Int64()), CodePoint(e.Code), e.Pos)
+}
+
+func (e *ErrNotASCII) Error() string {
+	return e.Localize(i18n.Localizer{})
+}
+
+// ErrInvalidCode is returned when a character code is not an integer, e.g.
+// 3.5. Pos is the 1-based position of the code in a sequence.
+type ErrInvalidCode struct {
+	Pos   int
+	Value string
+}
+
+func (e *ErrInvalidCode) Localize(l i18n.Localizer) string {
+	return l.T("non-integer code %s at position %d", e.Value, e.Pos)
+}
+
+func (e *ErrInvalidCode) Error() string {
+	return e.Localize(i18n.Localizer{})
+}
+
+// ErrInvalidEncoding is returned when bytes are not valid in a Unicode encoding
+// such as UTF-8. Offset is the 0-based offset of the first bad byte.
+type ErrInvalidEncoding struct {
+	Encoding string
+	Offset   int
+	Reason   i18n.Message
+}
+
+func (e *ErrInvalidEncoding) Localize(l i18n.Localizer) string {
+	return l.T("invalid %s at byte offset %d: %s", e.Encoding, e.Offset, e.Reason)
+}
+
+func (e *ErrInvalidEncoding) Error() string {
+	return e.Localize(i18n.Localizer{})
+}
+
+// ErrInvalidCodePoint is returned for a code that is not a Unicode character:
+// a surrogate, or above U+10FFFF. Pos is the 1-based position of the code.
+type ErrInvalidCodePoint struct {
+	Pos  int
+	Code *big.Int
+}
+
+func (e *ErrInvalidCodePoint) Localize(l i18n.Localizer) string {
+	reason := l.T("above U+10FFFF")
+	if e.Code.Sign() < 0 {
+		reason = l.T("negative")
+	} else if e.Code.Cmp(big.NewInt(0xD800)) >= 0 && e.Code.Cmp(big.NewInt(0xDFFF)) <= 0 {
+		reason = l.T("a surrogate")
+	}
+	return l.T("invalid code point %s at position %d: %s", CodePoint(e.Code), e.Pos, reason)
+}
+
+func (e *ErrInvalidCodePoint) Error() string {
+	return e.Localize(i18n.Localizer{})
+}
+
+// ErrInvalidNibble is returned when a BCD nibble holds 10 to 15, which is not
+// a decimal digit. Pos is the 1-based position of its first character.
+type ErrInvalidNibble struct {
+	Pos    int
+	Nibble string
+}
+
+func (e *ErrInvalidNibble) Localize(l i18n.Localizer) string {
+	return l.T("invalid BCD nibble %s at position %d: not a decimal digit", e.Nibble, e.Pos)
+}
+
+func (e *ErrInvalidNibble) Error() string {
+	return e.Localize(i18n.Localizer{})
+}
+
+// ErrInvalidLength is returned when input has the wrong number of digits for
+// its encoding, e.g. BCD that is not a whole number of 4-bit groups. Kind
+// names the encoding and Reason says what the length must be.
+type ErrInvalidLength struct {
+	Value  string
+	Kind   i18n.Message
+	Reason i18n.Message
+}
+
+func (e *ErrInvalidLength) Localize(l i18n.Localizer) string {
+	return l.T("invalid length of %s %q: %s", e.Kind, e.Value, e.Reason)
+}
+
+func (e *ErrInvalidLength) Error() string {
+	return e.Localize(i18n.Localizer{})
+}
+
+// ErrInvalidPadding is returned when '=' padding of base64 or base32 text is
+// missing, misplaced or too long. Pos is the 1-based position of the problem.
+type ErrInvalidPadding struct {
+	Pos    int
+	Reason i18n.Message
+}
+
+func (e *ErrInvalidPadding) Localize(l i18n.Localizer) string {
+	return l.T("invalid padding at position %d: %s", e.Pos, e.Reason)
+}
+
+func (e *ErrInvalidPadding) Error() string {
+	return e.Localize(i18n.Localizer{})
+}
+
+// ErrInvalidNumeral is returned when a Roman numeral has a character that is
+// not a Roman digit or is not in canonical form (e.g. IIII for IV). Pos is the
+// 1-based position of the first offending character, and 0 for an empty numeral.
+type ErrInvalidNumeral struct {
+	Pos     int
+	Numeral string
+	Reason  i18n.Message
+}
+
+func (e *ErrInvalidNumeral) Localize(l i18n.Localizer) string {
+	if e.Numeral == "" {
+		return l.T("invalid Roman numeral: %s", e.Reason)
+	}
+	return l.T("invalid Roman numeral %s at position %d: %s", e.Numeral, e.Pos, e.Reason)
+}
+
+func (e *ErrInvalidNumeral) Error() string {
+	return e.Localize(i18n.Localizer{})
+}
+
+// ErrInvalidExpression is returned when an arithmetic expression is malformed
+// or cannot be evaluated, e.g. a division by zero. Pos is the 1-based position
+// of the offending character or operator.
+type ErrInvalidExpression struct {
+	Pos    int
+	Reason i18n.Message
+}
+
+func (e *ErrInvalidExpression) Localize(l i18n.Localizer) string {
+	return l.T("invalid expression at position %d: %s", e.Pos, e.Reason)
+}
+
+func (e *ErrInvalidExpression) Error() string {
+	return e.Localize(i18n.Localizer{})
+}
+
+// CodePoint (c *big.Int) string - c in U+XXXX notation
+func CodePoint(c *big.Int) string {
+	if c.Sign() < 0 {
+		return c.String()
+	}
+	return fmt.Sprintf("U+%04X", c)
+}
+
+// DigitValue (r rune, base int) (int, bool)
+func DigitValue(r rune, base int) (int, bool) {
+	var d int
+	switch {
+	case '0' <= r && r <= '9':
+		d = int(r - '0')
+	case 'a' <= r && r <= 'z':
+		d = int(r-'a') + 10
+	case 'A' <= r && r <= 'Z':
+		// upper and lower case are the same digit up to base 36, distinct above it
+		d = int(r-'A') + 10
+		if base > 36 {
+			d += 26
+		}
+	default:
+		return 0, false
+	}
+	return d, d < base
+}
+
+// invalidDigit (s string, base int, fraction bool) error - the first rune of s that is not a digit of base
+func invalidDigit(s string, base int, fraction bool) error {
+	offset := 0
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		offset = 1
+	}
+
+	// base 0 means the base comes from a literal prefix
+	if base == 0 {
+		lower := strings.ToLower(s[offset:])
+		switch {
+		case strings.HasPrefix(lower, "0x"):
+			base, offset = HEXADECIMAL_BASE, offset+2
+		case strings.HasPrefix(lower, "0b"):
+			base, offset = BINARY_BASE, offset+2
+		case strings.HasPrefix(lower, "0o"):
+			base, offset = OCTAL_BASE, offset+2
+		case strings.HasPrefix(lower, "0"):
+			base = OCTAL_BASE
+		default:
+			base = DECIMAL_BASE
+		}
+	}
+
+	point := !fraction
+	for i, r := range []rune(s) {
+		if i < offset {
+			continue
+		}
+		if r == '.' && !point {
+			point = true
+			continue
+		}
+		if _, ok := DigitValue(r, base); !ok {
+			return &ErrInvalidDigit{Pos: i + 1, Rune: r, Base: base}
+		}
+	}
+	return nil
+}
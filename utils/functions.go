@@ -2,32 +2,22 @@ package utils
 
 import (
 	"fmt"
-	"os"
-	"reflect"
+	"math/big"
 	"regexp"
 	"strconv"
-)
+	"strings"
+	"unicode/utf8"
 
-// Invoke (fn interface{}, args ...interface{}) interface{}
-func Invoke(fn interface{}, args ...interface{}) interface{} {
-	v := reflect.ValueOf(fn)
-	rargs := make([]reflect.Value, len(args))
-	for i, a := range args {
-		rargs[i] = reflect.ValueOf(a)
-	}
-	defer recover()
-	return v.Call(rargs)[0]
-}
+	"github.com/clarketm/ncalc/i18n"
+)
 
-// CheckError (err error, formatSrc, formatDst string)
+// CheckError (err error, formatSrc, formatDst string) - panics with err wrapped in
+// "error converting formatSrc to formatDst" when err is not nil. Only the non-E
+// wrappers such as roman.Decimal2Roman use it; callers that can fail on user input
+// should call the E variant and handle the error.
 func CheckError(err error, formatSrc, formatDst string) {
 	if err != nil {
-		if VERBOSE {
-			fmt.Fprintln(os.Stderr, "There was an error converting", formatSrc, "to", formatDst, fmt.Sprintf("\n%v", err))
-		} else {
-			fmt.Fprintln(os.Stderr, "There was an error converting", formatSrc, "to", formatDst)
-		}
-		os.Exit(1)
+		panic(fmt.Errorf("error converting %s to %s: %w", formatSrc, formatDst, err))
 	}
 }
 
@@ -43,63 +33,238 @@ func IsNumeric(s string) bool {
 	if _, err = strconv.ParseFloat(s, 0); err == nil {
 		return true
 	}
-	if _, err = strconv.ParseInt(s, 0, 0); err == nil {
-		return true
+	for _, base := range []int{0, BINARY_BASE, OCTAL_BASE, DECIMAL_BASE, HEXADECIMAL_BASE} {
+		if _, ok := new(big.Int).SetString(s, base); ok {
+			return true
+		}
 	}
-	if _, err = strconv.ParseInt(s, BINARY_BASE, 0); err == nil {
-		return true
+	return false
+}
+
+// IsDecimal (f string) bool
+func IsDecimal(s string) bool {
+	if IsFraction(s) {
+		_, err := ParseFraction(s, DECIMAL_BASE)
+		return err == nil
 	}
-	if _, err = strconv.ParseInt(s, OCTAL_BASE, 0); err == nil {
-		return true
+	_, ok := new(big.Int).SetString(s, DECIMAL_BASE)
+	return ok
+}
+
+// IsFraction (s string) bool
+func IsFraction(s string) bool {
+	return strings.Contains(s, ".")
+}
+
+// Parse (s string, base int) (*big.Int, error) - s in base, or a literal such as 0x1F when s is not;
+// a suffixed literal that starts with a letter, such as FFh, only in its own base
+func Parse(s string, base int) (*big.Int, error) {
+	if !isDigits(s, base) {
+		lit, err := ParseLiteral(s)
+		if err == nil {
+			return ParseRadix(lit.Digits, lit.Base)
+		}
+		if err != ErrNotLiteral {
+			return nil, err
+		}
+		if lit, ok := suffixIn(s, base); ok {
+			return ParseRadix(lit.Digits, lit.Base)
+		}
 	}
-	if _, err = strconv.ParseInt(s, DECIMAL_BASE, 0); err == nil {
-		return true
+	return ParseRadix(s, base)
+}
+
+// ParseRadix (s string, base int) (*big.Int, error)
+func ParseRadix(s string, base int) (*big.Int, error) {
+	i, ok := new(big.Int).SetString(s, base)
+	if !ok {
+		if err := invalidDigit(s, base, false); err != nil {
+			return nil, err
+		}
+		return nil, &strconv.NumError{Func: "Parse", Num: s, Err: strconv.ErrSyntax}
 	}
-	if _, err = strconv.ParseInt(s, HEXADECIMAL_BASE, 0); err == nil {
-		return true
+	return i, nil
+}
+
+// ParseFraction (s string, base int) (*big.Rat, error)
+func ParseFraction(s string, base int) (*big.Rat, error) {
+	syntaxError := &strconv.NumError{Func: "ParseFraction", Num: s, Err: strconv.ErrSyntax}
+
+	sign := ""
+	digits := s
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		sign, digits = digits[:1], digits[1:]
 	}
-	return false
+	intPart, fracPart, _ := strings.Cut(digits, ".")
+	if intPart+fracPart == "" {
+		return nil, syntaxError
+	}
+	if err := invalidDigit(s, base, true); err != nil {
+		return nil, err
+	}
+
+	// (intPart * base^len(fracPart) + fracPart) / base^len(fracPart)
+	num, ok := new(big.Int).SetString(sign+intPart+fracPart, base)
+	if !ok {
+		if err := invalidDigit(s, base, true); err != nil {
+			return nil, err
+		}
+		return nil, syntaxError
+	}
+	den := new(big.Int).Exp(big.NewInt(int64(base)), big.NewInt(int64(len(fracPart))), nil)
+	return new(big.Rat).SetFrac(num, den), nil
 }
 
-// IsDecimal (f string) bool
-func IsDecimal(s string) bool {
-	if _, err := strconv.ParseInt(s, DECIMAL_BASE, 0); err == nil {
-		return true
+// FractionDigits (num, den *big.Int, base, precision int) ([]int64, int, bool)
+func FractionDigits(num, den *big.Int, base, precision int) ([]int64, int, bool) {
+	var digits []int64
+	seen := map[string]int{}
+	b := big.NewInt(int64(base))
+	rem := new(big.Int).Set(num)
+
+	for rem.Sign() != 0 {
+		if start, ok := seen[rem.String()]; ok {
+			return digits, start, false
+		}
+		if len(digits) == precision {
+			return digits, -1, true
+		}
+		seen[rem.String()] = len(digits)
+
+		digit := new(big.Int)
+		digit.QuoRem(rem.Mul(rem, b), den, rem)
+		digits = append(digits, digit.Int64())
 	}
-	return false
+	return digits, -1, false
+}
+
+// FormatFraction (r *big.Rat, base, precision int) string
+func FormatFraction(r *big.Rat, base, precision int) string {
+	sign := ""
+	if r.Sign() < 0 {
+		sign = "-"
+	}
+	intPart, rem := new(big.Int).QuoRem(new(big.Int).Abs(r.Num()), r.Denom(), new(big.Int))
+	s := sign + intPart.Text(base)
+	if rem.Sign() == 0 {
+		return s
+	}
+
+	digits, repeat, truncated := FractionDigits(rem, r.Denom(), base, precision)
+	s += "."
+	for i, d := range digits {
+		if i == repeat {
+			s += "("
+		}
+		s += big.NewInt(d).Text(base)
+	}
+	if repeat >= 0 {
+		s += ")"
+	}
+	if truncated {
+		s += "..."
+	}
+	return s
+}
+
+// ConvertFraction (s string, from, to int) string - ConvertFractionE, but panics if it fails
+func ConvertFraction(s string, from, to int) string {
+	r, err := ConvertFractionE(s, from, to)
+	CheckError(err, RadixFormat(from), RadixFormat(to))
+	return r
 }
 
-// IsLiteral (s string) bool
-func IsLiteral(s string) bool {
-	var octalLiteral = regexp.MustCompile(`^0[0-7]+$`)
-	var hexLiteral = regexp.MustCompile(`^0[xX][0-9a-zA-Z]+$`)
-	return octalLiteral.MatchString(s) || hexLiteral.MatchString(s)
+// ConvertFractionE (s string, from, to int) (string, error) - s in base to, with at most DEFAULT_PRECISION fractional digits
+func ConvertFractionE(s string, from, to int) (string, error) {
+	r, err := ParseFraction(s, from)
+	if err != nil {
+		return "", err
+	}
+	return FormatFraction(r, to, DEFAULT_PRECISION), nil
+}
+
+// RadixFormat (base int) string
+func RadixFormat(base int) string {
+	switch base {
+	case BINARY_BASE:
+		return BINARY
+	case OCTAL_BASE:
+		return OCTAL
+	case DECIMAL_BASE:
+		return DECIMAL
+	case HEXADECIMAL_BASE:
+		return HEXADECIMAL
+	}
+	return fmt.Sprintf("%s:%d", RADIX, base)
+}
+
+// BaseOf (format string) (int, bool)
+func BaseOf(format string) (int, bool) {
+	switch format {
+	case BINARY:
+		return BINARY_BASE, true
+	case OCTAL:
+		return OCTAL_BASE, true
+	case DECIMAL:
+		return DECIMAL_BASE, true
+	case HEXADECIMAL:
+		return HEXADECIMAL_BASE, true
+	}
+	if !strings.HasPrefix(format, RADIX+":") {
+		return 0, false
+	}
+	base, err := strconv.Atoi(strings.TrimPrefix(format, RADIX+":"))
+	if err != nil || base < MIN_BASE || base > MAX_BASE {
+		return 0, false
+	}
+	return base, true
 }
 
-// Parse (s string, base int) (int64, error)
-func Parse(s string, base int) (int64, error) {
-	if IsLiteral(s) {
-		return strconv.ParseInt(s, 0, 0)
-	} else {
-		return strconv.ParseInt(s, base, 0)
+// ToRune (i *big.Int) rune
+func ToRune(i *big.Int) rune {
+	if !i.IsInt64() || i.Int64() < 0 || i.Int64() > utf8.MaxRune {
+		return utf8.RuneError
+	}
+	return rune(i.Int64())
+}
+
+// Fields (s string) []string - the whitespace-separated codes of s, or s itself when blank so that it fails to parse
+func Fields(s string) []string {
+	if f := strings.Fields(s); len(f) > 0 {
+		return f
+	}
+	return []string{s}
+}
+
+// QuoteASCII (codes []*big.Int) (string, error) - a quoted character, or a quoted string for several codes
+func QuoteASCII(codes []*big.Int) (string, error) {
+	text := make([]rune, len(codes))
+	for i, c := range codes {
+		if c.Sign() < 0 || c.Cmp(big.NewInt(utf8.RuneSelf)) >= 0 {
+			return "", &ErrNotASCII{Pos: i + 1, Code: c}
+		}
+		text[i] = rune(c.Int64())
+	}
+	if len(text) == 1 {
+		return fmt.Sprintf("%q", text[0]), nil
 	}
+	return strconv.Quote(string(text)), nil
 }
 
-// CheckType (v interface{}, f string)
-func CheckType(v interface{}, f string) {
+// CheckType (v interface{}, f string) error
+func CheckType(v interface{}, f string) error {
 
 	switch v.(type) {
 	case int:
 		if IsAscii(f) {
-			fmt.Fprintln(os.Stderr, "Invalid ascii character", fmt.Sprintf("%d", v.(int)))
-			os.Exit(1)
+			return i18n.Errorf("invalid ascii character %d", v.(int))
 		}
 	case int32:
 		if IsNumeric(f) {
-			fmt.Fprintln(os.Stderr, "Invalid integer", fmt.Sprintf("%q", v.(int32)))
-			os.Exit(1)
+			return i18n.Errorf("invalid integer %q", v.(int32))
 		}
 	}
+	return nil
 }
 
 // IsValidBinary (s string) bool
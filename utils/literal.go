@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// Literal is a number written with a marker for its base, such as 0x1F, 1Fh,
+// &H1F or #1F, or a plain reading of digits in one base. Digits holds the
+// sign and digits without the marker.
+type Literal struct {
+	Base   int
+	Digits string
+	Marker string
+	Suffix bool
+}
+
+// ErrNotLiteral is returned by ParseLiteral when s has no base marker
+var ErrNotLiteral = errors.New("not a base literal")
+
+// literalPrefixes are the base prefixes; a strict prefix (0x, 0o, 0b) makes
+// bad digits an error, any other prefix just means s is not a literal
+var literalPrefixes = []struct {
+	marker string
+	base   int
+	strict bool
+}{
+	{"0x", HEXADECIMAL_BASE, true},
+	{"0o", OCTAL_BASE, true},
+	{"0b", BINARY_BASE, true},
+	{"&h", HEXADECIMAL_BASE, false},
+	{"&o", OCTAL_BASE, false},
+	{"&b", BINARY_BASE, false},
+	{"#", HEXADECIMAL_BASE, false},
+}
+
+// literalSuffixes are the assembler base suffixes, as in 1Fh or 1010b
+var literalSuffixes = []struct {
+	marker string
+	base   int
+}{
+	{"h", HEXADECIMAL_BASE},
+	{"b", BINARY_BASE},
+	{"o", OCTAL_BASE},
+	{"q", OCTAL_BASE},
+	{"d", DECIMAL_BASE},
+}
+
+// ParseLiteral (s string) (Literal, error) - s written with a base prefix (0x,
+// 0o, 0b, &H, &O, &B, #) or suffix (h, b, o, q, d), in either case. A suffixed
+// literal must start with a decimal digit, as in 0FFh.
+func ParseLiteral(s string) (Literal, error) {
+	sign, body := "", s
+	if strings.HasPrefix(body, "-") || strings.HasPrefix(body, "+") {
+		sign, body = body[:1], body[1:]
+	}
+	lower := strings.ToLower(body)
+
+	for _, p := range literalPrefixes {
+		digits := body[min(len(p.marker), len(body)):]
+		if !strings.HasPrefix(lower, p.marker) || digits == "" {
+			continue
+		}
+		err := invalidDigit(digits, p.base, false)
+		if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+			// the sign goes before the prefix, as in -0x5, never after it
+			err = &ErrInvalidDigit{Pos: 1, Rune: rune(digits[0]), Base: p.base}
+		}
+		if err != nil {
+			if !p.strict {
+				return Literal{}, ErrNotLiteral
+			}
+			// report the position within the whole literal
+			var digitErr *ErrInvalidDigit
+			if errors.As(err, &digitErr) {
+				digitErr.Pos += len(sign) + len(p.marker)
+			}
+			return Literal{}, err
+		}
+		return Literal{Base: p.base, Digits: sign + digits, Marker: body[:len(p.marker)]}, nil
+	}
+
+	if body == "" || body[0] < '0' || body[0] > '9' {
+		return Literal{}, ErrNotLiteral
+	}
+	return suffixLiteral(sign, body)
+}
+
+// suffixLiteral (sign, body string) (Literal, error) - body read as digits followed by a base suffix
+func suffixLiteral(sign, body string) (Literal, error) {
+	lower := strings.ToLower(body)
+	for _, p := range literalSuffixes {
+		if !strings.HasSuffix(lower, p.marker) || len(body) < 2 {
+			continue
+		}
+		digits := body[:len(body)-len(p.marker)]
+		if !strings.HasPrefix(digits, "-") && !strings.HasPrefix(digits, "+") && invalidDigit(digits, p.base, false) == nil {
+			return Literal{Base: p.base, Digits: sign + digits, Marker: body[len(digits):], Suffix: true}, nil
+		}
+	}
+	return Literal{}, ErrNotLiteral
+}
+
+// IsLiteral (s string) bool
+func IsLiteral(s string) bool {
+	_, err := ParseLiteral(s)
+	return err == nil
+}
+
+// Interpretations (s string) []Literal - the ways to read s as an integer: a
+// literal with a base prefix is read only in that base; otherwise a literal
+// with a suffix, then every base of 2, 8, 10 and 16 whose digits s has as it
+// stands. A suffixed literal that starts with a letter, such as FFh, is not a
+// literal to ParseLiteral but is still a possible reading here.
+func Interpretations(s string) []Literal {
+	lit, err := ParseLiteral(s)
+	if err == nil && !lit.Suffix {
+		return []Literal{lit}
+	}
+	if err == ErrNotLiteral {
+		sign, body := "", s
+		if strings.HasPrefix(body, "-") || strings.HasPrefix(body, "+") {
+			sign, body = body[:1], body[1:]
+		}
+		lit, err = suffixLiteral(sign, body)
+	}
+
+	var readings []Literal
+	if err == nil {
+		readings = append(readings, lit)
+	}
+	for _, base := range []int{BINARY_BASE, OCTAL_BASE, DECIMAL_BASE, HEXADECIMAL_BASE} {
+		if isDigits(s, base) {
+			readings = append(readings, Literal{Base: base, Digits: s})
+		}
+	}
+	return readings
+}
+
+// Value () *big.Int - the integer the literal spells
+func (l Literal) Value() *big.Int {
+	i, _ := new(big.Int).SetString(l.Digits, l.Base)
+	return i
+}
+
+// String () string - the literal as written
+func (l Literal) String() string {
+	sign, digits := "", l.Digits
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		sign, digits = digits[:1], digits[1:]
+	}
+	if l.Suffix {
+		return sign + digits + l.Marker
+	}
+	return sign + l.Marker + digits
+}
+
+// Digits (s string, base int) (string, int) - the digits of s and their base:
+// s itself when it is valid in base, otherwise the digits of a literal such
+// as 0x1F or 1Fh in the base the literal names. A suffixed literal that
+// starts with a letter, such as FFh, is read only when base is its base.
+func Digits(s string, base int) (string, int) {
+	if isDigits(s, base) {
+		return s, base
+	}
+	if lit, err := ParseLiteral(s); err == nil {
+		return lit.Digits, lit.Base
+	}
+	if lit, ok := suffixIn(s, base); ok {
+		return lit.Digits, lit.Base
+	}
+	return s, base
+}
+
+// suffixIn (s string, base int) (Literal, bool) - s read as a suffixed literal of base, such as FFh in base 16
+func suffixIn(s string, base int) (Literal, bool) {
+	sign, body := "", s
+	if strings.HasPrefix(body, "-") || strings.HasPrefix(body, "+") {
+		sign, body = body[:1], body[1:]
+	}
+	lit, err := suffixLiteral(sign, body)
+	return lit, err == nil && lit.Base == base
+}
+
+// isDigits (s string, base int) bool - s is a sign and at least one digit of base
+func isDigits(s string, base int) bool {
+	digits := strings.TrimLeft(s, "+-")
+	if digits == "" || len(s)-len(digits) > 1 {
+		return false
+	}
+	return invalidDigit(s, base, false) == nil
+}
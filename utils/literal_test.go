@@ -0,0 +1,77 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package utils_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/clarketm/ncalc/utils"
+)
+
+func ExampleParseLiteral() {
+
+	// LITERAL
+	for _, s := range []string{"0b1010", "0o17", "-0x1F", "1Fh", "1010b", "&H1F", "#FF", "0xZZ", "0x-5", "&H-5", "FFh", "10"} {
+		lit, err := utils.ParseLiteral(s)
+		if err != nil {
+			fmt.Println(s, err)
+			continue
+		}
+		fmt.Println(s, lit.Base, lit.Value())
+	}
+
+	// Output:
+	// 0b1010 2 10
+	// 0o17 8 15
+	// -0x1F 16 -31
+	// 1Fh 16 31
+	// 1010b 2 10
+	// &H1F 16 31
+	// #FF 16 255
+	// 0xZZ invalid digit 'Z' at position 3 for base 16
+	// 0x-5 invalid digit '-' at position 3 for base 16
+	// &H-5 not a base literal
+	// FFh not a base literal
+	// 10 not a base literal
+}
+
+func ExampleInterpretations() {
+
+	// LITERAL (ambiguous input)
+	for _, s := range []string{"10", "1010b", "0x10", "9", "FFh"} {
+		var readings []string
+		for _, r := range utils.Interpretations(s) {
+			readings = append(readings, fmt.Sprintf("%s=%s", utils.RadixFormat(r.Base), r.Value()))
+		}
+		fmt.Println(strings.Join(readings, " "))
+	}
+
+	// Output:
+	// binary=2 octal=8 decimal=10 hexadecimal=16
+	// binary=10 hexadecimal=65803
+	// hexadecimal=16
+	// decimal=9 hexadecimal=9
+	// hexadecimal=255
+}
+
+func ExampleParse() {
+
+	// LITERAL (a leading zero is not octal)
+	fmt.Println(utils.Parse("0101", utils.BINARY_BASE))
+	fmt.Println(utils.Parse("0101", utils.DECIMAL_BASE))
+	fmt.Println(utils.Parse("0x1F", utils.DECIMAL_BASE))
+	fmt.Println(utils.Parse("FFh", utils.HEXADECIMAL_BASE))
+
+	// Output:
+	// 5 <nil>
+	// 101 <nil>
+	// 31 <nil>
+	// 255 <nil>
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Presentation describes how a number in a base is shown. Prefix is "",
+// PREFIX_C (0b, 0o and 0x for bases 2, 8 and 16) or PREFIX_SUBSCRIPT (the
+// base as a subscript, e.g. ff₁₆). Pad is the minimum number of integer
+// digits, filled with leading zeros. Group splits the integer digits into
+// groups of that size from the right, joined by Separator: by default ","
+// for groups of 3 (thousands) and "_" otherwise (e.g. nibbles). Upper writes
+// letter digits in upper case where case does not matter (bases up to 36).
+type Presentation struct {
+	Prefix    string
+	Pad       int
+	Group     int
+	Separator string
+	Upper     bool
+}
+
+// subscripts are the subscript forms of the digits 0-9
+var subscripts = []rune("₀₁₂₃₄₅₆₇₈₉")
+
+// Present (s string, base int) string - every space-separated number of s shown as p describes
+func (p Presentation) Present(s string, base int) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return s
+	}
+	for i, f := range fields {
+		fields[i] = p.present(f, base)
+	}
+	return strings.Join(fields, " ")
+}
+
+// present (s string, base int) string
+func (p Presentation) present(s string, base int) string {
+	sign, digits := "", s
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+
+	// only numbers are changed, not e.g. NaN or a character
+	for _, r := range digits {
+		if _, ok := DigitValue(r, base); !ok && !strings.ContainsRune(".()", r) {
+			return s
+		}
+	}
+
+	intPart, fracPart, point := strings.Cut(digits, ".")
+	if len(intPart) < p.Pad {
+		intPart = strings.Repeat("0", p.Pad-len(intPart)) + intPart
+	}
+	if p.Group > 0 {
+		sep := p.Separator
+		if sep == "" {
+			sep = "_"
+			if p.Group == 3 {
+				sep = ","
+			}
+		}
+		intPart = group(intPart, p.Group, sep)
+	}
+	digits = intPart
+	if point {
+		digits += "." + fracPart
+	}
+	if p.Upper && base <= 36 {
+		digits = strings.ToUpper(digits)
+	}
+
+	switch p.Prefix {
+	case PREFIX_C:
+		switch base {
+		case BINARY_BASE:
+			digits = "0b" + digits
+		case OCTAL_BASE:
+			digits = "0o" + digits
+		case HEXADECIMAL_BASE:
+			digits = "0x" + digits
+		}
+	case PREFIX_SUBSCRIPT:
+		for _, d := range []rune(strconv.Itoa(base)) {
+			digits += string(subscripts[d-'0'])
+		}
+	}
+	return sign + digits
+}
+
+// group (s string, size int, sep string) string - s split into groups of size from the right
+func group(s string, size int, sep string) string {
+	var groups []string
+	for len(s) > size {
+		groups = append([]string{s[len(s)-size:]}, groups...)
+		s = s[:len(s)-size]
+	}
+	return strings.Join(append([]string{s}, groups...), sep)
+}
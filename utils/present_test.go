@@ -0,0 +1,32 @@
+/*
+
+Copyright 2018 Travis Clarke. All rights reserved.
+Use of this source code is governed by a Apache-2.0
+license that can be found in the LICENSE file.
+
+*/
+
+package utils_test
+
+import (
+	"fmt"
+
+	"github.com/clarketm/ncalc/utils"
+)
+
+func ExamplePresentation_Present() {
+
+	// PRESENTATION
+	fmt.Println(utils.Presentation{Prefix: utils.PREFIX_C, Upper: true}.Present("ff", 16))
+	fmt.Println(utils.Presentation{Pad: 16, Group: 4}.Present("1111101000", 2))
+	fmt.Println(utils.Presentation{Group: 3}.Present("-1234567.25", 10))
+	fmt.Println(utils.Presentation{Prefix: utils.PREFIX_SUBSCRIPT}.Present("48 69", 16))
+	fmt.Println(utils.Presentation{Upper: true}.Present("NaN", 10))
+
+	// Output:
+	// 0xFF
+	// 0000_0011_1110_1000
+	// -1,234,567.25
+	// 48₁₆ 69₁₆
+	// NaN
+}